@@ -17,20 +17,29 @@ package groupmembership
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	abstractions "github.com/microsoft/kiota-abstractions-go"
+	azauth "github.com/microsoft/kiota-authentication-azure-go"
 	msgraphsdkgo "github.com/microsoftgraph/msgraph-sdk-go"
 	msgraphsdkgocore "github.com/microsoftgraph/msgraph-sdk-go-core"
 	"github.com/microsoftgraph/msgraph-sdk-go/groups"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
 	"github.com/microsoftgraph/msgraph-sdk-go/users"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	gosync "github.com/ovotech/go-sync"
 )
@@ -38,6 +47,15 @@ import (
 // GroupName is the name of your group within Azure AD.
 const GroupName gosync.ConfigKey = "group_name"
 
+// graphScope is the OAuth scope requested for the Microsoft Graph API.
+const graphScope = "https://graph.microsoft.com/.default"
+
+// writePermissions are the Graph permissions that allow the members of a group to be changed, any one of which is
+// enough.
+//
+//nolint:gochecknoglobals
+var writePermissions = []string{"GroupMember.ReadWrite.All", "Group.ReadWrite.All", "Directory.ReadWrite.All"}
+
 type iClient interface {
 	GetAdapter() abstractions.RequestAdapter
 }
@@ -65,10 +83,11 @@ var _ iUserClient = &users.UsersRequestBuilder{}
 
 type GroupMembership struct {
 	client      iClient
+	credential  azcore.TokenCredential // credential is used by Validate to check the token's permissions.
 	groupClient iGroupClient
 	userClient  iUserClient
 
-	Logger *log.Logger
+	Logger *slog.Logger
 
 	group string
 
@@ -93,9 +112,11 @@ type GroupMembership struct {
 
 // Get will return the membership of the group.
 func (g *GroupMembership) Get(ctx context.Context) ([]string, error) {
+	g.Logger.Info("Fetching members of Azure AD group")
+
 	gid, err := resolveGroupID(ctx, g.groupClient, g.group)
 	if err != nil {
-		return nil, fmt.Errorf("azuread.groupmembership(%s).get -> %w", g.group, resolveOdataError(err))
+		return nil, gosync.NewError(g, gosync.PhaseGet, nil, fmt.Errorf("resolvegroupid -> %w", resolveOdataError(err)))
 	}
 
 	req := groups.ItemMembersRequestBuilderGetRequestConfiguration{
@@ -107,7 +128,7 @@ func (g *GroupMembership) Get(ctx context.Context) ([]string, error) {
 	resp, err := g.getGroupMembers(ctx, g.groupClient.ByGroupId(gid), to.Ptr(req))
 	// resp, err := g.groupClient.ByGroupId(gid).Members().Get(ctx, to.Ptr(req))
 	if err != nil {
-		return nil, fmt.Errorf("azuread.groupmembership(%s).get -> %w", g.group, resolveOdataError(err))
+		return nil, gosync.NewError(g, gosync.PhaseGet, nil, fmt.Errorf("members -> %w", resolveOdataError(err)))
 	}
 
 	pageIterator, err := msgraphsdkgocore.NewPageIterator[models.Userable](
@@ -116,7 +137,7 @@ func (g *GroupMembership) Get(ctx context.Context) ([]string, error) {
 		models.CreateGroupCollectionResponseFromDiscriminatorValue,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("azuread.groupmembership(%s).get -> %w", g.group, resolveOdataError(err))
+		return nil, gosync.NewError(g, gosync.PhaseGet, nil, fmt.Errorf("pageiterator -> %w", resolveOdataError(err)))
 	}
 
 	emails := make([]string, 0)
@@ -133,9 +154,11 @@ func (g *GroupMembership) Get(ctx context.Context) ([]string, error) {
 		return true
 	})
 	if err != nil {
-		return nil, fmt.Errorf("azuread.groupmembership(%s).get -> %w", g.group, resolveOdataError(err))
+		return nil, gosync.NewError(g, gosync.PhaseGet, nil, fmt.Errorf("iterate -> %w", resolveOdataError(err)))
 	}
 
+	g.Logger.Info("Fetched members successfully", slog.Int(gosync.LogKeyCount, len(emails)))
+
 	return emails, nil
 }
 
@@ -146,13 +169,11 @@ const addBatchSize = 20
 
 // Add will add the given members to the group's member list.
 func (g *GroupMembership) Add(ctx context.Context, members []string) error {
+	g.Logger.Info("Adding members to Azure AD group", slog.Int(gosync.LogKeyCount, len(members)))
+
 	gid, err := resolveGroupID(ctx, g.groupClient, g.group)
 	if err != nil {
-		return fmt.Errorf(
-			"azuread.groupmembership(%s).add.resolveGroupID -> %w",
-			g.group,
-			resolveOdataError(err),
-		)
+		return gosync.NewError(g, gosync.PhaseAdd, members, fmt.Errorf("resolvegroupid -> %w", resolveOdataError(err)))
 	}
 
 	var payloads [][]string
@@ -167,14 +188,14 @@ func (g *GroupMembership) Add(ctx context.Context, members []string) error {
 		payload := make([]string, 0, end-start)
 
 		for _, member := range members[start:end] {
+			g.Logger.Debug("Adding member", slog.String(gosync.LogKeyThing, member))
+
 			uid, err := resolveUserID(ctx, g.userClient, member)
 			if err != nil {
-				return fmt.Errorf(
-					"azuread.groupmembership(%s).add(%s).resolveUserID -> %w",
-					g.group,
-					member,
+				return gosync.NewError(g, gosync.PhaseAdd, []string{member}, fmt.Errorf(
+					"resolveuserid -> %w",
 					resolveOdataError(err),
-				)
+				))
 			}
 
 			payload = append(payload, "https://graph.microsoft.com/v1.0/directoryObjects/"+uid)
@@ -184,55 +205,60 @@ func (g *GroupMembership) Add(ctx context.Context, members []string) error {
 	}
 
 	for _, payload := range payloads {
-		req := models.NewGroup()
-		req.SetAdditionalData(map[string]interface{}{
-			"members@odata.bind": payload,
-		})
-
-		_, err := g.patchGroup(ctx, g.groupClient.ByGroupId(gid), req, nil)
-		// _, err := g.groupClient.ByGroupId(gid).Patch(ctx, req, nil)
+		err := g.addMembers(ctx, g.groupClient.ByGroupId(gid), payload)
 		if err != nil {
-			return fmt.Errorf("azuread.groupmembership(%s).add.patch -> %w", g.group, resolveOdataError(err))
+			return gosync.NewError(g, gosync.PhaseAdd, members, fmt.Errorf("patch -> %w", resolveOdataError(err)))
 		}
 	}
 
+	g.Logger.Info("Finished adding members successfully")
+
 	return nil
 }
 
 // Remove will remove the given email addresses from the group's member list.
 func (g *GroupMembership) Remove(ctx context.Context, members []string) error {
+	g.Logger.Info("Removing members from Azure AD group", slog.Int(gosync.LogKeyCount, len(members)))
+
 	gid, err := resolveGroupID(ctx, g.groupClient, g.group)
 	if err != nil {
-		return fmt.Errorf(
-			"azuread.groupmembership(%s).remove.resolveGroupID -> %w",
-			g.group,
+		return gosync.NewError(g, gosync.PhaseRemove, members, fmt.Errorf(
+			"resolvegroupid -> %w",
 			resolveOdataError(err),
-		)
+		))
 	}
 
 	for _, member := range members {
+		g.Logger.Debug("Removing member", slog.String(gosync.LogKeyThing, member))
+
 		uid, err := resolveUserID(ctx, g.userClient, member)
-		if err != nil {
-			return fmt.Errorf(
-				"azuread.groupmembership(%s).remove(%s).resolveUserID -> %w",
-				g.group,
-				member,
+		if errors.Is(err, ErrNoResults) {
+			g.Logger.Debug("User doesn't exist, skipping", slog.String(gosync.LogKeyThing, member))
+
+			continue
+		} else if err != nil {
+			return gosync.NewError(g, gosync.PhaseRemove, []string{member}, fmt.Errorf(
+				"resolveuserid -> %w",
 				resolveOdataError(err),
-			)
+			))
 		}
 
 		err = g.removeGroupMember(ctx, g.groupClient.ByGroupId(gid), uid, nil)
 		// err = g.groupClient.ByGroupId(gid).Members().ByDirectoryObjectId(uid).Ref().Delete(ctx, nil)
-		if err != nil {
-			return fmt.Errorf(
-				"azuread.groupmembership(%s).remove(%s).delete -> %w",
-				g.group,
-				member,
+		if isNotMember(err) {
+			g.Logger.Debug("User isn't a member, skipping", slog.String(gosync.LogKeyThing, member))
+
+			continue
+		} else if err != nil {
+			return gosync.NewError(g, gosync.PhaseRemove, []string{member}, fmt.Errorf(
+				"delete -> %w",
 				resolveOdataError(err),
-			)
+			))
 		}
 	}
 
+	g.Logger.Info("Finished removing members successfully")
+
 	return nil
 }
 
@@ -244,6 +270,70 @@ var ErrTooManyResults = errors.New("too many results in response")
 // were expected.
 var ErrNoResults = errors.New("no results found")
 
+/*
+addMembers binds a batch of directory objects to a group. Graph rejects the whole batch if any of them are already
+members, so each object is then retried on its own and existing members are skipped.
+*/
+func (g *GroupMembership) addMembers(
+	ctx context.Context,
+	builder *groups.GroupItemRequestBuilder,
+	refs []string,
+) error {
+	req := models.NewGroup()
+	req.SetAdditionalData(map[string]interface{}{
+		"members@odata.bind": refs,
+	})
+
+	_, err := g.patchGroup(ctx, builder, req, nil)
+	// _, err := g.groupClient.ByGroupId(gid).Patch(ctx, req, nil)
+	if !isAlreadyMember(err) {
+		return err
+	}
+
+	if len(refs) == 1 {
+		g.Logger.Debug("Member already exists, skipping", slog.String("ref", refs[0]))
+
+		return nil
+	}
+
+	for _, ref := range refs {
+		if err := g.addMembers(ctx, builder, []string{ref}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isAlreadyMember returns true if Graph rejected a request because an object is already a member of a group.
+func isAlreadyMember(err error) bool {
+	var odataError *odataerrors.ODataError
+	if !errors.As(err, &odataError) || odataError.ResponseStatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	mainError := odataError.GetErrorEscaped()
+	if mainError == nil || mainError.GetMessage() == nil {
+		return false
+	}
+
+	return strings.Contains(*mainError.GetMessage(), "object references already exist")
+}
+
+// isForbidden returns true if Graph rejected a request because the App Registration lacks a permission.
+func isForbidden(err error) bool {
+	var odataError *odataerrors.ODataError
+
+	return errors.As(err, &odataError) && odataError.ResponseStatusCode == http.StatusForbidden
+}
+
+// isNotMember returns true if Graph rejected a request because an object isn't a member of a group.
+func isNotMember(err error) bool {
+	var odataError *odataerrors.ODataError
+
+	return errors.As(err, &odataError) && odataError.ResponseStatusCode == http.StatusNotFound
+}
+
 func resolveGroupID(ctx context.Context, client iGroupClient, group string) (string, error) {
 	req := groups.GroupsRequestBuilderGetRequestConfiguration{
 		QueryParameters: to.Ptr(groups.GroupsRequestBuilderGetQueryParameters{
@@ -346,9 +436,107 @@ func removeGroupMember(
 
 var (
 	_ gosync.Adapter                  = &GroupMembership{}
+	_ gosync.Describer                = &GroupMembership{}
+	_ gosync.Capable                  = &GroupMembership{}
+	_ gosync.Validator                = &GroupMembership{}
 	_ gosync.InitFn[*GroupMembership] = Init
 )
 
+// Schema describes the configuration that [groupmembership.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: GroupName, Required: true, Description: "Display name of the Azure AD group."},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("azuread/groupmembership", Init)
+	gosync.RegisterSchema("azuread/groupmembership", Schema)
+}
+
+/*
+Validate checks that the Azure AD group exists, that the App Registration is allowed to read its members, and that its
+access token has been granted GroupMember.ReadWrite.All (or a broader Group or Directory permission) to change them.
+*/
+func (g *GroupMembership) Validate(ctx context.Context) error {
+	gid, err := resolveGroupID(ctx, g.groupClient, g.group)
+
+	switch {
+	case errors.Is(err, ErrNoResults):
+		return gosync.NewError(g, gosync.PhaseValidate, nil, fmt.Errorf("resolvegroupid -> %w", gosync.ErrNotFound))
+	case isForbidden(err):
+		return gosync.NewError(g, gosync.PhaseValidate, nil, fmt.Errorf(
+			"resolvegroupid -> %w",
+			gosync.ErrMissingPermission,
+		))
+	case err != nil:
+		return gosync.NewError(g, gosync.PhaseValidate, nil, fmt.Errorf("resolvegroupid -> %w", err))
+	}
+
+	req := groups.ItemMembersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.ItemMembersRequestBuilderGetQueryParameters{
+			Select: []string{"mail"},
+			Top:    to.Ptr(int32(1)),
+		},
+	}
+
+	_, err = g.getGroupMembers(ctx, g.groupClient.ByGroupId(gid), to.Ptr(req))
+	if isForbidden(err) {
+		return gosync.NewError(g, gosync.PhaseValidate, nil, fmt.Errorf("members -> %w", gosync.ErrMissingPermission))
+	} else if err != nil {
+		return gosync.NewError(g, gosync.PhaseValidate, nil, fmt.Errorf("members -> %w", resolveOdataError(err)))
+	}
+
+	if g.credential == nil {
+		return nil
+	}
+
+	permissions, err := tokenPermissions(ctx, g.credential)
+	if err != nil {
+		return gosync.NewError(g, gosync.PhaseValidate, nil, fmt.Errorf("token -> %w", err))
+	}
+
+	for _, permission := range writePermissions {
+		if slices.Contains(permissions, permission) {
+			return nil
+		}
+	}
+
+	return gosync.NewError(g, gosync.PhaseValidate, nil,
+		fmt.Errorf("token -> %w(%s)", gosync.ErrMissingPermission, writePermissions[0]))
+}
+
+/*
+tokenPermissions returns the Graph permissions granted to a credential, read from the claims of its access token: the
+application roles of an App Registration, or the delegated scopes of a signed in user.
+*/
+func tokenPermissions(ctx context.Context, credential azcore.TokenCredential) ([]string, error) {
+	token, err := credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{graphScope}})
+	if err != nil {
+		return nil, fmt.Errorf("gettoken -> %w", err)
+	}
+
+	parts := strings.Split(token.Token, ".")
+	if len(parts) != 3 { //nolint:gomnd,mnd
+		return nil, fmt.Errorf("%w(access token isn't a JWT)", gosync.ErrInvalidConfig)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decode -> %w", err)
+	}
+
+	var claims struct {
+		Roles []string `json:"roles"`
+		Scp   string   `json:"scp"`
+	}
+
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("unmarshal -> %w", err)
+	}
+
+	return append(claims.Roles, strings.Fields(claims.Scp)...), nil
+}
+
 // WithClient provides a mechanism to pass a custom client to the adapter.
 func WithClient(client iClient) func(u *GroupMembership) {
 	return func(u *GroupMembership) {
@@ -356,6 +544,59 @@ func WithClient(client iClient) func(u *GroupMembership) {
 	}
 }
 
+// Kind of adapter.
+func (g *GroupMembership) Kind() string {
+	return "azuread/groupmembership"
+}
+
+// Target returns the name of the Azure AD group.
+func (g *GroupMembership) Target() string {
+	return g.group
+}
+
+// Capabilities of the adapter.
+func (g *GroupMembership) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+	}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
+func WithLogger(logger *log.Logger) gosync.ConfigFn[*GroupMembership] {
+	return func(g *GroupMembership) {
+		g.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*GroupMembership] {
+	return func(g *GroupMembership) {
+		g.Logger = logger
+	}
+}
+
+// newGraphServiceClient creates a Graph Service Client, with an HTTP client instrumented with OpenTelemetry.
+func newGraphServiceClient(creds azcore.TokenCredential) (*msgraphsdkgo.GraphServiceClient, error) {
+	auth, err := azauth.NewAzureIdentityAuthenticationProviderWithScopes(creds, []string{graphScope})
+	if err != nil {
+		return nil, fmt.Errorf("auth -> %w", err)
+	}
+
+	options := msgraphsdkgo.GetDefaultClientOptions()
+	httpClient := msgraphsdkgocore.GetDefaultClient(&options)
+	httpClient.Transport = otelhttp.NewTransport(httpClient.Transport)
+
+	requestAdapter, err := msgraphsdkgo.NewGraphRequestAdapterWithParseNodeFactoryAndSerializationWriterFactoryAndHttpClient( //nolint:lll
+		auth, nil, nil, httpClient,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("adapter -> %w", err)
+	}
+
+	return msgraphsdkgo.NewGraphServiceClient(requestAdapter), nil
+}
+
 // Init creates a new adapter. It expects a single configuration entry.
 // Required config:
 //   - groupmembership.GroupName: the name of the AD group to sync members to.
@@ -364,10 +605,9 @@ func Init(
 	config map[gosync.ConfigKey]string,
 	configFns ...gosync.ConfigFn[*GroupMembership],
 ) (*GroupMembership, error) {
-	for _, key := range []gosync.ConfigKey{GroupName} {
-		if _, ok := config[key]; !ok {
-			return nil, fmt.Errorf("azuread.groupmembership.init -> %w(%s)", gosync.ErrMissingConfig, key)
-		}
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("azuread.groupmembership.init -> %w", err)
 	}
 
 	creds, err := azidentity.NewDefaultAzureCredential(nil)
@@ -375,32 +615,31 @@ func Init(
 		return nil, fmt.Errorf("azuread.groupmembership.init.creds -> %w", err)
 	}
 
-	client, err := msgraphsdkgo.NewGraphServiceClientWithCredentials(creds, []string{})
+	client, err := newGraphServiceClient(creds)
 	if err != nil {
 		return nil, fmt.Errorf("azuread.groupmembership.init.client -> %w", err)
 	}
 
 	adapter := &GroupMembership{
 		client:      client,
+		credential:  creds,
 		groupClient: client.Groups(),
 		userClient:  client.Users(),
 
-		group: config[GroupName],
+		group: parsed.String(GroupName),
 
 		getGroupMembers:   getGroupMembers,
 		patchGroup:        patchGroup,
 		removeGroupMember: removeGroupMember,
 
-		Logger: log.New(
-			os.Stderr,
-			"[go-sync/azuread/groupmembership] ",
-			log.LstdFlags|log.Lshortfile|log.Lmsgprefix,
-		),
+		Logger: slog.Default(),
 	}
 
 	for _, configFn := range configFns {
 		configFn(adapter)
 	}
 
+	parsed.LogUnknown(adapter.Logger)
+
 	return adapter, nil
 }
@@ -2,23 +2,30 @@ package groupmembership
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"net/http"
+	"slices"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	abstractions "github.com/microsoft/kiota-abstractions-go"
 	"github.com/microsoft/kiota-abstractions-go/serialization"
 	"github.com/microsoft/kiota-abstractions-go/store"
 	"github.com/microsoftgraph/msgraph-sdk-go/groups"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
 	"github.com/microsoftgraph/msgraph-sdk-go/users"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adaptertest"
 )
 
 func TestInit(t *testing.T) {
@@ -220,7 +227,7 @@ func TestGroupMembership_Get(t *testing.T) {
 	client.On("GetAdapter").Return(&MockRequestAdapter{})
 
 	adapter := &GroupMembership{
-		Logger:          log.New(io.Discard, "", 0),
+		Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
 		client:          client,
 		groupClient:     groupClient,
 		group:           "example",
@@ -283,7 +290,7 @@ func TestGroupMembership_Add(t *testing.T) {
 	)).Return(userResp2, nil)
 
 	adapter := &GroupMembership{
-		Logger:      log.New(io.Discard, "", 0),
+		Logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
 		groupClient: groupClient,
 		userClient:  userClient,
 		group:       groupName,
@@ -364,7 +371,7 @@ func TestGroupMembership_Remove(t *testing.T) {
 	)).Return(userResp2, nil)
 
 	adapter := &GroupMembership{
-		Logger:      log.New(io.Discard, "", 0),
+		Logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
 		groupClient: groupClient,
 		userClient:  userClient,
 		group:       groupName,
@@ -384,6 +391,110 @@ func TestGroupMembership_Remove(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// newODataError creates an error in the format returned by Microsoft Graph.
+func newODataError(status int, code string, message string) *odataerrors.ODataError {
+	mainError := odataerrors.NewMainError()
+	mainError.SetCode(to.Ptr(code))
+	mainError.SetMessage(to.Ptr(message))
+
+	err := odataerrors.NewODataError()
+	err.ResponseStatusCode = status
+	err.SetErrorEscaped(mainError)
+
+	return err
+}
+
+func TestGroupMembership_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	gid := "00000001-0000-0000-0000-000123456789"
+	groupResp := models.NewGroupCollectionResponse()
+	groupResp.SetValue([]models.Groupable{models.NewGroup()})
+	groupResp.GetValue()[0].SetId(to.Ptr(gid))
+
+	groupClient := newMockIGroupClient(t)
+	groupClient.EXPECT().Get(ctx, mock.Anything).Return(groupResp, nil)
+	groupClient.EXPECT().ByGroupId(gid).Return(&groups.GroupItemRequestBuilder{
+		BaseRequestBuilder: abstractions.BaseRequestBuilder{RequestAdapter: &MockRequestAdapter{}},
+	})
+
+	userIDs := map[string]string{
+		"existing@example.com":   "00000001-1000-0000-0000-000123456789",
+		"new@example.com":        "00000002-1000-0000-0000-000123456789",
+		"not.member@example.com": "00000003-1000-0000-0000-000123456789",
+	}
+
+	userClient := newMockIUserClient(t)
+	userClient.EXPECT().Get(ctx, mock.Anything).RunAndReturn(
+		func(
+			_ context.Context,
+			req *users.UsersRequestBuilderGetRequestConfiguration,
+		) (models.UserCollectionResponseable, error) {
+			resp := models.NewUserCollectionResponse()
+			resp.SetValue([]models.Userable{})
+
+			for mail, uid := range userIDs {
+				if *req.QueryParameters.Filter == fmt.Sprintf("mail eq '%s'", mail) {
+					resp.SetValue([]models.Userable{models.NewUser()})
+					resp.GetValue()[0].SetId(to.Ptr(uid))
+				}
+			}
+
+			return resp, nil
+		},
+	)
+
+	var added []string
+
+	adapter := &GroupMembership{
+		Logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		groupClient: groupClient,
+		userClient:  userClient,
+		group:       "TestGroupMembership_Idempotent",
+		patchGroup: func(
+			_ context.Context,
+			_ *groups.GroupItemRequestBuilder,
+			req models.Groupable,
+			_ *groups.GroupItemRequestBuilderPatchRequestConfiguration,
+		) (models.Groupable, error) {
+			refs, _ := req.GetAdditionalData()["members@odata.bind"].([]string)
+			if slices.Contains(refs, "https://graph.microsoft.com/v1.0/directoryObjects/"+userIDs["existing@example.com"]) {
+				return nil, newODataError(
+					http.StatusBadRequest,
+					"Request_BadRequest",
+					"One or more added object references already exist for the following modified properties: 'members'.",
+				)
+			}
+
+			added = append(added, refs...)
+
+			return req, nil
+		},
+		removeGroupMember: func(
+			_ context.Context,
+			_ *groups.GroupItemRequestBuilder,
+			uid string,
+			_ *groups.ItemMembersItemRefRequestBuilderDeleteRequestConfiguration,
+		) error {
+			assert.Equal(t, userIDs["not.member@example.com"], uid)
+
+			return newODataError(http.StatusNotFound, "Request_ResourceNotFound", "Resource does not exist.")
+		},
+	}
+
+	adaptertest.Idempotent(
+		ctx,
+		t,
+		adapter,
+		[]string{"existing@example.com", "new@example.com"},
+		[]string{"not.member@example.com", "deleted.user@example.com"},
+	)
+
+	assert.Equal(t, []string{"https://graph.microsoft.com/v1.0/directoryObjects/" + userIDs["new@example.com"]}, added)
+}
+
 type MockRequestAdapter struct {
 	SerializationWriterFactory serialization.SerializationWriterFactory
 }
@@ -472,3 +583,163 @@ func (r *MockRequestAdapter) SetBaseUrl(_ string) {
 func (r *MockRequestAdapter) GetBaseUrl() string {
 	return ""
 }
+
+func TestGroupMembership_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	gid := "00000001-0000-0000-0000-000123456789"
+	forbidden := newODataError(http.StatusForbidden, "Authorization_RequestDenied", "Insufficient privileges.")
+
+	newGroupClient := func(t *testing.T, ids ...string) *mockIGroupClient {
+		t.Helper()
+
+		resp := models.NewGroupCollectionResponse()
+		resp.SetValue([]models.Groupable{})
+
+		for _, id := range ids {
+			group := models.NewGroup()
+			group.SetId(to.Ptr(id))
+			resp.SetValue(append(resp.GetValue(), group))
+		}
+
+		groupClient := newMockIGroupClient(t)
+		groupClient.EXPECT().Get(ctx, mock.Anything).Return(resp, nil)
+
+		return groupClient
+	}
+
+	getGroupMembers := func(t *testing.T, err error) func(
+		context.Context,
+		*groups.GroupItemRequestBuilder,
+		*groups.ItemMembersRequestBuilderGetRequestConfiguration,
+	) (models.DirectoryObjectCollectionResponseable, error) {
+		t.Helper()
+
+		return func(
+			_ context.Context,
+			_ *groups.GroupItemRequestBuilder,
+			req *groups.ItemMembersRequestBuilderGetRequestConfiguration,
+		) (models.DirectoryObjectCollectionResponseable, error) {
+			assert.Equal(t, int32(1), *req.QueryParameters.Top)
+
+			return models.NewDirectoryObjectCollectionResponse(), err
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		groupClient := newGroupClient(t, gid)
+		groupClient.EXPECT().ByGroupId(gid).Return(&groups.GroupItemRequestBuilder{})
+
+		adapter := &GroupMembership{
+			groupClient:     groupClient,
+			group:           "example",
+			getGroupMembers: getGroupMembers(t, nil),
+		}
+
+		require.NoError(t, adapter.Validate(ctx))
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := &GroupMembership{groupClient: newGroupClient(t), group: "example"}
+
+		err := adapter.Validate(ctx)
+
+		var syncErr *gosync.Error
+
+		require.ErrorIs(t, err, gosync.ErrNotFound)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, gosync.PhaseValidate, syncErr.Phase)
+	})
+
+	t.Run("Missing permission", func(t *testing.T) {
+		t.Parallel()
+
+		groupClient := newGroupClient(t, gid)
+		groupClient.EXPECT().ByGroupId(gid).Return(&groups.GroupItemRequestBuilder{})
+
+		adapter := &GroupMembership{
+			groupClient:     groupClient,
+			group:           "example",
+			getGroupMembers: getGroupMembers(t, forbidden),
+		}
+
+		require.ErrorIs(t, adapter.Validate(ctx), gosync.ErrMissingPermission)
+	})
+
+	for name, test := range map[string]struct {
+		claims string
+		err    error
+	}{
+		"Application role": {
+			claims: `{"roles": ["User.Read.All", "GroupMember.ReadWrite.All"]}`,
+		},
+		"Delegated scope": {
+			claims: `{"scp": "User.Read Group.ReadWrite.All"}`,
+		},
+		"Read only": {
+			claims: `{"roles": ["GroupMember.Read.All"]}`,
+			err:    gosync.ErrMissingPermission,
+		},
+		"No permissions": {
+			claims: `{}`,
+			err:    gosync.ErrMissingPermission,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			groupClient := newGroupClient(t, gid)
+			groupClient.EXPECT().ByGroupId(gid).Return(&groups.GroupItemRequestBuilder{})
+
+			adapter := &GroupMembership{
+				groupClient:     groupClient,
+				credential:      tokenCredential(test.claims),
+				group:           "example",
+				getGroupMembers: getGroupMembers(t, nil),
+			}
+
+			err := adapter.Validate(ctx)
+
+			if test.err == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var syncErr *gosync.Error
+
+			require.ErrorIs(t, err, test.err)
+			require.ErrorAs(t, err, &syncErr)
+			assert.Equal(t, gosync.PhaseValidate, syncErr.Phase)
+			assert.Contains(t, err.Error(), "GroupMember.ReadWrite.All")
+		})
+	}
+}
+
+// tokenCredential is an azcore.TokenCredential that returns an unsigned JWT with the claims.
+type tokenCredential string
+
+func (c tokenCredential) GetToken(_ context.Context, _ policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(c))
+
+	return azcore.AccessToken{Token: "e30." + claims + ".signature"}, nil
+}
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("azuread/groupmembership")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("azuread/groupmembership")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
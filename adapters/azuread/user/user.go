@@ -17,18 +17,25 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"net/http"
 	"regexp"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	abstractions "github.com/microsoft/kiota-abstractions-go"
+	azauth "github.com/microsoft/kiota-authentication-azure-go"
 	msgraphsdkgo "github.com/microsoftgraph/msgraph-sdk-go"
 	msgraphsdkgocore "github.com/microsoftgraph/msgraph-sdk-go-core"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
 	"github.com/microsoftgraph/msgraph-sdk-go/users"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	gosync "github.com/ovotech/go-sync"
 )
@@ -41,6 +48,9 @@ See the [reference] for more information on filter queries.
 */
 const Filter gosync.ConfigKey = "filter"
 
+// graphScope is the OAuth scope requested for the Microsoft Graph API.
+const graphScope = "https://graph.microsoft.com/.default"
+
 type iUser interface {
 	Get(
 		ctx context.Context,
@@ -56,62 +66,183 @@ type iClient interface {
 type User struct {
 	users  iUser
 	client iClient
-	Logger *log.Logger
+	Logger *slog.Logger
 
 	filter string
 }
 
+// Get email addresses of users in Azure AD.
 func (u *User) Get(ctx context.Context) ([]string, error) {
-	var request users.UsersRequestBuilderGetRequestConfiguration
+	return gosync.Collect(ctx, u) //nolint:wrapcheck
+}
 
-	if isAdvancedQuery(u.filter) {
-		headers := abstractions.NewRequestHeaders()
-		headers.Add("ConsistencyLevel", "eventual")
+// streamPageSize is the number of users passed to each call of Stream's callback, which matches Graph's default page.
+const streamPageSize = 100
 
-		request = users.UsersRequestBuilderGetRequestConfiguration{
-			Headers: headers,
-			QueryParameters: &users.UsersRequestBuilderGetQueryParameters{
-				Count:  to.Ptr(true),
-				Filter: to.Ptr(u.filter),
-			},
+// Stream email addresses of users in Azure AD, a page at a time.
+func (u *User) Stream(ctx context.Context, fn func(emails []string) error) error {
+	u.Logger.Info("Fetching users from Azure AD")
+
+	resp, err := u.users.Get(ctx, to.Ptr(u.request()))
+	if err != nil {
+		return gosync.NewError(u, gosync.PhaseGet, nil, fmt.Errorf("userget -> %w", err))
+	}
+
+	// Use PageIterator to iterate through all users
+	pageIterator, err := msgraphsdkgocore.NewPageIterator[models.Userable](
+		resp,
+		u.client.GetAdapter(),
+		models.CreateUserCollectionResponseFromDiscriminatorValue,
+	)
+	if err != nil {
+		return gosync.NewError(u, gosync.PhaseGet, nil, fmt.Errorf("iterator -> %w", err))
+	}
+
+	count := 0
+	page := make([]string, 0, streamPageSize)
+
+	var fnErr error
+
+	err = pageIterator.Iterate(ctx, func(user models.Userable) bool {
+		if email := user.GetMail(); email != nil {
+			page = append(page, *email)
 		}
-	} else {
-		request = users.UsersRequestBuilderGetRequestConfiguration{
-			QueryParameters: &users.UsersRequestBuilderGetQueryParameters{
-				Filter: to.Ptr(u.filter),
-			},
+
+		if len(page) < streamPageSize {
+			return true
 		}
+
+		count += len(page)
+		fnErr = fn(page)
+		page = make([]string, 0, streamPageSize)
+
+		return fnErr == nil
+	})
+	if err != nil {
+		return gosync.NewError(u, gosync.PhaseGet, nil, fmt.Errorf("iterate -> %w", err))
+	}
+
+	if fnErr == nil && len(page) > 0 {
+		count += len(page)
+		fnErr = fn(page)
 	}
 
+	if fnErr != nil {
+		return gosync.NewError(u, gosync.PhaseGet, nil, fmt.Errorf("stream -> %w", fnErr))
+	}
+
+	u.Logger.Info("Fetched users successfully", slog.Int(gosync.LogKeyCount, count))
+
+	return nil
+}
+
+/*
+Identities returns the email addresses of each user in Azure AD, so that Sync can match people by any of their
+addresses. Each user's `mail` is their primary address, followed by the SMTP addresses in `proxyAddresses` and their
+`otherMails`. Users without any email addresses are skipped.
+
+	adapter, err := user.Init(ctx, map[gosync.ConfigKey]string{})
+
+	syncService := gosync.New(source, gosync.WithIdentities(adapter))
+*/
+func (u *User) Identities(ctx context.Context) ([][]string, error) {
+	request := u.request()
+	request.QueryParameters.Select = []string{"mail", "proxyAddresses", "otherMails"}
+
 	resp, err := u.users.Get(ctx, to.Ptr(request))
 	if err != nil {
-		return nil, fmt.Errorf("azuread.user.get.userget -> %w", err)
+		return nil, fmt.Errorf("azuread.user.identities.userget -> %w", err)
 	}
 
-	// Use PageIterator to iterate through all users
 	pageIterator, err := msgraphsdkgocore.NewPageIterator[models.Userable](
 		resp,
 		u.client.GetAdapter(),
 		models.CreateUserCollectionResponseFromDiscriminatorValue,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("azuread.user.get.iterator -> %w", err)
+		return nil, fmt.Errorf("azuread.user.identities.iterator -> %w", err)
 	}
 
-	emails := make([]string, 0)
+	identities := make([][]string, 0)
 
 	err = pageIterator.Iterate(ctx, func(user models.Userable) bool {
-		if email := user.GetMail(); email != nil {
-			emails = append(emails, *email)
+		if aliases := userAliases(user); len(aliases) > 0 {
+			identities = append(identities, aliases)
 		}
 
 		return true
 	})
 	if err != nil {
-		return nil, fmt.Errorf("azuread.user.get.iterate -> %w", err)
+		return nil, fmt.Errorf("azuread.user.identities.iterate -> %w", err)
+	}
+
+	u.Logger.Info("Fetched identities successfully", slog.Int(gosync.LogKeyCount, len(identities)))
+
+	return identities, nil
+}
+
+// smtpPrefix is the type prefix of SMTP addresses in proxyAddresses, which is uppercase for the primary address.
+const smtpPrefix = "smtp:"
+
+// userAliases returns a user's email addresses, with their primary address first.
+func userAliases(user models.Userable) []string {
+	aliases := make([]string, 0)
+	add := func(address string) {
+		for _, alias := range aliases {
+			if strings.EqualFold(alias, address) {
+				return
+			}
+		}
+
+		aliases = append(aliases, address)
+	}
+
+	if mail := user.GetMail(); mail != nil && *mail != "" {
+		add(*mail)
+	}
+
+	// The primary SMTP address is used if the user doesn't have a mail attribute.
+	for _, primary := range []bool{true, false} {
+		for _, proxy := range user.GetProxyAddresses() {
+			if len(proxy) <= len(smtpPrefix) || !strings.EqualFold(proxy[:len(smtpPrefix)], smtpPrefix) {
+				continue
+			}
+
+			if (proxy[:len(smtpPrefix)] == strings.ToUpper(smtpPrefix)) == primary {
+				add(proxy[len(smtpPrefix):])
+			}
+		}
+	}
+
+	for _, other := range user.GetOtherMails() {
+		if other != "" {
+			add(other)
+		}
 	}
 
-	return emails, nil
+	return aliases
+}
+
+// request builds the query for users, using the filter if it has been set.
+func (u *User) request() users.UsersRequestBuilderGetRequestConfiguration {
+	if isAdvancedQuery(u.filter) {
+		headers := abstractions.NewRequestHeaders()
+		headers.Add("ConsistencyLevel", "eventual")
+
+		return users.UsersRequestBuilderGetRequestConfiguration{
+			Headers: headers,
+			QueryParameters: &users.UsersRequestBuilderGetQueryParameters{
+				Count:  to.Ptr(true),
+				Filter: to.Ptr(u.filter),
+			},
+		}
+	}
+
+	return users.UsersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.UsersRequestBuilderGetQueryParameters{
+			Filter: to.Ptr(u.filter),
+		},
+	}
 }
 
 //nolint:gochecknoglobals
@@ -137,19 +268,58 @@ func isAdvancedQuery(filter string) bool {
 	return false
 }
 
-func (u *User) Add(_ context.Context, _ []string) error {
-	return fmt.Errorf("azuread.user.add -> %w", gosync.ErrReadOnly)
+// Add is not supported, as the adapter is readonly.
+func (u *User) Add(_ context.Context, emails []string) error {
+	return gosync.NewError(u, gosync.PhaseAdd, emails, gosync.ErrReadOnly)
 }
 
-func (u *User) Remove(_ context.Context, _ []string) error {
-	return fmt.Errorf("azuread.user.remove -> %w", gosync.ErrReadOnly)
+// Remove is not supported, as the adapter is readonly.
+func (u *User) Remove(_ context.Context, emails []string) error {
+	return gosync.NewError(u, gosync.PhaseRemove, emails, gosync.ErrReadOnly)
+}
+
+/*
+Validate checks that the App Registration is allowed to read users, and that Azure AD accepts the filter, by fetching a
+single user.
+*/
+func (u *User) Validate(ctx context.Context) error {
+	request := u.request()
+	request.QueryParameters.Top = to.Ptr(int32(1))
+
+	_, err := u.users.Get(ctx, to.Ptr(request))
+
+	var odataError *odataerrors.ODataError
+
+	if errors.As(err, &odataError) && odataError.ResponseStatusCode == http.StatusForbidden {
+		return gosync.NewError(u, gosync.PhaseValidate, nil, fmt.Errorf("userget -> %w", gosync.ErrMissingPermission))
+	} else if err != nil {
+		return gosync.NewError(u, gosync.PhaseValidate, nil, fmt.Errorf("userget -> %w", err))
+	}
+
+	return nil
 }
 
 var (
-	_ gosync.Adapter       = &User{} // Ensure [user.User] fully satisfies the [gosync.Adapter] interface.
-	_ gosync.InitFn[*User] = Init    // Ensure the [user.Init] function fully satisfies the [gosync.InitFn] type.
+	_ gosync.Adapter        = &User{} // Ensure [user.User] fully satisfies the [gosync.Adapter] interface.
+	_ gosync.Describer      = &User{} // Ensure [user.User] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Capable        = &User{} // Ensure [user.User] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Validator      = &User{} // Ensure [user.User] fully satisfies the [gosync.Validator] interface.
+	_ gosync.Streamer       = &User{} // Ensure [user.User] fully satisfies the [gosync.Streamer] interface.
+	_ gosync.IdentitySource = &User{} // Ensure [user.User] fully satisfies the [gosync.IdentitySource] interface.
+	_ gosync.InitFn[*User]  = Init    // Ensure the [user.Init] function fully satisfies the [gosync.InitFn] type.
 )
 
+// Schema describes the configuration that [user.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: Filter, Description: "Microsoft Graph query filter for the users, such as `accountEnabled eq true`."},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("azuread/user", Init)
+	gosync.RegisterSchema("azuread/user", Schema)
+}
+
 // WithFilter provides a mechanism to set the Microsoft Graph query filter
 // when instantiating a new User adapter with the [user.New] method.
 func WithFilter(f string) func(u *User) {
@@ -165,6 +335,56 @@ func WithClient(client iClient) func(u *User) {
 	}
 }
 
+// Kind of adapter.
+func (u *User) Kind() string {
+	return "azuread/user"
+}
+
+// Target returns the Microsoft Graph query filter.
+func (u *User) Target() string {
+	return u.filter
+}
+
+// Capabilities of the adapter, which is read-only.
+func (u *User) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
+func WithLogger(logger *log.Logger) gosync.ConfigFn[*User] {
+	return func(u *User) {
+		u.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*User] {
+	return func(u *User) {
+		u.Logger = logger
+	}
+}
+
+// newGraphServiceClient creates a Graph Service Client, with an HTTP client instrumented with OpenTelemetry.
+func newGraphServiceClient(creds azcore.TokenCredential) (*msgraphsdkgo.GraphServiceClient, error) {
+	auth, err := azauth.NewAzureIdentityAuthenticationProviderWithScopes(creds, []string{graphScope})
+	if err != nil {
+		return nil, fmt.Errorf("auth -> %w", err)
+	}
+
+	options := msgraphsdkgo.GetDefaultClientOptions()
+	httpClient := msgraphsdkgocore.GetDefaultClient(&options)
+	httpClient.Transport = otelhttp.NewTransport(httpClient.Transport)
+
+	requestAdapter, err := msgraphsdkgo.NewGraphRequestAdapterWithParseNodeFactoryAndSerializationWriterFactoryAndHttpClient( //nolint:lll
+		auth, nil, nil, httpClient,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("adapter -> %w", err)
+	}
+
+	return msgraphsdkgo.NewGraphServiceClient(requestAdapter), nil
+}
+
 // Init creates a new Adapter. By default, an Azure Graph Service Client will
 // be created using the default credentials in the environment.
 func Init(
@@ -172,12 +392,17 @@ func Init(
 	config map[gosync.ConfigKey]string,
 	configFns ...gosync.ConfigFn[*User],
 ) (*User, error) {
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("azuread.user.init -> %w", err)
+	}
+
 	creds, err := azidentity.NewDefaultAzureCredential(nil)
 	if err != nil {
 		return nil, fmt.Errorf("azuread.user.init.creds -> %w", err)
 	}
 
-	client, err := msgraphsdkgo.NewGraphServiceClientWithCredentials(creds, []string{})
+	client, err := newGraphServiceClient(creds)
 	if err != nil {
 		return nil, fmt.Errorf("azuread.user.init.client -> %w", err)
 	}
@@ -185,16 +410,18 @@ func Init(
 	user := &User{
 		client: client,
 		users:  client.Users(),
-		Logger: log.New(os.Stderr, "[go-sync/azuread/user] ", log.LstdFlags|log.Lshortfile|log.Lmsgprefix),
+		Logger: slog.Default(),
 	}
 
 	for _, configFn := range configFns {
 		configFn(user)
 	}
 
-	if filter, ok := config[Filter]; ok {
-		user.filter = filter
+	if parsed.Has(Filter) {
+		user.filter = parsed.String(Filter)
 	}
 
+	parsed.LogUnknown(user.Logger)
+
 	return user, nil
 }
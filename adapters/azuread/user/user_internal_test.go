@@ -2,6 +2,10 @@ package user
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
@@ -9,6 +13,8 @@ import (
 	"github.com/microsoft/kiota-abstractions-go/serialization"
 	"github.com/microsoft/kiota-abstractions-go/store"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -167,7 +173,87 @@ func TestTeam_Remove(t *testing.T) {
 
 	err = adapter.Remove(context.TODO(), []string{"foo@email", "bar@email"})
 
+	var syncErr *gosync.Error
+
 	require.ErrorIs(t, err, gosync.ErrReadOnly)
+	require.ErrorAs(t, err, &syncErr)
+	assert.Equal(t, gosync.PhaseRemove, syncErr.Phase)
+	assert.Equal(t, "azuread/user", syncErr.Kind)
+	assert.Equal(t, []string{"foo@email", "bar@email"}, syncErr.Things)
+}
+
+func TestUser_Capabilities(t *testing.T) {
+	t.Parallel()
+
+	adapter, err := Init(context.TODO(), nil, WithClient(newMockIClient(t)))
+	require.NoError(t, err)
+
+	assert.True(t, gosync.CapabilitiesOf(adapter).ReadOnly())
+}
+
+func TestUser_Stream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	mockClient := newMockIClient(t)
+	mockClient.EXPECT().GetAdapter().Return(&MockRequestAdapter{})
+
+	mockUser := newMockIUser(t)
+
+	respOut := make([]models.Userable, 0, 250)
+
+	for i := range 250 {
+		user := models.NewUser()
+		user.SetMail(to.Ptr(fmt.Sprintf("user%d@ovo.com", i)))
+		respOut = append(respOut, user)
+	}
+
+	resp := models.NewUserCollectionResponse()
+	resp.SetValue(respOut)
+
+	mockUser.EXPECT().Get(ctx, mock.Anything).Return(resp, nil)
+
+	adapter := &User{users: mockUser, client: mockClient, Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	var pages []int
+
+	err := adapter.Stream(ctx, func(emails []string) error {
+		pages = append(pages, len(emails))
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{100, 100, 50}, pages)
+}
+
+func TestUser_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	forbidden := odataerrors.NewODataError()
+	forbidden.ResponseStatusCode = http.StatusForbidden
+
+	mockUser := newMockIUser(t)
+	mockUser.EXPECT().Get(ctx, mock.MatchedBy(func(req *users.UsersRequestBuilderGetRequestConfiguration) bool {
+		return *req.QueryParameters.Top == 1 && *req.QueryParameters.Filter == "accountEnabled eq true"
+	})).Return(models.NewUserCollectionResponse(), nil).Once()
+
+	adapter := &User{users: mockUser, filter: "accountEnabled eq true"}
+
+	require.NoError(t, adapter.Validate(ctx))
+
+	mockUser.EXPECT().Get(ctx, mock.Anything).Return(nil, forbidden).Once()
+
+	err := adapter.Validate(ctx)
+
+	var syncErr *gosync.Error
+
+	require.ErrorIs(t, err, gosync.ErrMissingPermission)
+	require.ErrorAs(t, err, &syncErr)
+	assert.Equal(t, gosync.PhaseValidate, syncErr.Phase)
 }
 
 func Test_isAdvancedQuery(t *testing.T) {
@@ -238,3 +324,52 @@ func TestInit(t *testing.T) {
 		})
 	})
 }
+
+func TestUser_Identities(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	mockClient := newMockIClient(t)
+	mockClient.EXPECT().GetAdapter().Return(&MockRequestAdapter{})
+
+	jane := models.NewUser()
+	jane.SetMail(to.Ptr("jane@ovo.com"))
+	jane.SetProxyAddresses([]string{"smtp:jane.doe@ovo.com", "SMTP:Jane@ovo.com", "x500:/o=ovo", "smtp:jane@old.com"})
+	jane.SetOtherMails([]string{"jane@gmail.com"})
+
+	// Without a mail attribute, the primary SMTP address comes first.
+	bob := models.NewUser()
+	bob.SetProxyAddresses([]string{"smtp:bob@old.com", "SMTP:bob@ovo.com"})
+
+	resp := models.NewUserCollectionResponse()
+	resp.SetValue([]models.Userable{jane, bob, models.NewUser()})
+
+	mockUser := newMockIUser(t)
+	mockUser.EXPECT().Get(ctx, mock.MatchedBy(func(req *users.UsersRequestBuilderGetRequestConfiguration) bool {
+		return assert.ObjectsAreEqual([]string{"mail", "proxyAddresses", "otherMails"}, req.QueryParameters.Select)
+	})).Return(resp, nil)
+
+	adapter := &User{users: mockUser, client: mockClient, Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	identities, err := adapter.Identities(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"jane@ovo.com", "jane.doe@ovo.com", "jane@old.com", "jane@gmail.com"},
+		{"bob@ovo.com", "bob@old.com"},
+	}, identities)
+}
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("azuread/user")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("azuread/user")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
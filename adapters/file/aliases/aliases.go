@@ -0,0 +1,75 @@
+/*
+Package aliases loads identities from a local YAML or JSON file, so that Sync can match people by any of their email
+addresses when a directory doesn't know about all of them, such as addresses on an old domain after a rebrand.
+
+# File format
+
+The file is a list of identities, each with its primary address first, followed by its aliases:
+
+	# aliases.yaml
+	- [jane@example.com, jane.doe@example.com, jane@old-example.com]
+	- [bob@example.com, bob@old-example.com]
+
+As JSON is a subset of YAML, the same file can also be written as JSON:
+
+	[["jane@example.com", "jane.doe@example.com", "jane@old-example.com"]]
+
+# Examples
+
+See [New].
+*/
+package aliases
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// Ensure [aliases.Aliases] fully satisfies the [gosync.IdentitySource] interface.
+var _ gosync.IdentitySource = &Aliases{}
+
+// Aliases loads identities from a file.
+type Aliases struct {
+	path     string
+	readFile func(name string) ([]byte, error)
+}
+
+// Identities reads and parses the file of aliases. Empty identities are skipped.
+func (a *Aliases) Identities(_ context.Context) ([][]string, error) {
+	data, err := a.readFile(a.path)
+	if err != nil {
+		return nil, fmt.Errorf("file.aliases.identities.readfile -> %w", err)
+	}
+
+	sets := make([][]string, 0)
+
+	if err = yaml.Unmarshal(data, &sets); err != nil {
+		return nil, fmt.Errorf("file.aliases.identities.unmarshal -> %w", err)
+	}
+
+	identities := make([][]string, 0, len(sets))
+
+	for _, set := range sets {
+		if len(set) > 0 {
+			identities = append(identities, set)
+		}
+	}
+
+	return identities, nil
+}
+
+/*
+New creates a [gosync.IdentitySource] that reads identities from a YAML or JSON file at path.
+
+	identities := aliases.New("aliases.yaml")
+
+	syncService := gosync.New(source, gosync.WithIdentities(identities))
+*/
+func New(path string) *Aliases {
+	return &Aliases{path: path, readFile: os.ReadFile}
+}
@@ -0,0 +1,77 @@
+package aliases
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAliases_Identities(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	tests := map[string]struct {
+		data    string
+		readErr error
+		want    [][]string
+		wantErr error
+	}{
+		"YAML": {
+			data: "- [jane@example.com, jane@old-example.com]\n- []\n- [bob@example.com]\n",
+			want: [][]string{{"jane@example.com", "jane@old-example.com"}, {"bob@example.com"}},
+		},
+		"JSON": {
+			data: `[["jane@example.com", "jane.doe@example.com"]]`,
+			want: [][]string{{"jane@example.com", "jane.doe@example.com"}},
+		},
+		"Empty": {
+			data: "",
+			want: [][]string{},
+		},
+		"Missing file": {
+			readErr: os.ErrNotExist,
+			wantErr: os.ErrNotExist,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			adapter := &Aliases{path: "aliases.yaml", readFile: func(name string) ([]byte, error) {
+				assert.Equal(t, "aliases.yaml", name)
+
+				return []byte(test.data), test.readErr
+			}}
+
+			identities, err := adapter.Identities(ctx)
+
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, identities)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := &Aliases{readFile: func(string) ([]byte, error) {
+			return []byte("foo: bar"), nil
+		}}
+
+		_, err := adapter.Identities(ctx)
+
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, os.ErrNotExist))
+	})
+}
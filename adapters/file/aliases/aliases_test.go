@@ -0,0 +1,24 @@
+package aliases_test
+
+import (
+	"context"
+	"log"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/file/aliases"
+	"github.com/ovotech/go-sync/adapters/file/grants"
+)
+
+func ExampleNew() {
+	ctx := context.Background()
+
+	source, err := grants.Init(ctx, map[gosync.ConfigKey]string{
+		grants.Path: "grants.yaml",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Grants to old addresses match the same people in destinations, without removing and adding them again.
+	gosync.New(source, gosync.WithIdentities(aliases.New("aliases.yaml")))
+}
@@ -0,0 +1,14 @@
+module github.com/ovotech/go-sync/adapters/file
+
+go 1.22
+
+require (
+	github.com/ovotech/go-sync v0.14.0
+	github.com/stretchr/testify v1.9.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
@@ -0,0 +1,298 @@
+/*
+Package grants allows you to synchronise other services with time-bound access grants, read from a local YAML or JSON
+file. Each grant can have an expiry time, after which it is no longer returned by Get, so that Sync removes the access
+from its destinations on the next run.
+
+Note: Grants are readonly, and so you can only use this as a source.
+
+# File format
+
+The file is a list of grants, each with the thing being granted access, such as an email address:
+
+	# grants.yaml
+	- name: contractor@example.com
+	  expires_at: 2024-07-01T00:00:00Z
+	  reason: Migration project
+	- name: responder@example.com
+	  expires_at: 2024-06-14T18:00:00+01:00
+	  reason: INC-1234
+	- name: permanent@example.com
+
+Grants without an `expires_at` never expire. As JSON is a subset of YAML, the same file can also be written as JSON:
+
+	[{"name": "contractor@example.com", "expires_at": "2024-07-01T00:00:00Z"}]
+
+The file is read each time Get is called, so grants can be added or extended without restarting the sync.
+
+# Examples
+
+See [Init] and [Grants.Expiring].
+*/
+package grants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// Path is the location of the YAML or JSON file of grants.
+const Path gosync.ConfigKey = "path"
+
+/*
+ExpiryWarning is how long before a grant expires that it is reported as expiring soon, as a [time.Duration] string such
+as `48h`. Defaults to [DefaultExpiryWarning].
+*/
+const ExpiryWarning gosync.ConfigKey = "expiry_warning"
+
+// DefaultExpiryWarning is the default value of [grants.ExpiryWarning].
+const DefaultExpiryWarning = 24 * time.Hour
+
+var (
+	_ gosync.Adapter         = &Grants{} // Ensure [grants.Grants] fully satisfies the [gosync.Adapter] interface.
+	_ gosync.Describer       = &Grants{} // Ensure [grants.Grants] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Capable         = &Grants{} // Ensure [grants.Grants] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Validator       = &Grants{} // Ensure [grants.Grants] fully satisfies the [gosync.Validator] interface.
+	_ gosync.InitFn[*Grants] = Init      // Ensure [grants.Init] fully satisfies the [gosync.InitFn] type.
+)
+
+// Schema describes the configuration that [grants.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: Path, Required: true, Description: "Location of the YAML or JSON file of grants."},
+	{
+		Key:         ExpiryWarning,
+		Type:        gosync.ConfigDuration,
+		Default:     DefaultExpiryWarning.String(),
+		Description: "How long before a grant expires that it is reported as expiring soon.",
+	},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("file/grants", Init)
+	gosync.RegisterSchema("file/grants", Schema)
+}
+
+// Grant gives a thing access until it expires.
+type Grant struct {
+	Name      string     `yaml:"name"`                 // Name is the thing being granted access, e.g. an email.
+	ExpiresAt *time.Time `yaml:"expires_at,omitempty"` // ExpiresAt is nil if the grant never expires.
+	Reason    string     `yaml:"reason,omitempty"`     // Reason the grant was given, e.g. an incident.
+}
+
+// Expired returns true if the grant has expired at the given time.
+func (g Grant) Expired(now time.Time) bool {
+	return g.ExpiresAt != nil && !now.Before(*g.ExpiresAt)
+}
+
+// expiresWithin returns true if the grant hasn't expired, but will within the given duration.
+func (g Grant) expiresWithin(now time.Time, duration time.Duration) bool {
+	return g.ExpiresAt != nil && !g.Expired(now) && g.ExpiresAt.Before(now.Add(duration))
+}
+
+type Grants struct {
+	path          string
+	readFile      func(name string) ([]byte, error)
+	getTime       func() time.Time
+	ExpiryWarning time.Duration // ExpiryWarning is how long before expiry that a grant is reported as expiring soon.
+	Logger        *slog.Logger
+}
+
+// load reads and parses the file of grants.
+func (g *Grants) load() ([]Grant, error) {
+	data, err := g.readFile(g.path)
+	if err != nil {
+		return nil, fmt.Errorf("readfile -> %w", err)
+	}
+
+	grants := make([]Grant, 0)
+
+	if err = yaml.Unmarshal(data, &grants); err != nil {
+		return nil, fmt.Errorf("unmarshal -> %w", err)
+	}
+
+	for index, grant := range grants {
+		if grant.Name == "" {
+			return nil, fmt.Errorf("grant(%d) -> %w(name)", index, gosync.ErrMissingConfig)
+		}
+	}
+
+	return grants, nil
+}
+
+// Get the names of things with unexpired grants. Grants that are expiring soon are logged as a warning.
+func (g *Grants) Get(_ context.Context) ([]string, error) {
+	g.Logger.Info("Fetching grants from file")
+
+	grants, err := g.load()
+	if err != nil {
+		return nil, gosync.NewError(g, gosync.PhaseGet, nil, err)
+	}
+
+	now := g.getTime()
+	names := make([]string, 0, len(grants))
+	seen := make(map[string]bool, len(grants))
+	expired := 0
+
+	for _, grant := range grants {
+		if grant.Expired(now) {
+			expired++
+
+			continue
+		}
+
+		if grant.expiresWithin(now, g.ExpiryWarning) {
+			g.Logger.Warn("Grant is expiring soon",
+				slog.String(gosync.LogKeyThing, grant.Name),
+				slog.Time("expires_at", *grant.ExpiresAt),
+				slog.String("reason", grant.Reason),
+			)
+		}
+
+		// A thing can be granted access more than once, and has access until its last grant expires.
+		if !seen[grant.Name] {
+			seen[grant.Name] = true
+			names = append(names, grant.Name)
+		}
+	}
+
+	g.Logger.Info("Fetched grants successfully",
+		slog.Int(gosync.LogKeyCount, len(names)),
+		slog.Int("expired", expired),
+	)
+
+	return names, nil
+}
+
+/*
+Expiring returns the unexpired grants that will expire within the adapter's ExpiryWarning, soonest first, so that they
+can be reported or extended before access is removed.
+
+	expiring, err := adapter.Expiring(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, grant := range expiring {
+		fmt.Printf("%s expires at %s\n", grant.Name, grant.ExpiresAt)
+	}
+*/
+func (g *Grants) Expiring(_ context.Context) ([]Grant, error) {
+	grants, err := g.load()
+	if err != nil {
+		return nil, fmt.Errorf("file.grants.expiring -> %w", err)
+	}
+
+	now := g.getTime()
+	expiring := make([]Grant, 0)
+
+	for _, grant := range grants {
+		if grant.expiresWithin(now, g.ExpiryWarning) {
+			expiring = append(expiring, grant)
+		}
+	}
+
+	slices.SortStableFunc(expiring, func(a, b Grant) int {
+		return a.ExpiresAt.Compare(*b.ExpiresAt)
+	})
+
+	return expiring, nil
+}
+
+// Add is not supported, as grants are readonly.
+func (g *Grants) Add(_ context.Context, names []string) error {
+	return gosync.NewError(g, gosync.PhaseAdd, names, gosync.ErrReadOnly)
+}
+
+// Remove is not supported, as grants are readonly.
+func (g *Grants) Remove(_ context.Context, names []string) error {
+	return gosync.NewError(g, gosync.PhaseRemove, names, gosync.ErrReadOnly)
+}
+
+// Validate checks that the file of grants exists, and that every grant in it is valid.
+func (g *Grants) Validate(_ context.Context) error {
+	_, err := g.load()
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return gosync.NewError(g, gosync.PhaseValidate, nil, fmt.Errorf("%w: %w", gosync.ErrNotFound, err))
+	case err != nil:
+		return gosync.NewError(g, gosync.PhaseValidate, nil, err)
+	}
+
+	return nil
+}
+
+// Kind of adapter.
+func (g *Grants) Kind() string {
+	return "file/grants"
+}
+
+// Target returns the path of the file of grants.
+func (g *Grants) Target() string {
+	return g.path
+}
+
+// Capabilities of the adapter, which is read-only.
+func (g *Grants) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
+func WithLogger(logger *log.Logger) gosync.ConfigFn[*Grants] {
+	return func(g *Grants) {
+		g.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*Grants] {
+	return func(g *Grants) {
+		g.Logger = logger
+	}
+}
+
+/*
+Init a new file Grants [gosync.Adapter].
+
+Required config:
+  - [grants.Path]
+*/
+func Init(
+	_ context.Context,
+	config map[gosync.ConfigKey]string,
+	configFns ...gosync.ConfigFn[*Grants],
+) (*Grants, error) {
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("file.grants.init -> %w", err)
+	}
+
+	adapter := &Grants{
+		path:          parsed.String(Path),
+		readFile:      os.ReadFile,
+		getTime:       time.Now,
+		ExpiryWarning: parsed.Duration(ExpiryWarning),
+	}
+
+	for _, configFn := range configFns {
+		configFn(adapter)
+	}
+
+	if adapter.Logger == nil {
+		WithSlogLogger(slog.Default())(adapter)
+	}
+
+	parsed.LogUnknown(adapter.Logger)
+
+	return adapter, nil
+}
@@ -0,0 +1,270 @@
+package grants
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+var testTime = time.Date(2024, 6, 14, 12, 0, 0, 0, time.UTC)
+
+const testGrants = `
+- name: expired@example.com
+  expires_at: 2024-06-14T11:00:00Z
+- name: expiring@example.com
+  expires_at: 2024-06-14T18:00:00Z
+  reason: INC-1234
+- name: later@example.com
+  expires_at: 2024-07-01T00:00:00Z
+- name: permanent@example.com
+- name: expiring@example.com
+  expires_at: 2024-06-14T11:00:00Z
+`
+
+func createAdapter(ctx context.Context, t *testing.T, data string) *Grants {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "grants.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	adapter, err := Init(ctx, map[gosync.ConfigKey]string{Path: path})
+	require.NoError(t, err)
+
+	adapter.getTime = func() time.Time {
+		return testTime
+	}
+
+	return adapter
+}
+
+func TestGrants_Get(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Returns unexpired grants", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAdapter(ctx, t, testGrants)
+
+		things, err := adapter.Get(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"expiring@example.com", "later@example.com", "permanent@example.com"}, things)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAdapter(ctx, t, `[
+			{"name": "expired@example.com", "expires_at": "2024-06-14T11:00:00Z"},
+			{"name": "later@example.com", "expires_at": "2024-07-01T00:00:00+01:00"}
+		]`)
+
+		things, err := adapter.Get(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"later@example.com"}, things)
+	})
+
+	t.Run("Empty file", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAdapter(ctx, t, "")
+
+		things, err := adapter.Get(ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, things)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		tests := map[string]struct {
+			data string
+			want error
+		}{
+			"Missing name": {data: "- expires_at: 2024-06-14T11:00:00Z", want: gosync.ErrMissingConfig},
+			"Invalid time": {data: "- name: foo\n  expires_at: tomorrow"},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				adapter := createAdapter(ctx, t, test.data)
+
+				_, err := adapter.Get(ctx)
+
+				var syncErr *gosync.Error
+
+				require.Error(t, err)
+				require.ErrorAs(t, err, &syncErr)
+				assert.Equal(t, gosync.PhaseGet, syncErr.Phase)
+
+				if test.want != nil {
+					require.ErrorIs(t, err, test.want)
+				}
+			})
+		}
+	})
+}
+
+func TestGrants_Expiring(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	adapter := createAdapter(ctx, t, testGrants)
+	adapter.ExpiryWarning = 30 * 24 * time.Hour
+
+	expiring, err := adapter.Expiring(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, expiring, 2)
+	assert.Equal(t, "expiring@example.com", expiring[0].Name)
+	assert.Equal(t, "INC-1234", expiring[0].Reason)
+	assert.Equal(t, "later@example.com", expiring[1].Name)
+
+	adapter.ExpiryWarning = time.Hour
+
+	expiring, err = adapter.Expiring(ctx)
+
+	require.NoError(t, err)
+	assert.Empty(t, expiring)
+}
+
+func TestGrants_Add(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	adapter := createAdapter(ctx, t, testGrants)
+
+	err := adapter.Add(ctx, []string{"foo@example.com"})
+
+	require.ErrorIs(t, err, gosync.ErrReadOnly)
+}
+
+func TestGrants_Remove(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	adapter := createAdapter(ctx, t, testGrants)
+
+	err := adapter.Remove(ctx, []string{"foo@example.com"})
+
+	require.ErrorIs(t, err, gosync.ErrReadOnly)
+}
+
+func TestGrants_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAdapter(ctx, t, testGrants)
+
+		require.NoError(t, adapter.Validate(ctx))
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := createAdapter(ctx, t, testGrants)
+		adapter.readFile = func(string) ([]byte, error) {
+			return nil, fs.ErrNotExist
+		}
+
+		err := adapter.Validate(ctx)
+
+		require.ErrorIs(t, err, gosync.ErrNotFound)
+	})
+
+	t.Run("Invalid file", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		adapter := createAdapter(ctx, t, testGrants)
+		adapter.readFile = func(string) ([]byte, error) {
+			return nil, testErr
+		}
+
+		err := adapter.Validate(ctx)
+
+		require.ErrorIs(t, err, testErr)
+		require.NotErrorIs(t, err, gosync.ErrNotFound)
+	})
+}
+
+func TestInit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
+			Path:          "grants.yaml",
+			ExpiryWarning: "48h",
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, &Grants{}, adapter)
+		assert.Equal(t, "grants.yaml", adapter.Target())
+		assert.Equal(t, 48*time.Hour, adapter.ExpiryWarning)
+	})
+
+	t.Run("Default expiry warning", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, err := Init(ctx, map[gosync.ConfigKey]string{Path: "grants.yaml"})
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultExpiryWarning, adapter.ExpiryWarning)
+	})
+
+	t.Run("Invalid expiry warning", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Init(ctx, map[gosync.ConfigKey]string{Path: "grants.yaml", ExpiryWarning: "soon"})
+
+		require.ErrorIs(t, err, gosync.ErrInvalidConfig)
+		require.ErrorContains(t, err, ExpiryWarning)
+	})
+
+	t.Run("Missing config", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Init(ctx, map[gosync.ConfigKey]string{})
+
+		require.ErrorIs(t, err, gosync.ErrMissingConfig)
+		require.ErrorContains(t, err, Path)
+	})
+}
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("file/grants")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("file/grants")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
@@ -0,0 +1,76 @@
+package grants_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/file/grants"
+)
+
+func ExampleInit() {
+	ctx := context.Background()
+
+	adapter, err := grants.Init(ctx, map[gosync.ConfigKey]string{
+		grants.Path:          "access/contractors.yaml",
+		grants.ExpiryWarning: "72h",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gosync.New(adapter)
+}
+
+func ExampleGrants_Expiring() {
+	ctx := context.Background()
+
+	adapter, err := grants.Init(ctx, map[gosync.ConfigKey]string{
+		grants.Path: "access/contractors.yaml",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	expiring, err := adapter.Expiring(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, grant := range expiring {
+		fmt.Printf("Access for %s expires at %s\n", grant.Name, grant.ExpiresAt)
+	}
+}
+
+func ExampleWithLogger() {
+	ctx := context.Background()
+
+	logger := log.New(os.Stdout, "", log.LstdFlags)
+
+	adapter, err := grants.Init(ctx, map[gosync.ConfigKey]string{
+		grants.Path: "access/contractors.yaml",
+	}, grants.WithLogger(logger))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gosync.New(adapter)
+}
+
+func ExampleWithSlogLogger() {
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	adapter, err := grants.Init(ctx, map[gosync.ConfigKey]string{
+		grants.Path: "access/contractors.yaml",
+	}, grants.WithSlogLogger(logger))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gosync.New(adapter)
+}
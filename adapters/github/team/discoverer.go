@@ -0,0 +1,107 @@
+package team
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/google/go-github/v47/github"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// Ensure [team.Discoverer] fully satisfies the [gosync.Discoverer] interface.
+var _ gosync.Discoverer = &Discoverer{}
+
+// listTeamsPageSize is the number of teams requested in each call to ListTeams.
+const listTeamsPageSize = 100
+
+// Discoverer finds GitHub teams in an organisation whose slugs match a selector, and initialises an adapter for each.
+type Discoverer struct {
+	teams     iGitHubTeam
+	org       string
+	config    map[gosync.ConfigKey]string
+	configFns []gosync.ConfigFn[*Team]
+}
+
+/*
+NewDiscoverer creates a [gosync.Discoverer] for GitHub teams. It accepts the same config as [Init], except
+[team.TeamSlug], which is set to the slug of each discovered team.
+
+	discoverer, err := team.NewDiscoverer(ctx, map[gosync.ConfigKey]string{
+		team.GitHubToken:        "my-github-token",
+		team.GitHubOrg:          "my-org",
+		team.DiscoveryMechanism: "saml",
+	})
+
+	err = gosync.FanOut(ctx, gosync.Discovery{Discoverer: discoverer, Selector: "platform-*", Source: source})
+*/
+func NewDiscoverer(
+	ctx context.Context,
+	config map[gosync.ConfigKey]string,
+	configFns ...gosync.ConfigFn[*Team],
+) (*Discoverer, error) {
+	// Initialise an adapter without a team, to check the config and create the GitHub client.
+	adapter, err := Init(ctx, withTeamSlug(config, ""), configFns...)
+	if err != nil {
+		return nil, fmt.Errorf("github.team.newdiscoverer -> %w", err)
+	}
+
+	return &Discoverer{
+		teams:     adapter.teams,
+		org:       adapter.org,
+		config:    config,
+		configFns: configFns,
+	}, nil
+}
+
+// Discover GitHub teams whose slugs match the selector, keyed by slug. See [gosync.MatchSelector].
+func (d *Discoverer) Discover(ctx context.Context, selector string) (map[string]gosync.Adapter, error) {
+	out := make(map[string]gosync.Adapter)
+	opts := &github.ListOptions{PerPage: listTeamsPageSize}
+
+	for {
+		teams, resp, err := d.teams.ListTeams(ctx, d.org, opts)
+		if err != nil {
+			return nil, fmt.Errorf("github.team.discover.listteams -> %w", err)
+		}
+
+		for _, team := range teams {
+			matched, err := gosync.MatchSelector(selector, team.GetSlug())
+			if err != nil {
+				return nil, fmt.Errorf("github.team.discover -> %w", err)
+			}
+
+			if !matched {
+				continue
+			}
+
+			adapter, err := Init(ctx, withTeamSlug(d.config, team.GetSlug()), d.configFns...)
+			if err != nil {
+				return nil, fmt.Errorf("github.team.discover(%s) -> %w", team.GetSlug(), err)
+			}
+
+			out[team.GetSlug()] = adapter
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return out, nil
+}
+
+// withTeamSlug returns a copy of config with the team slug set.
+func withTeamSlug(config map[gosync.ConfigKey]string, slug string) map[gosync.ConfigKey]string {
+	out := maps.Clone(config)
+	if out == nil {
+		out = make(map[gosync.ConfigKey]string)
+	}
+
+	out[TeamSlug] = slug
+
+	return out
+}
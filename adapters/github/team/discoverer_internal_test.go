@@ -0,0 +1,82 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+func TestDiscoverer_Discover(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	newDiscoverer := func(t *testing.T) (*Discoverer, *mockIGitHubTeam) {
+		t.Helper()
+
+		gitHubClient := newMockIGitHubTeam(t)
+		discovery := NewMockGitHubDiscovery(t)
+
+		discoverer, err := NewDiscoverer(ctx, map[gosync.ConfigKey]string{GitHubOrg: "org"}, func(team *Team) {
+			team.teams = gitHubClient
+			team.discovery = discovery
+		})
+		require.NoError(t, err)
+
+		return discoverer, gitHubClient
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		discoverer, gitHubClient := newDiscoverer(t)
+
+		gitHubClient.EXPECT().ListTeams(ctx, "org", &github.ListOptions{PerPage: 100}).Return(
+			[]*github.Team{{Slug: github.String("platform-foo")}, {Slug: github.String("data")}},
+			&github.Response{NextPage: 2},
+			nil,
+		)
+		gitHubClient.EXPECT().ListTeams(ctx, "org", &github.ListOptions{PerPage: 100, Page: 2}).Return(
+			[]*github.Team{{Slug: github.String("platform-bar")}},
+			&github.Response{},
+			nil,
+		)
+
+		adapters, err := discoverer.Discover(ctx, "platform-*")
+
+		require.NoError(t, err)
+		assert.Len(t, adapters, 2)
+		assert.Equal(t, "platform-foo", adapters["platform-foo"].(*Team).slug) //nolint:forcetypeassert
+		assert.Equal(t, "platform-bar", adapters["platform-bar"].(*Team).slug) //nolint:forcetypeassert
+		assert.Equal(t, "org", adapters["platform-bar"].(*Team).org)           //nolint:forcetypeassert
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		discoverer, gitHubClient := newDiscoverer(t)
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		gitHubClient.EXPECT().ListTeams(ctx, "org", &github.ListOptions{PerPage: 100}).Return(nil, nil, testErr)
+
+		_, err := discoverer.Discover(ctx, "platform-*")
+
+		require.ErrorIs(t, err, testErr)
+	})
+
+	t.Run("Missing config", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewDiscoverer(ctx, map[gosync.ConfigKey]string{})
+
+		require.ErrorIs(t, err, gosync.ErrMissingConfig)
+		require.ErrorContains(t, err, GitHubOrg)
+	})
+}
@@ -0,0 +1,134 @@
+package team
+
+import (
+	"context"
+	"log/slog"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// RoleAttribute is the attribute of a [gosync.Item] that holds a member's role, either `member` or `maintainer`.
+const RoleAttribute = "role"
+
+// The roles that a member of a GitHub team can have.
+const (
+	RoleMember     = "member"
+	RoleMaintainer = "maintainer"
+)
+
+var (
+	// Ensure [team.Members] fully satisfies the [gosync.TypedAdapter] interface.
+	_ gosync.TypedAdapter[gosync.Item] = &Members{}
+	// Ensure [team.Members] fully satisfies the [gosync.Updater] interface.
+	_ gosync.Updater[gosync.Item] = &Members{}
+	// Ensure [team.Members] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Describer = &Members{}
+	// Ensure [team.Members] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Capable = &Members{}
+)
+
+/*
+Members is a [gosync.TypedAdapter] for the members of a GitHub team and whether they're a maintainer, so that
+[gosync.TypedSync] can promote or demote an existing member rather than removing and adding them again. Each member is a
+[gosync.Item] keyed by their email, with [team.RoleMember] or [team.RoleMaintainer] in the [team.RoleAttribute]
+attribute. Members that are added without a role become a [team.RoleMember].
+
+	err := gosync.NewTyped[gosync.Item](source).SyncWith(ctx, team.NewMembers(adapter))
+*/
+type Members struct {
+	team *Team
+}
+
+// NewMembers returns a typed adapter for the members of a GitHub team and their roles.
+func NewMembers(team *Team) *Members {
+	return &Members{team: team}
+}
+
+// Get members of the GitHub team, with their roles.
+func (m *Members) Get(ctx context.Context) ([]gosync.Item, error) {
+	m.team.Logger.Info("Fetching accounts and roles from GitHub team")
+
+	m.team.cache = make(map[string]string)
+
+	items := make([]gosync.Item, 0)
+
+	for _, role := range []string{RoleMaintainer, RoleMember} {
+		emails, err := m.team.list(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, email := range emails {
+			items = append(items, gosync.Item{ID: email, Attributes: map[string]string{RoleAttribute: role}})
+		}
+	}
+
+	m.team.Logger.Info("Fetched accounts successfully", slog.Int(gosync.LogKeyCount, len(items)))
+
+	return items, nil
+}
+
+// Add members to the GitHub team, with their roles.
+func (m *Members) Add(ctx context.Context, items []gosync.Item) error {
+	m.team.Logger.Info("Adding accounts to GitHub team", slog.Int(gosync.LogKeyCount, len(items)))
+
+	for _, item := range items {
+		if err := m.team.addMembership(ctx, gosync.PhaseAdd, item.ID, role(item)); err != nil {
+			return err
+		}
+	}
+
+	m.team.Logger.Info("Finished adding accounts successfully")
+
+	return nil
+}
+
+// Remove members from the GitHub team.
+func (m *Members) Remove(ctx context.Context, items []gosync.Item) error {
+	emails := make([]string, 0, len(items))
+
+	for _, item := range items {
+		emails = append(emails, item.ID)
+	}
+
+	return m.team.Remove(ctx, emails)
+}
+
+// Update the roles of existing members of the GitHub team.
+func (m *Members) Update(ctx context.Context, items []gosync.Item) error {
+	m.team.Logger.Info("Updating roles in GitHub team", slog.Int(gosync.LogKeyCount, len(items)))
+
+	for _, item := range items {
+		if err := m.team.addMembership(ctx, gosync.PhaseUpdate, item.ID, role(item)); err != nil {
+			return err
+		}
+	}
+
+	m.team.Logger.Info("Finished updating roles successfully")
+
+	return nil
+}
+
+// role of a member, which is RoleMember unless the item has one.
+func role(item gosync.Item) string {
+	if role := item.Attributes[RoleAttribute]; role != "" {
+		return role
+	}
+
+	return RoleMember
+}
+
+// Kind of adapter.
+func (m *Members) Kind() string {
+	return m.team.Kind()
+}
+
+// Target returns the GitHub team as `org/slug`.
+func (m *Members) Target() string {
+	return m.team.Target()
+}
+
+// Capabilities of the adapter.
+func (m *Members) Capabilities() gosync.Capabilities {
+	return m.team.Capabilities()
+}
@@ -0,0 +1,134 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/google/go-github/v47/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// newMockMembers returns a typed Members adapter for a GitHub team with mocked clients.
+func newMockMembers(t *testing.T) (*Members, *mockIGitHubTeam, *MockGitHubDiscovery) {
+	t.Helper()
+
+	gitHubClient := newMockIGitHubTeam(t)
+	discovery := NewMockGitHubDiscovery(t)
+
+	team := &Team{
+		teams:     gitHubClient,
+		discovery: discovery,
+		org:       "org",
+		slug:      "slug",
+		cache:     make(map[string]string),
+		Logger:    slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	return NewMembers(team), gitHubClient, discovery
+}
+
+func TestMembers_Get(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	members, gitHubClient, discovery := newMockMembers(t)
+
+	gitHubClient.
+		EXPECT().
+		ListTeamMembersBySlug(ctx, "org", "slug", &github.TeamListTeamMembersOptions{Role: RoleMaintainer}).
+		Return([]*github.User{{Login: github.String("foo")}}, &github.Response{}, nil)
+	gitHubClient.
+		EXPECT().
+		ListTeamMembersBySlug(ctx, "org", "slug", &github.TeamListTeamMembersOptions{Role: RoleMember}).
+		Return([]*github.User{{Login: github.String("bar")}}, &github.Response{}, nil)
+	discovery.EXPECT().GetEmailFromUsername(ctx, []string{"foo"}).Return([]string{"foo@email"}, nil)
+	discovery.EXPECT().GetEmailFromUsername(ctx, []string{"bar"}).Return([]string{"bar@email"}, nil)
+
+	items, err := members.Get(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []gosync.Item{
+		{ID: "foo@email", Attributes: map[string]string{RoleAttribute: RoleMaintainer}},
+		{ID: "bar@email", Attributes: map[string]string{RoleAttribute: RoleMember}},
+	}, items)
+	assert.Equal(t, map[string]string{"foo@email": "foo", "bar@email": "bar"}, members.team.cache)
+}
+
+func TestMembers_Add(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	members, gitHubClient, discovery := newMockMembers(t)
+
+	discovery.EXPECT().GetUsernameFromEmail(ctx, []string{"foo@email"}).Return([]string{"foo"}, nil)
+	discovery.EXPECT().GetUsernameFromEmail(ctx, []string{"bar@email"}).Return([]string{"bar"}, nil)
+	gitHubClient.EXPECT().
+		AddTeamMembershipBySlug(ctx, "org", "slug", "foo", &github.TeamAddTeamMembershipOptions{Role: RoleMaintainer}).
+		Return(nil, nil, nil)
+	gitHubClient.EXPECT().
+		AddTeamMembershipBySlug(ctx, "org", "slug", "bar", &github.TeamAddTeamMembershipOptions{Role: RoleMember}).
+		Return(nil, nil, nil)
+
+	err := members.Add(ctx, []gosync.Item{
+		{ID: "foo@email", Attributes: map[string]string{RoleAttribute: RoleMaintainer}},
+		{ID: "bar@email"},
+	})
+
+	require.NoError(t, err)
+}
+
+func TestMembers_Update(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	testErr := errors.New("foo")
+
+	tests := map[string]struct {
+		err     error
+		wantErr error
+	}{
+		"Success": {err: nil, wantErr: nil},
+		"Error":   {err: testErr, wantErr: testErr},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			members, gitHubClient, discovery := newMockMembers(t)
+
+			discovery.EXPECT().GetUsernameFromEmail(ctx, []string{"foo@email"}).Return([]string{"foo"}, nil)
+			gitHubClient.EXPECT().
+				AddTeamMembershipBySlug(ctx, "org", "slug", "foo", &github.TeamAddTeamMembershipOptions{
+					Role: RoleMaintainer,
+				}).
+				Return(nil, nil, test.err)
+
+			err := members.Update(ctx, []gosync.Item{
+				{ID: "foo@email", Attributes: map[string]string{RoleAttribute: RoleMaintainer}},
+			})
+
+			if test.wantErr == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, test.wantErr)
+
+			var syncErr *gosync.Error
+			require.ErrorAs(t, err, &syncErr)
+			assert.Equal(t, gosync.PhaseUpdate, syncErr.Phase)
+			assert.Equal(t, []string{"foo@email"}, syncErr.Things)
+		})
+	}
+}
@@ -93,6 +93,75 @@ func (_c *mockIGitHubTeam_AddTeamMembershipBySlug_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// GetTeamBySlug provides a mock function with given fields: ctx, org, slug
+func (_m *mockIGitHubTeam) GetTeamBySlug(ctx context.Context, org string, slug string) (*github.Team, *github.Response, error) {
+	ret := _m.Called(ctx, org, slug)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamBySlug")
+	}
+
+	var r0 *github.Team
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*github.Team, *github.Response, error)); ok {
+		return rf(ctx, org, slug)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *github.Team); ok {
+		r0 = rf(ctx, org, slug)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *github.Response); ok {
+		r1 = rf(ctx, org, slug)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, org, slug)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// mockIGitHubTeam_GetTeamBySlug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamBySlug'
+type mockIGitHubTeam_GetTeamBySlug_Call struct {
+	*mock.Call
+}
+
+// GetTeamBySlug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org string
+//   - slug string
+func (_e *mockIGitHubTeam_Expecter) GetTeamBySlug(ctx interface{}, org interface{}, slug interface{}) *mockIGitHubTeam_GetTeamBySlug_Call {
+	return &mockIGitHubTeam_GetTeamBySlug_Call{Call: _e.mock.On("GetTeamBySlug", ctx, org, slug)}
+}
+
+func (_c *mockIGitHubTeam_GetTeamBySlug_Call) Run(run func(ctx context.Context, org string, slug string)) *mockIGitHubTeam_GetTeamBySlug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *mockIGitHubTeam_GetTeamBySlug_Call) Return(_a0 *github.Team, _a1 *github.Response, _a2 error) *mockIGitHubTeam_GetTeamBySlug_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *mockIGitHubTeam_GetTeamBySlug_Call) RunAndReturn(run func(context.Context, string, string) (*github.Team, *github.Response, error)) *mockIGitHubTeam_GetTeamBySlug_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListTeamMembersBySlug provides a mock function with given fields: ctx, org, slug, opts
 func (_m *mockIGitHubTeam) ListTeamMembersBySlug(ctx context.Context, org string, slug string, opts *github.TeamListTeamMembersOptions) ([]*github.User, *github.Response, error) {
 	ret := _m.Called(ctx, org, slug, opts)
@@ -163,6 +232,75 @@ func (_c *mockIGitHubTeam_ListTeamMembersBySlug_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// ListTeams provides a mock function with given fields: ctx, org, opts
+func (_m *mockIGitHubTeam) ListTeams(ctx context.Context, org string, opts *github.ListOptions) ([]*github.Team, *github.Response, error) {
+	ret := _m.Called(ctx, org, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTeams")
+	}
+
+	var r0 []*github.Team
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *github.ListOptions) ([]*github.Team, *github.Response, error)); ok {
+		return rf(ctx, org, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *github.ListOptions) []*github.Team); ok {
+		r0 = rf(ctx, org, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *github.ListOptions) *github.Response); ok {
+		r1 = rf(ctx, org, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, *github.ListOptions) error); ok {
+		r2 = rf(ctx, org, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// mockIGitHubTeam_ListTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTeams'
+type mockIGitHubTeam_ListTeams_Call struct {
+	*mock.Call
+}
+
+// ListTeams is a helper method to define mock.On call
+//   - ctx context.Context
+//   - org string
+//   - opts *github.ListOptions
+func (_e *mockIGitHubTeam_Expecter) ListTeams(ctx interface{}, org interface{}, opts interface{}) *mockIGitHubTeam_ListTeams_Call {
+	return &mockIGitHubTeam_ListTeams_Call{Call: _e.mock.On("ListTeams", ctx, org, opts)}
+}
+
+func (_c *mockIGitHubTeam_ListTeams_Call) Run(run func(ctx context.Context, org string, opts *github.ListOptions)) *mockIGitHubTeam_ListTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*github.ListOptions))
+	})
+	return _c
+}
+
+func (_c *mockIGitHubTeam_ListTeams_Call) Return(_a0 []*github.Team, _a1 *github.Response, _a2 error) *mockIGitHubTeam_ListTeams_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *mockIGitHubTeam_ListTeams_Call) RunAndReturn(run func(context.Context, string, *github.ListOptions) ([]*github.Team, *github.Response, error)) *mockIGitHubTeam_ListTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveTeamMembershipBySlug provides a mock function with given fields: ctx, org, slug, user
 func (_m *mockIGitHubTeam) RemoveTeamMembershipBySlug(ctx context.Context, org string, slug string, user string) (*github.Response, error) {
 	ret := _m.Called(ctx, org, slug, user)
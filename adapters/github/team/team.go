@@ -26,11 +26,13 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"net/http"
 	"strings"
 
 	"github.com/google/go-github/v47/github"
 	"github.com/shurcooL/githubv4"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/oauth2"
 
 	gosync "github.com/ovotech/go-sync"
@@ -81,9 +83,35 @@ const SamlMuteUserNotFoundErr gosync.ConfigKey = "saml_mute_user_not_found_err"
 
 var (
 	_ gosync.Adapter       = &Team{} // Ensure [team.Team] fully satisfies the [gosync.Adapter] interface.
+	_ gosync.Describer     = &Team{} // Ensure [team.Team] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Capable       = &Team{} // Ensure [team.Team] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Validator     = &Team{} // Ensure [team.Team] fully satisfies the [gosync.Validator] interface.
 	_ gosync.InitFn[*Team] = Init    // Ensure [team.Init] fully satisfies the [gosync.InitFn] type.
 )
 
+// Schema describes the configuration that [team.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: GitHubToken, Secret: true, Description: "GitHub token with read:org and admin:org scopes."},
+	{Key: GitHubOrg, Required: true, Description: "Name of the GitHub organisation."},
+	{Key: TeamSlug, Required: true, Description: "Slug of the team within the organisation."},
+	{
+		Key:         DiscoveryMechanism,
+		Allowed:     []string{"saml"},
+		Description: "How emails are converted into GitHub users. Needed unless a discovery service is passed.",
+	},
+	{
+		Key:         SamlMuteUserNotFoundErr,
+		Type:        gosync.ConfigBool,
+		Description: "Mute the error when SAML discovery can't find a user.",
+	},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("github/team", Init)
+	gosync.RegisterSchema("github/team", Schema)
+}
+
 // iSlackConversation is a subset of the Slack Client used to build mocks for easy testing.
 type iGitHubTeam interface {
 	ListTeamMembersBySlug(
@@ -100,32 +128,49 @@ type iGitHubTeam interface {
 		opts *github.TeamAddTeamMembershipOptions,
 	) (*github.Membership, *github.Response, error)
 	RemoveTeamMembershipBySlug(ctx context.Context, org, slug, user string) (*github.Response, error)
+	GetTeamBySlug(ctx context.Context, org, slug string) (*github.Team, *github.Response, error)
+	ListTeams(ctx context.Context, org string, opts *github.ListOptions) ([]*github.Team, *github.Response, error)
 }
 
+// requiredScope is the OAuth scope needed to manage team memberships with a classic Personal Access Token.
+const requiredScope = "admin:org"
+
 type Team struct {
 	teams     iGitHubTeam               // GitHub v3 REST API teams.
 	discovery discovery.GitHubDiscovery // DiscoveryMechanism adapter to convert GH users -> emails (and vice versa).
 	org       string                    // GitHub organisation.
 	slug      string                    // GitHub team slug.
 	cache     map[string]string         // Cache of users.
-	Logger    *log.Logger
+	Logger    *slog.Logger
 }
 
 // Get email addresses in a GitHub Team.
 func (t *Team) Get(ctx context.Context) ([]string, error) {
-	t.Logger.Printf("Fetching accounts from GitHub team %s/%s", t.org, t.slug)
+	t.Logger.Info("Fetching accounts from GitHub team")
 
 	// Initialise the cache.
 	t.cache = make(map[string]string)
 
+	out, err := t.list(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	t.Logger.Info("Fetched accounts successfully", slog.Int(gosync.LogKeyCount, len(out)))
+
+	return out, nil
+}
+
+// list fetches the email addresses of team members with a role, or every member if role is empty, and caches them.
+func (t *Team) list(ctx context.Context, role string) ([]string, error) {
 	out := make([]string, 0)
 
-	opts := &github.TeamListTeamMembersOptions{}
+	opts := &github.TeamListTeamMembersOptions{Role: role}
 
 	for {
 		users, resp, err := t.teams.ListTeamMembersBySlug(ctx, t.org, t.slug, opts)
 		if err != nil {
-			return nil, fmt.Errorf("github.team.get.listteammembersbyslug(%s, %s) -> %w", t.org, t.slug, err)
+			return nil, gosync.NewError(t, gosync.PhaseGet, nil, fmt.Errorf("listteammembersbyslug -> %w", err))
 		}
 
 		logins := make([]string, 0, len(users))
@@ -135,7 +180,7 @@ func (t *Team) Get(ctx context.Context) ([]string, error) {
 
 		emails, err := t.discovery.GetEmailFromUsername(ctx, logins)
 		if err != nil {
-			return nil, fmt.Errorf("github.team.get.discovery -> %w", err)
+			return nil, gosync.NewError(t, gosync.PhaseGet, nil, fmt.Errorf("discovery -> %w", err))
 		}
 
 		out = append(out, emails...)
@@ -151,58 +196,122 @@ func (t *Team) Get(ctx context.Context) ([]string, error) {
 		opts.Page = resp.NextPage
 	}
 
-	t.Logger.Println("Fetched accounts successfully")
-
 	return out, nil
 }
 
 // Add email addresses to a GitHub Team.
 func (t *Team) Add(ctx context.Context, emails []string) error {
-	t.Logger.Printf("Adding %s to GitHub team %s/%s", emails, t.org, t.slug)
+	t.Logger.Info("Adding accounts to GitHub team", slog.Int(gosync.LogKeyCount, len(emails)))
+
+	for _, email := range emails {
+		if err := t.addMembership(ctx, gosync.PhaseAdd, email, RoleMember); err != nil {
+			return err
+		}
+	}
 
-	names, err := t.discovery.GetUsernameFromEmail(ctx, emails)
+	t.Logger.Info("Finished adding accounts successfully")
+
+	return nil
+}
+
+/*
+addMembership adds a member to the team with a role. GitHub changes the role of existing members, so it's also used to
+update them.
+*/
+func (t *Team) addMembership(ctx context.Context, phase gosync.Phase, email string, role string) error {
+	// Usernames are looked up one email at a time, as discovery can skip emails that it can't find.
+	names, err := t.discovery.GetUsernameFromEmail(ctx, []string{email})
 	if err != nil {
-		return fmt.Errorf("github.team.add.discovery -> %w", err)
+		return gosync.NewError(t, phase, []string{email}, fmt.Errorf("discovery -> %w", err))
 	}
 
-	for _, name := range names {
-		opts := &github.TeamAddTeamMembershipOptions{
-			Role: "member",
-		}
+	if len(names) == 0 {
+		t.Logger.Debug("Account not found, skipping", slog.String(gosync.LogKeyThing, email))
 
-		_, _, err = t.teams.AddTeamMembershipBySlug(ctx, t.org, t.slug, name, opts)
-		if err != nil {
-			return fmt.Errorf("github.team.add.addteammembershipbyslug(%s, %s, %s) -> %w", t.org, t.slug, name, err)
-		}
+		return nil
 	}
 
-	t.Logger.Println("Finished adding accounts successfully")
+	t.Logger.Debug("Adding account", slog.String(gosync.LogKeyThing, email), slog.String("role", role))
+
+	opts := &github.TeamAddTeamMembershipOptions{
+		Role: role,
+	}
+
+	_, _, err = t.teams.AddTeamMembershipBySlug(ctx, t.org, t.slug, names[0], opts)
+	if err != nil {
+		return gosync.NewError(t, phase, []string{email}, fmt.Errorf("addteammembershipbyslug -> %w", err))
+	}
 
 	return nil
 }
 
 // Remove email addresses from a GitHub Team.
 func (t *Team) Remove(ctx context.Context, emails []string) error {
-	t.Logger.Printf("Removing %s from GitHub team %s/%s", emails, t.org, t.slug)
+	t.Logger.Info("Removing accounts from GitHub team", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	if t.cache == nil {
-		return fmt.Errorf("github.team.remove -> %w", gosync.ErrCacheEmpty)
+		return gosync.NewError(t, gosync.PhaseRemove, emails, gosync.ErrCacheEmpty)
 	}
 
 	for _, email := range emails {
-		name := t.cache[email]
+		name, ok := t.cache[email]
+		if !ok {
+			t.Logger.Debug("Account isn't a member, skipping", slog.String(gosync.LogKeyThing, email))
 
-		_, err := t.teams.RemoveTeamMembershipBySlug(ctx, t.org, t.slug, name)
-		if err != nil {
-			return fmt.Errorf("github.team.remove.removeteammembershipbyslug -> %w", err)
+			continue
+		}
+
+		t.Logger.Debug("Removing account", slog.String(gosync.LogKeyThing, email))
+
+		resp, err := t.teams.RemoveTeamMembershipBySlug(ctx, t.org, t.slug, name)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			t.Logger.Debug("Account isn't a member, skipping", slog.String(gosync.LogKeyThing, email))
+
+			continue
+		} else if err != nil {
+			return gosync.NewError(t, gosync.PhaseRemove, []string{email}, fmt.Errorf("removeteammembershipbyslug -> %w", err))
 		}
 	}
 
-	t.Logger.Println("Finished removing accounts successfully")
+	t.Logger.Info("Finished removing accounts successfully")
 
 	return nil
 }
 
+/*
+Validate checks that the GitHub team exists, and that the token has been granted the admin:org scope. Scopes are only
+returned by GitHub for classic Personal Access Tokens, so they aren't checked for other types of token.
+*/
+func (t *Team) Validate(ctx context.Context) error {
+	_, resp, err := t.teams.GetTeamBySlug(ctx, t.org, t.slug)
+
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		return gosync.NewError(t, gosync.PhaseValidate, nil, fmt.Errorf("getteambyslug -> %w", gosync.ErrNotFound))
+	case resp != nil && resp.StatusCode == http.StatusForbidden:
+		return gosync.NewError(t, gosync.PhaseValidate, nil, fmt.Errorf("getteambyslug -> %w", gosync.ErrMissingPermission))
+	case err != nil:
+		return gosync.NewError(t, gosync.PhaseValidate, nil, fmt.Errorf("getteambyslug -> %w", err))
+	}
+
+	granted := resp.Header.Get("X-OAuth-Scopes")
+	if granted == "" {
+		return nil
+	}
+
+	for _, scope := range strings.Split(granted, ",") {
+		if strings.TrimSpace(scope) == requiredScope {
+			return nil
+		}
+	}
+
+	return gosync.NewError(t, gosync.PhaseValidate, nil, fmt.Errorf(
+		"scopes -> %w(%s)",
+		gosync.ErrMissingPermission,
+		requiredScope,
+	))
+}
+
 // WithClient passes a custom GitHub client to the adapter.
 func WithClient(client *github.Client) gosync.ConfigFn[*Team] {
 	return func(t *Team) {
@@ -217,8 +326,34 @@ func WithDiscoveryService(discovery discovery.GitHubDiscovery) gosync.ConfigFn[*
 	}
 }
 
-// WithLogger passes a custom logger to the adapter.
+// Kind of adapter.
+func (t *Team) Kind() string {
+	return "github/team"
+}
+
+// Target returns the GitHub team as `org/slug`.
+func (t *Team) Target() string {
+	return t.org + "/" + t.slug
+}
+
+// Capabilities of the adapter. Remove uses the cache populated by Get.
+func (t *Team) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+		RequiresGet:    true,
+	}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
 func WithLogger(logger *log.Logger) gosync.ConfigFn[*Team] {
+	return func(t *Team) {
+		t.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*Team] {
 	return func(t *Team) {
 		t.Logger = logger
 	}
@@ -237,36 +372,41 @@ func Init(
 	config map[gosync.ConfigKey]string,
 	configFns ...gosync.ConfigFn[*Team],
 ) (*Team, error) {
-	for _, key := range []gosync.ConfigKey{GitHubOrg, TeamSlug} {
-		if _, ok := config[key]; !ok {
-			return nil, fmt.Errorf("github.team.init -> %w(%s)", gosync.ErrMissingConfig, key)
-		}
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("github.team.init -> %w", err)
 	}
 
 	adapter := &Team{
-		org:   config[GitHubOrg],
-		slug:  config[TeamSlug],
+		org:   parsed.String(GitHubOrg),
+		slug:  parsed.String(TeamSlug),
 		cache: make(map[string]string),
 	}
 
-	if config[GitHubToken] != "" {
+	// Instrument requests to GitHub with OpenTelemetry, unless the context already has a custom HTTP client.
+	if _, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); !ok {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		})
+	}
+
+	token := parsed.String(GitHubToken)
+
+	if token != "" {
 		oauthClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(
-			&oauth2.Token{AccessToken: config[GitHubToken]},
+			&oauth2.Token{AccessToken: token},
 		))
 
 		WithClient(github.NewClient(oauthClient))(adapter)
 	}
 
-	if config[GitHubToken] != "" && strings.ToLower(config[DiscoveryMechanism]) == "saml" {
+	if token != "" && parsed.String(DiscoveryMechanism) == "saml" {
 		oauthClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(
-			&oauth2.Token{AccessToken: config[GitHubToken]},
+			&oauth2.Token{AccessToken: token},
 		))
 
-		discoverySvc := saml.New(githubv4.NewClient(oauthClient), config[GitHubOrg])
-
-		if strings.ToLower(config[SamlMuteUserNotFoundErr]) == "true" {
-			discoverySvc.MuteUserNotFoundErr = true
-		}
+		discoverySvc := saml.New(githubv4.NewClient(oauthClient), parsed.String(GitHubOrg))
+		discoverySvc.MuteUserNotFoundErr = parsed.Bool(SamlMuteUserNotFoundErr)
 
 		WithDiscoveryService(discoverySvc)(adapter)
 	}
@@ -276,13 +416,11 @@ func Init(
 	}
 
 	if adapter.Logger == nil {
-		logger := log.New(
-			os.Stderr, "[go-sync/github/team] ", log.LstdFlags|log.Lshortfile|log.Lmsgprefix,
-		)
-
-		WithLogger(logger)(adapter)
+		WithSlogLogger(slog.Default())(adapter)
 	}
 
+	parsed.LogUnknown(adapter.Logger)
+
 	if adapter.teams == nil {
 		return nil, fmt.Errorf("github.team.init -> %w(%s)", gosync.ErrMissingConfig, GitHubToken)
 	}
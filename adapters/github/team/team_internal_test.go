@@ -1,8 +1,12 @@
 package team
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
 	"testing"
 
@@ -14,6 +18,7 @@ import (
 
 	gosync "github.com/ovotech/go-sync"
 	"github.com/ovotech/go-sync/adapters/github/discovery/saml"
+	"github.com/ovotech/go-sync/adaptertest"
 )
 
 func TestTeam_Get(t *testing.T) {
@@ -29,7 +34,7 @@ func TestTeam_Get(t *testing.T) {
 		discovery: discovery,
 		org:       "org",
 		slug:      "slug",
-		Logger:    log.New(os.Stdout, "", log.LstdFlags),
+		Logger:    slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	gitHubClient.
@@ -69,21 +74,52 @@ func TestTeam_Add(t *testing.T) {
 		discovery: discovery,
 		org:       "org",
 		slug:      "slug",
-		Logger:    log.New(os.Stdout, "", log.LstdFlags),
+		Logger:    slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
-	discovery.EXPECT().GetUsernameFromEmail(ctx, []string{"fizz@email", "buzz@email"}).
-		Maybe().Return([]string{"fizz", "buzz"}, nil)
-	discovery.EXPECT().GetUsernameFromEmail(ctx, []string{"fizz@email", "buzz@email"}).
-		Maybe().Return([]string{"buzz", "fizz"}, nil)
+	discovery.EXPECT().GetUsernameFromEmail(ctx, []string{"fizz@email"}).Return([]string{"fizz"}, nil)
+	discovery.EXPECT().GetUsernameFromEmail(ctx, []string{"buzz@email"}).Return([]string{"buzz"}, nil)
+	discovery.EXPECT().GetUsernameFromEmail(ctx, []string{"missing@email"}).Return([]string{}, nil)
 	gitHubClient.EXPECT().AddTeamMembershipBySlug(ctx, "org", "slug", "fizz", mock.Anything).Return(nil, nil, nil)
 	gitHubClient.EXPECT().AddTeamMembershipBySlug(ctx, "org", "slug", "buzz", mock.Anything).Return(nil, nil, nil)
 
-	err := adapter.Add(ctx, []string{"fizz@email", "buzz@email"})
+	err := adapter.Add(ctx, []string{"fizz@email", "missing@email", "buzz@email"})
 
 	require.NoError(t, err)
 }
 
+func TestTeam_Add_Error(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	gitHubClient := newMockIGitHubTeam(t)
+	discovery := NewMockGitHubDiscovery(t)
+
+	adapter := &Team{
+		teams:     gitHubClient,
+		discovery: discovery,
+		org:       "org",
+		slug:      "slug",
+		Logger:    slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	testErr := errors.New("foo") //nolint:goerr113
+
+	discovery.EXPECT().GetUsernameFromEmail(ctx, []string{"foo@email"}).Return([]string{"foo"}, nil)
+	gitHubClient.EXPECT().AddTeamMembershipBySlug(ctx, "org", "slug", "foo", mock.Anything).Return(nil, nil, testErr)
+
+	err := adapter.Add(ctx, []string{"foo@email"})
+
+	var syncErr *gosync.Error
+
+	// The error names the email that Sync passed in, rather than the GitHub username.
+	require.ErrorIs(t, err, testErr)
+	require.ErrorAs(t, err, &syncErr)
+	assert.Equal(t, gosync.PhaseAdd, syncErr.Phase)
+	assert.Equal(t, []string{"foo@email"}, syncErr.Things)
+}
+
 func TestTeam_Remove(t *testing.T) {
 	t.Parallel()
 
@@ -98,7 +134,7 @@ func TestTeam_Remove(t *testing.T) {
 		org:       "org",
 		slug:      "slug",
 		cache:     map[string]string{"foo@email": "foo", "bar@email": "bar"},
-		Logger:    log.New(os.Stdout, "", log.LstdFlags),
+		Logger:    slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	gitHubClient.EXPECT().RemoveTeamMembershipBySlug(ctx, "org", "slug", "foo").Return(nil, nil)
@@ -109,6 +145,130 @@ func TestTeam_Remove(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestTeam_Remove_Error(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	gitHubClient := newMockIGitHubTeam(t)
+
+	adapter := &Team{
+		teams:  gitHubClient,
+		org:    "org",
+		slug:   "slug",
+		cache:  map[string]string{"foo@email": "foo"},
+		Logger: slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	testErr := errors.New("foo") //nolint:goerr113
+
+	gitHubClient.EXPECT().RemoveTeamMembershipBySlug(ctx, "org", "slug", "foo").Return(nil, testErr)
+
+	err := adapter.Remove(ctx, []string{"foo@email"})
+
+	var syncErr *gosync.Error
+
+	require.ErrorIs(t, err, testErr)
+	require.ErrorAs(t, err, &syncErr)
+	assert.Equal(t, gosync.PhaseRemove, syncErr.Phase)
+	assert.Equal(t, "github/team", syncErr.Kind)
+	assert.Equal(t, "org/slug", syncErr.Target)
+	assert.Equal(t, []string{"foo@email"}, syncErr.Things)
+
+	adapter.cache = nil
+
+	err = adapter.Remove(ctx, []string{"foo@email"})
+
+	require.ErrorIs(t, err, gosync.ErrCacheEmpty)
+	require.ErrorAs(t, err, &syncErr)
+}
+
+func TestTeam_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	gitHubClient := newMockIGitHubTeam(t)
+	discovery := NewMockGitHubDiscovery(t)
+
+	adapter := &Team{
+		teams:     gitHubClient,
+		discovery: discovery,
+		org:       "org",
+		slug:      "slug",
+		cache:     map[string]string{"foo@email": "foo", "bar@email": "bar"},
+		Logger:    slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	notFound := &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+
+	discovery.EXPECT().GetUsernameFromEmail(ctx, []string{"foo@email"}).Return([]string{"foo"}, nil)
+	gitHubClient.EXPECT().AddTeamMembershipBySlug(ctx, "org", "slug", "foo", mock.Anything).Return(nil, nil, nil)
+	gitHubClient.EXPECT().RemoveTeamMembershipBySlug(ctx, "org", "slug", "bar").Return(notFound, &github.ErrorResponse{
+		Response: notFound.Response,
+		Message:  "Not Found",
+	})
+
+	adaptertest.Idempotent(ctx, t, adapter, []string{"foo@email"}, []string{"bar@email", "baz@email"})
+}
+
+func TestTeam_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	response := func(status int, scopes string) *github.Response {
+		return &github.Response{Response: &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"X-Oauth-Scopes": []string{scopes}},
+		}}
+	}
+
+	errForbidden := errors.New("forbidden") //nolint:goerr113
+	errNotFound := errors.New("not found")  //nolint:goerr113
+
+	tests := map[string]struct {
+		resp *github.Response
+		err  error
+		want error
+	}{
+		"Success":            {resp: response(http.StatusOK, "admin:org, repo")},
+		"Fine-grained token": {resp: response(http.StatusOK, "")},
+		"Missing scope":      {resp: response(http.StatusOK, "read:org"), want: gosync.ErrMissingPermission},
+		"Forbidden": {
+			resp: response(http.StatusForbidden, ""),
+			err:  errForbidden,
+			want: gosync.ErrMissingPermission,
+		},
+		"Not found": {resp: response(http.StatusNotFound, ""), err: errNotFound, want: gosync.ErrNotFound},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			gitHubClient := newMockIGitHubTeam(t)
+			gitHubClient.EXPECT().GetTeamBySlug(ctx, "org", "slug").Return(&github.Team{}, test.resp, test.err)
+
+			adapter := &Team{teams: gitHubClient, org: "org", slug: "slug"}
+
+			err := adapter.Validate(ctx)
+
+			if test.want == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var syncErr *gosync.Error
+
+			require.ErrorIs(t, err, test.want)
+			require.ErrorAs(t, err, &syncErr)
+			assert.Equal(t, gosync.PhaseValidate, syncErr.Phase)
+		})
+	}
+}
+
 func TestInit(t *testing.T) {
 	t.Parallel()
 
@@ -184,13 +344,31 @@ func TestInit(t *testing.T) {
 			DiscoveryMechanism: "foo",
 		})
 
-		require.ErrorIs(t, err, gosync.ErrMissingConfig)
+		require.ErrorIs(t, err, gosync.ErrInvalidConfig)
+		require.ErrorContains(t, err, DiscoveryMechanism)
+	})
+
+	t.Run("invalid bool", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Init(ctx, map[gosync.ConfigKey]string{
+			GitHubToken:             "token",
+			GitHubOrg:               "org",
+			TeamSlug:                "slug",
+			DiscoveryMechanism:      "saml",
+			SamlMuteUserNotFoundErr: "maybe",
+		})
+
+		require.ErrorIs(t, err, gosync.ErrInvalidConfig)
+		require.ErrorContains(t, err, SamlMuteUserNotFoundErr)
 	})
 
 	t.Run("with logger", func(t *testing.T) {
 		t.Parallel()
 
-		logger := log.New(os.Stderr, "custom logger", log.LstdFlags)
+		var buf bytes.Buffer
+
+		logger := log.New(&buf, "custom logger ", 0)
 
 		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 			GitHubToken:        "token",
@@ -200,7 +378,29 @@ func TestInit(t *testing.T) {
 		}, WithLogger(logger))
 
 		require.NoError(t, err)
-		assert.Equal(t, logger, adapter.Logger)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "custom logger INFO test")
+	})
+
+	t.Run("with slog logger", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
+			GitHubToken:        "token",
+			GitHubOrg:          "org",
+			TeamSlug:           "slug",
+			DiscoveryMechanism: "saml",
+		}, WithSlogLogger(logger))
+
+		require.NoError(t, err)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "msg=test")
 	})
 
 	t.Run("with client", func(t *testing.T) {
@@ -236,3 +436,16 @@ func TestInit(t *testing.T) {
 		assert.Equal(t, mockDiscovery, adapter.discovery)
 	})
 }
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("github/team")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("github/team")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
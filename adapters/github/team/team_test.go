@@ -3,6 +3,7 @@ package team_test
 import (
 	"context"
 	"log"
+	"log/slog"
 	"os"
 
 	"github.com/google/go-github/v47/github"
@@ -90,3 +91,21 @@ func ExampleWithLogger() {
 
 	gosync.New(adapter)
 }
+
+func ExampleWithSlogLogger() {
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	adapter, err := team.Init(ctx, map[gosync.ConfigKey]string{
+		team.GitHubToken:        "my-github-token",
+		team.GitHubOrg:          "my-org",
+		team.TeamSlug:           "my-team-slug",
+		team.DiscoveryMechanism: "saml",
+	}, team.WithSlogLogger(logger))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gosync.New(adapter)
+}
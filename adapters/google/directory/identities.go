@@ -0,0 +1,191 @@
+/*
+Package directory loads identities from the Google Workspace directory, so that Sync can match people by their primary
+email address or any of their aliases.
+
+# Requirements
+
+You'll need the Admin SDK enabled on your account, and credentials with the following scopes:
+  - [admin.AdminDirectoryUserReadonlyScope]
+
+# Examples
+
+See [New].
+*/
+package directory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/option"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// Customer is the ID of the Google Workspace account to load users from (optional, default `my_customer`).
+const Customer gosync.ConfigKey = "customer"
+
+// Domain limits users to a domain, rather than every user in the customer's account (optional).
+const Domain gosync.ConfigKey = "domain"
+
+// Ensure [directory.Identities] fully satisfies the [gosync.IdentitySource] interface.
+var _ gosync.IdentitySource = &Identities{}
+
+// Schema describes the configuration that [directory.New] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: Customer, Default: "my_customer", Description: "ID of the Google Workspace account to load users from."},
+	{Key: Domain, Description: "Only load users in this domain."},
+}
+
+// callListUsers allows us to mock the returned struct from the List Google API call for users.
+func callListUsers(
+	ctx context.Context,
+	call *admin.UsersListCall,
+	customer string,
+	domain string,
+	pageToken string,
+) (*admin.Users, error) {
+	if domain != "" {
+		call = call.Domain(domain)
+	} else {
+		call = call.Customer(customer)
+	}
+
+	// Only the users' email addresses are needed.
+	call = call.Fields("nextPageToken", "users(primaryEmail,aliases,nonEditableAliases)")
+
+	return call.Context(ctx).PageToken(pageToken).MaxResults(500).Do() //nolint:wrapcheck,gomnd,mnd
+}
+
+// iUsersService is a subset of the Google UsersService, and used to build mocks for easy testing.
+type iUsersService interface {
+	List() *admin.UsersListCall
+}
+
+// Identities loads the primary email address and aliases of each user in Google Workspace.
+type Identities struct {
+	usersService iUsersService
+	customer     string
+	domain       string
+	Logger       *slog.Logger
+
+	callListUsers func(
+		ctx context.Context,
+		call *admin.UsersListCall,
+		customer string,
+		domain string,
+		pageToken string,
+	) (*admin.Users, error)
+}
+
+// Identities returns each user's primary email address, followed by their aliases.
+func (i *Identities) Identities(ctx context.Context) ([][]string, error) {
+	i.Logger.Info("Fetching identities from Google Workspace")
+
+	identities := make([][]string, 0)
+	pageToken := ""
+
+	for {
+		response, err := i.callListUsers(ctx, i.usersService.List(), i.customer, i.domain, pageToken)
+		if err != nil {
+			return nil, fmt.Errorf("google.directory.identities.list -> %w", err)
+		}
+
+		for _, user := range response.Users {
+			aliases := make([]string, 0, 1+len(user.Aliases)+len(user.NonEditableAliases))
+			aliases = append(aliases, user.PrimaryEmail)
+			aliases = append(aliases, user.Aliases...)
+			aliases = append(aliases, user.NonEditableAliases...)
+
+			identities = append(identities, aliases)
+		}
+
+		if response.NextPageToken == "" {
+			break
+		}
+
+		pageToken = response.NextPageToken
+	}
+
+	i.Logger.Info("Fetched identities successfully", slog.Int(gosync.LogKeyCount, len(identities)))
+
+	return identities, nil
+}
+
+// WithAdminService passes a custom Google Admin Service to the identities.
+func WithAdminService(adminService *admin.Service) gosync.ConfigFn[*Identities] {
+	return func(i *Identities) {
+		i.usersService = adminService.Users
+	}
+}
+
+// WithLogger passes a custom logger to the identities, converted with [gosync.NewLogLogger].
+func WithLogger(logger *log.Logger) gosync.ConfigFn[*Identities] {
+	return func(i *Identities) {
+		i.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the identities.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*Identities] {
+	return func(i *Identities) {
+		i.Logger = logger
+	}
+}
+
+/*
+New creates a [gosync.IdentitySource] for users in Google Workspace. By default, an Admin SDK client is created using
+the default credentials in the environment.
+
+Optional config:
+  - [directory.Customer]
+  - [directory.Domain]
+
+Example:
+
+	identities, err := directory.New(ctx, map[gosync.ConfigKey]string{
+		directory.Domain: "example.com",
+	})
+
+	syncService := gosync.New(source, gosync.WithIdentities(identities))
+*/
+func New(
+	ctx context.Context,
+	config map[gosync.ConfigKey]string,
+	configFns ...gosync.ConfigFn[*Identities],
+) (*Identities, error) {
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("google.directory.new -> %w", err)
+	}
+
+	identities := &Identities{
+		customer:      parsed.String(Customer),
+		domain:        parsed.String(Domain),
+		callListUsers: callListUsers,
+	}
+
+	for _, configFn := range configFns {
+		configFn(identities)
+	}
+
+	if identities.usersService == nil {
+		client, err := admin.NewService(ctx, option.WithScopes(admin.AdminDirectoryUserReadonlyScope))
+		if err != nil {
+			return nil, fmt.Errorf("google.directory.new -> %w", err)
+		}
+
+		WithAdminService(client)(identities)
+	}
+
+	if identities.Logger == nil {
+		WithSlogLogger(slog.Default())(identities)
+	}
+
+	parsed.LogUnknown(identities.Logger)
+
+	return identities, nil
+}
@@ -0,0 +1,108 @@
+package directory
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/option"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+type mockCalls struct {
+	mock.Mock
+}
+
+func (m *mockCalls) callListUsers(
+	ctx context.Context,
+	call *admin.UsersListCall,
+	customer string,
+	domain string,
+	pageToken string,
+) (*admin.Users, error) {
+	args := m.Called(ctx, call, customer, domain, pageToken)
+
+	return args.Get(0).(*admin.Users), args.Error(1)
+}
+
+func TestIdentities_Identities(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	newIdentities := func(t *testing.T, config map[gosync.ConfigKey]string) (*Identities, *mockCalls) {
+		t.Helper()
+
+		client, err := admin.NewService(ctx, option.WithAPIKey("_testing_"))
+		require.NoError(t, err)
+
+		identities, err := New(ctx, config, WithAdminService(client),
+			WithSlogLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+		require.NoError(t, err)
+
+		mockCall := new(mockCalls)
+		identities.callListUsers = mockCall.callListUsers
+
+		return identities, mockCall
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		identities, mockCall := newIdentities(t, map[gosync.ConfigKey]string{})
+
+		mockCall.On("callListUsers", ctx, mock.Anything, "my_customer", "", "").Return(&admin.Users{
+			NextPageToken: "page-2",
+			Users: []*admin.User{{
+				PrimaryEmail:       "jane@example.com",
+				Aliases:            []string{"jane.doe@example.com"},
+				NonEditableAliases: []string{"jane@example.test-google-a.com"},
+			}},
+		}, nil)
+		mockCall.On("callListUsers", ctx, mock.Anything, "my_customer", "", "page-2").Return(&admin.Users{
+			Users: []*admin.User{{PrimaryEmail: "bob@example.com"}},
+		}, nil)
+
+		out, err := identities.Identities(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"jane@example.com", "jane.doe@example.com", "jane@example.test-google-a.com"},
+			{"bob@example.com"},
+		}, out)
+	})
+
+	t.Run("Domain", func(t *testing.T) {
+		t.Parallel()
+
+		identities, mockCall := newIdentities(t, map[gosync.ConfigKey]string{Domain: "example.com", Customer: "foo"})
+
+		mockCall.On("callListUsers", ctx, mock.Anything, "foo", "example.com", "").Return(&admin.Users{}, nil)
+
+		out, err := identities.Identities(ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, out)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		identities, mockCall := newIdentities(t, map[gosync.ConfigKey]string{})
+
+		mockCall.On("callListUsers", ctx, mock.Anything, "my_customer", "", "").Return((*admin.Users)(nil), testErr)
+
+		_, err := identities.Identities(ctx)
+
+		require.ErrorIs(t, err, testErr)
+	})
+}
@@ -0,0 +1,31 @@
+package directory_test
+
+import (
+	"context"
+	"log"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/google/directory"
+	"github.com/ovotech/go-sync/adapters/google/group"
+)
+
+func ExampleNew() {
+	ctx := context.Background()
+
+	identities, err := directory.New(ctx, map[gosync.ConfigKey]string{
+		directory.Domain: "example.com",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source, err := group.Init(ctx, map[gosync.ConfigKey]string{
+		group.Name: "engineering@example.com",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// People are matched by any of their addresses, so aliases in the destination aren't removed and added again.
+	gosync.New(source, gosync.WithIdentities(identities))
+}
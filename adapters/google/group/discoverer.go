@@ -0,0 +1,169 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/option"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// Customer is the ID of the Google Workspace account to discover groups in (optional, default `my_customer`).
+const Customer gosync.ConfigKey = "customer"
+
+// Domain limits discovered groups to a domain, rather than every group in the customer's account (optional).
+const Domain gosync.ConfigKey = "domain"
+
+// Ensure [group.Discoverer] fully satisfies the [gosync.Discoverer] interface.
+var _ gosync.Discoverer = &Discoverer{}
+
+// callListGroups allows us to mock the returned struct from the List Google API call for groups.
+func callListGroups(
+	ctx context.Context,
+	call *admin.GroupsListCall,
+	customer string,
+	domain string,
+	pageToken string,
+) (*admin.Groups, error) {
+	if domain != "" {
+		call = call.Domain(domain)
+	} else {
+		call = call.Customer(customer)
+	}
+
+	return call.Context(ctx).PageToken(pageToken).MaxResults(200).Do() //nolint:wrapcheck,gomnd,mnd
+}
+
+// iGroupsService is a subset of the Google GroupsService, and used to build mocks for easy testing.
+type iGroupsService interface {
+	List() *admin.GroupsListCall
+}
+
+// Discoverer finds Google Groups whose emails match a selector, and initialises an adapter for each.
+type Discoverer struct {
+	groupsService iGroupsService
+	customer      string
+	domain        string
+	config        map[gosync.ConfigKey]string
+	configFns     []gosync.ConfigFn[*Group]
+
+	callListGroups func(
+		ctx context.Context,
+		call *admin.GroupsListCall,
+		customer string,
+		domain string,
+		pageToken string,
+	) (*admin.Groups, error)
+}
+
+/*
+NewDiscoverer creates a [gosync.Discoverer] for Google Groups. It accepts the same config as [Init], except
+[group.Name], which is set to the email of each discovered group, and the optional [group.Customer] and [group.Domain].
+
+Listing groups requires credentials with the [admin.AdminDirectoryGroupReadonlyScope] scope, as well as
+[admin.AdminDirectoryGroupMemberScope]. Discovered groups share a single Admin SDK client.
+
+	discoverer, err := group.NewDiscoverer(ctx, map[gosync.ConfigKey]string{
+		group.Domain: "example.com",
+	})
+
+	err = gosync.FanOut(ctx, gosync.Discovery{Discoverer: discoverer, Selector: "platform-*@example.com", Source: source})
+*/
+func NewDiscoverer(
+	ctx context.Context,
+	config map[gosync.ConfigKey]string,
+	configFns ...gosync.ConfigFn[*Group],
+) (*Discoverer, error) {
+	// Create a client that can list groups, unless one has been passed with WithAdminService.
+	probe := &Group{}
+	for _, configFn := range configFns {
+		configFn(probe)
+	}
+
+	if probe.groupsService == nil {
+		client, err := admin.NewService(ctx, option.WithScopes(
+			admin.AdminDirectoryGroupMemberScope,
+			admin.AdminDirectoryGroupReadonlyScope,
+		))
+		if err != nil {
+			return nil, fmt.Errorf("google.group.newdiscoverer -> %w", err)
+		}
+
+		configFns = append([]gosync.ConfigFn[*Group]{WithAdminService(client)}, configFns...)
+	}
+
+	// Initialise an adapter without a group, to check the config.
+	adapter, err := Init(ctx, withName(config, ""), configFns...)
+	if err != nil {
+		return nil, fmt.Errorf("google.group.newdiscoverer -> %w", err)
+	}
+
+	discoverer := &Discoverer{
+		groupsService:  adapter.groupsService,
+		customer:       "my_customer",
+		domain:         config[Domain],
+		config:         config,
+		configFns:      configFns,
+		callListGroups: callListGroups,
+	}
+
+	if val, ok := config[Customer]; ok {
+		discoverer.customer = val
+	}
+
+	return discoverer, nil
+}
+
+// Discover Google Groups whose emails match the selector, keyed by email. See [gosync.MatchSelector].
+func (d *Discoverer) Discover(ctx context.Context, selector string) (map[string]gosync.Adapter, error) {
+	out := make(map[string]gosync.Adapter)
+	pageToken := ""
+
+	for {
+		groups, err := d.callListGroups(ctx, d.groupsService.List(), d.customer, d.domain, pageToken)
+		if err != nil {
+			return nil, fmt.Errorf("google.group.discover.list -> %w", err)
+		}
+
+		for _, group := range groups.Groups {
+			matched, err := gosync.MatchSelector(selector, group.Email)
+			if err != nil {
+				return nil, fmt.Errorf("google.group.discover -> %w", err)
+			}
+
+			if !matched {
+				continue
+			}
+
+			adapter, err := Init(ctx, withName(d.config, group.Email), d.configFns...)
+			if err != nil {
+				return nil, fmt.Errorf("google.group.discover(%s) -> %w", group.Email, err)
+			}
+
+			out[group.Email] = adapter
+		}
+
+		if groups.NextPageToken == "" {
+			break
+		}
+
+		pageToken = groups.NextPageToken
+	}
+
+	return out, nil
+}
+
+// withName returns a copy of config with the group's name set.
+func withName(config map[gosync.ConfigKey]string, name string) map[gosync.ConfigKey]string {
+	out := maps.Clone(config)
+	if out == nil {
+		out = make(map[gosync.ConfigKey]string)
+	}
+
+	out[Name] = name
+
+	return out
+}
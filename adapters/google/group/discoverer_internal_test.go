@@ -0,0 +1,102 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	admin "google.golang.org/api/admin/directory/v1"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+func (m *mockCalls) callListGroups(
+	ctx context.Context,
+	call *admin.GroupsListCall,
+	customer string,
+	domain string,
+	pageToken string,
+) (*admin.Groups, error) {
+	args := m.Called(ctx, call, customer, domain, pageToken)
+
+	return args.Get(0).(*admin.Groups), args.Error(1)
+}
+
+func TestDiscoverer_Discover(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	newDiscoverer := func(t *testing.T, config map[gosync.ConfigKey]string) (*Discoverer, *mockCalls) {
+		t.Helper()
+
+		mockGroupsService := newMockIGroupsService(t)
+		mockGroupsService.EXPECT().List().Maybe().Return(nil)
+
+		discoverer, err := NewDiscoverer(ctx, config, withMockAdminService(ctx, t), func(g *Group) {
+			g.groupsService = mockGroupsService
+		})
+		require.NoError(t, err)
+
+		mockCall := new(mockCalls)
+		discoverer.callListGroups = mockCall.callListGroups
+
+		return discoverer, mockCall
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		discoverer, mockCall := newDiscoverer(t, map[gosync.ConfigKey]string{Role: "MANAGER"})
+
+		mockCall.On("callListGroups", ctx, mock.Anything, "my_customer", "", "").Return(&admin.Groups{
+			NextPageToken: "page-2",
+			Groups:        []*admin.Group{{Email: "platform-foo@example.com"}, {Email: "data@example.com"}},
+		}, nil)
+		mockCall.On("callListGroups", ctx, mock.Anything, "my_customer", "", "page-2").Return(&admin.Groups{
+			Groups: []*admin.Group{{Email: "platform-bar@example.com"}},
+		}, nil)
+
+		adapters, err := discoverer.Discover(ctx, "platform-*@example.com")
+
+		require.NoError(t, err)
+		assert.Len(t, adapters, 2)
+
+		foo, ok := adapters["platform-foo@example.com"].(*Group)
+		require.True(t, ok)
+		assert.Equal(t, "platform-foo@example.com", foo.name)
+		assert.Equal(t, "MANAGER", foo.Role)
+	})
+
+	t.Run("Domain", func(t *testing.T) {
+		t.Parallel()
+
+		discoverer, mockCall := newDiscoverer(t, map[gosync.ConfigKey]string{Domain: "example.com"})
+
+		mockCall.On("callListGroups", ctx, mock.Anything, "my_customer", "example.com", "").
+			Return(&admin.Groups{}, nil)
+
+		adapters, err := discoverer.Discover(ctx, "*")
+
+		require.NoError(t, err)
+		assert.Empty(t, adapters)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		discoverer, mockCall := newDiscoverer(t, nil)
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		mockCall.On("callListGroups", ctx, mock.Anything, "my_customer", "", "").
+			Return((*admin.Groups)(nil), testErr)
+
+		_, err := discoverer.Discover(ctx, "*")
+
+		require.ErrorIs(t, err, testErr)
+	})
+}
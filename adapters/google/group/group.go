@@ -15,11 +15,14 @@ package group
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"net/http"
 
 	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 
 	gosync "github.com/ovotech/go-sync"
@@ -60,9 +63,36 @@ const DeliverySettings gosync.ConfigKey = "delivery_settings"
 
 var (
 	_ gosync.Adapter        = &Group{} // Ensure [group.Group] fully satisfies the [gosync.Adapter] interface.
+	_ gosync.Describer      = &Group{} // Ensure [group.Group] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Capable        = &Group{} // Ensure [group.Group] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Validator      = &Group{} // Ensure [group.Group] fully satisfies the [gosync.Validator] interface.
+	_ gosync.Streamer       = &Group{} // Ensure [group.Group] fully satisfies the [gosync.Streamer] interface.
 	_ gosync.InitFn[*Group] = Init     // Ensure [group.Init] fully satisfies the [gosync.InitFn] type.
 )
 
+// Schema describes the configuration that [group.Init] and [group.NewDiscoverer] accept.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: Name, Required: true, Description: "Email address of the Google Group."},
+	{
+		Key:         Role,
+		Allowed:     []string{"MANAGER", "MEMBER", "OWNER"},
+		Description: "Role of new members of the group.",
+	},
+	{
+		Key:         DeliverySettings,
+		Allowed:     []string{"ALL_MAIL", "DAILY", "DIGEST", "DISABLED", "NONE"},
+		Description: "How new members of the group receive its emails.",
+	},
+	{Key: Customer, Default: "my_customer", Description: "ID of the Google Workspace account to discover groups in."},
+	{Key: Domain, Description: "Only discover groups in this domain."},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("google/group", Init)
+	gosync.RegisterSchema("google/group", Schema)
+}
+
 // callList allows us to mock the returned struct from the List Google API call.
 func callList(ctx context.Context, call *admin.MembersListCall, pageToken string) (*admin.Members, error) {
 	return call.Context(ctx).PageToken(pageToken).MaxResults(200).Do() //nolint:wrapcheck,gomnd,mnd
@@ -78,17 +108,31 @@ func callDelete(ctx context.Context, call *admin.MembersDeleteCall) error {
 	return call.Context(ctx).Do() //nolint:wrapcheck
 }
 
+// callPatch allows us to mock the returned struct from the Patch Google API call.
+func callPatch(ctx context.Context, call *admin.MembersPatchCall) (*admin.Member, error) {
+	return call.Context(ctx).Do() //nolint:wrapcheck
+}
+
+// hasStatusCode returns true if the Google API responded to a request with an HTTP status code.
+func hasStatusCode(err error, code int) bool {
+	var apiErr *googleapi.Error
+
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
 // iMembersService is a subset of the Google MembersService, and used to build mocks for easy testing.
 type iMembersService interface {
 	List(groupKey string) *admin.MembersListCall
 	Insert(groupKey string, member *admin.Member) *admin.MembersInsertCall
 	Delete(groupKey string, memberKey string) *admin.MembersDeleteCall
+	Patch(groupKey string, memberKey string, member *admin.Member) *admin.MembersPatchCall
 }
 
 type Group struct {
 	membersService iMembersService
+	groupsService  iGroupsService // groupsService lists groups for a Discoverer, if set by WithAdminService.
 	name           string
-	Logger         *log.Logger
+	Logger         *slog.Logger
 
 	DeliverySettings string // See [group.DeliverySettings].
 	Role             string // See [group.Role].
@@ -96,27 +140,49 @@ type Group struct {
 	callList   func(ctx context.Context, call *admin.MembersListCall, pageToken string) (*admin.Members, error)
 	callInsert func(ctx context.Context, call *admin.MembersInsertCall) (*admin.Member, error)
 	callDelete func(ctx context.Context, call *admin.MembersDeleteCall) error
+	callPatch  func(ctx context.Context, call *admin.MembersPatchCall) (*admin.Member, error)
 }
 
 // Get email addresses in a Google Group.
 func (g *Group) Get(ctx context.Context) ([]string, error) {
+	return gosync.Collect(ctx, g) //nolint:wrapcheck
+}
+
+// Stream email addresses in a Google Group, a page at a time.
+func (g *Group) Stream(ctx context.Context, fn func(emails []string) error) error {
+	return g.list(ctx, func(members []*admin.Member) error {
+		emails := make([]string, 0, len(members))
+
+		for _, member := range members {
+			emails = append(emails, member.Email)
+		}
+
+		return fn(emails)
+	})
+}
+
+// list members of a Google Group, a page at a time.
+func (g *Group) list(ctx context.Context, fn func(members []*admin.Member) error) error {
 	var (
 		pageToken = ""
-		emails    = make([]string, 0)
+		count     = 0
 	)
 
+	g.Logger.Info("Fetching accounts from Google Group")
+
 	for {
-		g.Logger.Printf("Fetching accounts from Google Group %s", g.name)
+		g.Logger.Debug("Fetching page of accounts from Google Group", slog.String("pageToken", pageToken))
 
 		response, err := g.callList(ctx, g.membersService.List(g.name), pageToken)
 		if err != nil {
-			return nil, fmt.Errorf("google.group.get(%s).list -> %w", g.name, err)
+			return gosync.NewError(g, gosync.PhaseGet, nil, fmt.Errorf("list -> %w", err))
 		}
 
-		for _, member := range response.Members {
-			emails = append(emails, member.Email)
+		if err = fn(response.Members); err != nil {
+			return gosync.NewError(g, gosync.PhaseGet, nil, fmt.Errorf("stream -> %w", err))
 		}
 
+		count += len(response.Members)
 		pageToken = response.NextPageToken
 
 		if pageToken == "" {
@@ -124,43 +190,81 @@ func (g *Group) Get(ctx context.Context) ([]string, error) {
 		}
 	}
 
-	g.Logger.Println("Fetched accounts successfully")
+	g.Logger.Info("Fetched accounts successfully", slog.Int(gosync.LogKeyCount, count))
 
-	return emails, nil
+	return nil
 }
 
 // Add email addresses to a Google Group.
 func (g *Group) Add(ctx context.Context, emails []string) error {
-	g.Logger.Printf("Adding %s to Google Group %s", emails, g.name)
+	g.Logger.Info("Adding accounts to Google Group", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	for _, email := range emails {
-		_, err := g.callInsert(ctx, g.membersService.Insert(g.name, &admin.Member{
-			Email:            email,
-			DeliverySettings: g.DeliverySettings,
-			Role:             g.Role,
-		}))
-		if err != nil {
-			return fmt.Errorf("google.group.add(%s, %s) -> %w", g.name, email, err)
+		if err := g.insert(ctx, email, g.Role); err != nil {
+			return err
 		}
 	}
 
-	g.Logger.Println("Finished adding accounts successfully")
+	g.Logger.Info("Finished adding accounts successfully")
+
+	return nil
+}
+
+// insert a member into the Google Group with a role, skipping them if they're already a member.
+func (g *Group) insert(ctx context.Context, email string, role string) error {
+	g.Logger.Debug("Adding account", slog.String(gosync.LogKeyThing, email))
+
+	_, err := g.callInsert(ctx, g.membersService.Insert(g.name, &admin.Member{
+		Email:            email,
+		DeliverySettings: g.DeliverySettings,
+		Role:             role,
+	}))
+	if hasStatusCode(err, http.StatusConflict) {
+		g.Logger.Debug("Account is already a member, skipping", slog.String(gosync.LogKeyThing, email))
+	} else if err != nil {
+		return gosync.NewError(g, gosync.PhaseAdd, []string{email}, fmt.Errorf("insert -> %w", err))
+	}
 
 	return nil
 }
 
 // Remove email addresses from a Google Group.
 func (g *Group) Remove(ctx context.Context, emails []string) error {
-	g.Logger.Printf("Removing %s from Google Group %s", emails, g.name)
+	g.Logger.Info("Removing accounts from Google Group", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	for _, email := range emails {
+		g.Logger.Debug("Removing account", slog.String(gosync.LogKeyThing, email))
+
 		err := g.callDelete(ctx, g.membersService.Delete(g.name, email))
-		if err != nil {
-			return fmt.Errorf("google.group.remove(%s, %s) -> %w", g.name, email, err)
+		if hasStatusCode(err, http.StatusNotFound) {
+			g.Logger.Debug("Account isn't a member, skipping", slog.String(gosync.LogKeyThing, email))
+
+			continue
+		} else if err != nil {
+			return gosync.NewError(g, gosync.PhaseRemove, []string{email}, fmt.Errorf("delete -> %w", err))
 		}
 	}
 
-	g.Logger.Println("Finished removing accounts successfully")
+	g.Logger.Info("Finished removing accounts successfully")
+
+	return nil
+}
+
+/*
+Validate checks that the Google Group exists, and that the credentials are allowed to list its members. Permission to
+insert and delete members is granted by the same scope, so it isn't checked separately.
+*/
+func (g *Group) Validate(ctx context.Context) error {
+	_, err := g.callList(ctx, g.membersService.List(g.name), "")
+
+	switch {
+	case hasStatusCode(err, http.StatusNotFound):
+		return gosync.NewError(g, gosync.PhaseValidate, nil, fmt.Errorf("list -> %w", gosync.ErrNotFound))
+	case hasStatusCode(err, http.StatusForbidden):
+		return gosync.NewError(g, gosync.PhaseValidate, nil, fmt.Errorf("list -> %w", gosync.ErrMissingPermission))
+	case err != nil:
+		return gosync.NewError(g, gosync.PhaseValidate, nil, fmt.Errorf("list -> %w", err))
+	}
 
 	return nil
 }
@@ -169,11 +273,37 @@ func (g *Group) Remove(ctx context.Context, emails []string) error {
 func WithAdminService(adminService *admin.Service) gosync.ConfigFn[*Group] {
 	return func(g *Group) {
 		g.membersService = adminService.Members
+		g.groupsService = adminService.Groups
 	}
 }
 
-// WithLogger passes a custom logger to the adapter.
+// Kind of adapter.
+func (g *Group) Kind() string {
+	return "google/group"
+}
+
+// Target returns the name of the Google Group.
+func (g *Group) Target() string {
+	return g.name
+}
+
+// Capabilities of the adapter.
+func (g *Group) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+	}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
 func WithLogger(logger *log.Logger) gosync.ConfigFn[*Group] {
+	return func(g *Group) {
+		g.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*Group] {
 	return func(g *Group) {
 		g.Logger = logger
 	}
@@ -190,18 +320,18 @@ func Init(
 	config map[gosync.ConfigKey]string,
 	configFns ...gosync.ConfigFn[*Group],
 ) (*Group, error) {
-	for _, key := range []gosync.ConfigKey{Name} {
-		if _, ok := config[key]; !ok {
-			return nil, fmt.Errorf("google.group.init -> %w(%s)", gosync.ErrMissingConfig, key)
-		}
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("google.group.init -> %w", err)
 	}
 
 	adapter := &Group{
-		name: config[Name],
+		name: parsed.String(Name),
 
 		callList:   callList,
 		callInsert: callInsert,
 		callDelete: callDelete,
+		callPatch:  callPatch,
 	}
 
 	for _, configFn := range configFns {
@@ -209,22 +339,21 @@ func Init(
 	}
 
 	if adapter.Logger == nil {
-		logger := log.New(
-			os.Stderr, "[go-sync/google/group] ", log.LstdFlags|log.Lshortfile|log.Lmsgprefix,
-		)
-
-		WithLogger(logger)(adapter)
+		WithSlogLogger(slog.Default())(adapter)
 	}
 
-	if val, ok := config[Role]; ok {
-		adapter.Role = val
+	parsed.LogUnknown(adapter.Logger)
+
+	if parsed.Has(Role) {
+		adapter.Role = parsed.String(Role)
 	}
 
-	if val, ok := config[DeliverySettings]; ok {
-		adapter.DeliverySettings = val
+	if parsed.Has(DeliverySettings) {
+		adapter.DeliverySettings = parsed.String(DeliverySettings)
 	}
 
 	if adapter.membersService == nil {
+		// The Admin SDK instruments its HTTP client with OpenTelemetry by default.
 		client, err := admin.NewService(ctx, option.WithScopes(admin.AdminDirectoryGroupMemberScope))
 		if err != nil {
 			return nil, fmt.Errorf("google.group.init -> %w", err)
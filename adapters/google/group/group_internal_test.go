@@ -1,8 +1,12 @@
 package group
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
 	"testing"
 
@@ -10,9 +14,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 
 	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adaptertest"
 )
 
 func withMockAdminService(ctx context.Context, t *testing.T) gosync.ConfigFn[*Group] {
@@ -56,6 +62,12 @@ func (m *mockCalls) callDelete(ctx context.Context, call *admin.MembersDeleteCal
 	return args.Error(0)
 }
 
+func (m *mockCalls) callPatch(ctx context.Context, call *admin.MembersPatchCall) (*admin.Member, error) {
+	args := m.Called(ctx, call)
+
+	return args.Get(0).(*admin.Member), args.Error(1)
+}
+
 func TestGroups_Get(t *testing.T) {
 	t.Parallel()
 
@@ -80,7 +92,7 @@ func TestGroups_Get(t *testing.T) {
 	group := &Group{
 		name:           "test",
 		membersService: mockMembersService,
-		Logger:         log.New(os.Stdout, "", log.LstdFlags),
+		Logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		callList:       mockCall.callList,
 		callInsert:     mockCall.callInsert,
 		callDelete:     mockCall.callDelete,
@@ -92,6 +104,43 @@ func TestGroups_Get(t *testing.T) {
 	assert.ElementsMatch(t, []string{"foo@email", "bar@email"}, emails)
 }
 
+func TestGroups_Stream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	mockMembersService := newMockIMembersService(t)
+	mockMembersService.EXPECT().List("test").Return(nil)
+
+	mockCall := new(mockCalls)
+	mockCall.On("callList", ctx, mock.Anything, "").Return(&admin.Members{
+		NextPageToken: "page-2",
+		Members:       []*admin.Member{{Email: "foo@email"}, {Email: "bar@email"}},
+	}, nil)
+
+	group := &Group{
+		name:           "test",
+		membersService: mockMembersService,
+		Logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		callList:       mockCall.callList,
+	}
+
+	testErr := errors.New("stop") //nolint:goerr113
+
+	var pages [][]string
+
+	// Returning an error from the callback stops the stream before the second page is fetched.
+	err := group.Stream(ctx, func(emails []string) error {
+		pages = append(pages, emails)
+
+		return testErr
+	})
+
+	require.ErrorIs(t, err, testErr)
+	assert.Equal(t, [][]string{{"foo@email", "bar@email"}}, pages)
+	mockCall.AssertNumberOfCalls(t, "callList", 1)
+}
+
 func TestGroups_Add(t *testing.T) {
 	t.Parallel()
 
@@ -107,7 +156,7 @@ func TestGroups_Add(t *testing.T) {
 	group := &Group{
 		name:           "test",
 		membersService: mockMembersService,
-		Logger:         log.New(os.Stdout, "", log.LstdFlags),
+		Logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		callList:       mockCall.callList,
 		callInsert:     mockCall.callInsert,
 		callDelete:     mockCall.callDelete,
@@ -133,7 +182,7 @@ func TestGroups_Remove(t *testing.T) {
 	group := &Group{
 		name:           "test",
 		membersService: mockMembersService,
-		Logger:         log.New(os.Stdout, "", log.LstdFlags),
+		Logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		callList:       mockCall.callList,
 		callInsert:     mockCall.callInsert,
 		callDelete:     mockCall.callDelete,
@@ -144,6 +193,113 @@ func TestGroups_Remove(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGroups_Remove_Error(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	testErr := errors.New("foo") //nolint:goerr113
+
+	mockMembersService := newMockIMembersService(t)
+	mockMembersService.EXPECT().Delete("test", "foo@email").Return(nil)
+
+	mockCall := new(mockCalls)
+	mockCall.On("callDelete", ctx, mock.Anything).Once().Return(testErr)
+
+	group := &Group{
+		name:           "test",
+		membersService: mockMembersService,
+		Logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		callList:       mockCall.callList,
+		callInsert:     mockCall.callInsert,
+		callDelete:     mockCall.callDelete,
+	}
+
+	err := group.Remove(ctx, []string{"foo@email", "bar@email"})
+
+	var syncErr *gosync.Error
+
+	require.ErrorIs(t, err, testErr)
+	require.ErrorAs(t, err, &syncErr)
+	assert.Equal(t, gosync.PhaseRemove, syncErr.Phase)
+	assert.Equal(t, "google/group", syncErr.Kind)
+	assert.Equal(t, "test", syncErr.Target)
+	assert.Equal(t, []string{"foo@email"}, syncErr.Things)
+}
+
+func TestGroups_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	mockMembersService := newMockIMembersService(t)
+	mockMembersService.EXPECT().Insert("test", &admin.Member{Email: "foo@email"}).Return(nil)
+	mockMembersService.EXPECT().Delete("test", "bar@email").Return(nil)
+
+	mockCall := new(mockCalls)
+	mockCall.On("callInsert", ctx, mock.Anything).Return(&admin.Member{}, &googleapi.Error{
+		Code:    http.StatusConflict,
+		Message: "Member already exists.",
+	})
+	mockCall.On("callDelete", ctx, mock.Anything).Return(&googleapi.Error{
+		Code:    http.StatusNotFound,
+		Message: "Resource Not Found: memberKey",
+	})
+
+	group := &Group{
+		name:           "test",
+		membersService: mockMembersService,
+		Logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		callList:       mockCall.callList,
+		callInsert:     mockCall.callInsert,
+		callDelete:     mockCall.callDelete,
+	}
+
+	adaptertest.Idempotent(ctx, t, group, []string{"foo@email"}, []string{"bar@email"})
+}
+
+func TestGroups_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	tests := map[string]struct {
+		err  error
+		want error
+	}{
+		"Success":   {},
+		"Forbidden": {err: &googleapi.Error{Code: http.StatusForbidden}, want: gosync.ErrMissingPermission},
+		"Not found": {err: &googleapi.Error{Code: http.StatusNotFound}, want: gosync.ErrNotFound},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mockMembersService := newMockIMembersService(t)
+			mockMembersService.EXPECT().List("test").Return(nil)
+
+			mockCall := new(mockCalls)
+			mockCall.On("callList", ctx, mock.Anything, "").Return(&admin.Members{}, test.err)
+
+			group := &Group{name: "test", membersService: mockMembersService, callList: mockCall.callList}
+
+			err := group.Validate(ctx)
+
+			if test.want == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var syncErr *gosync.Error
+
+			require.ErrorIs(t, err, test.want)
+			require.ErrorAs(t, err, &syncErr)
+			assert.Equal(t, gosync.PhaseValidate, syncErr.Phase)
+		})
+	}
+}
+
 func TestRole(t *testing.T) {
 	t.Parallel()
 
@@ -162,7 +318,7 @@ func TestRole(t *testing.T) {
 		name:           "test",
 		Role:           "test-role",
 		membersService: mockMembersService,
-		Logger:         log.New(os.Stdout, "", log.LstdFlags),
+		Logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		callList:       mockCall.callList,
 		callInsert:     mockCall.callInsert,
 		callDelete:     mockCall.callDelete,
@@ -191,7 +347,7 @@ func TestDeliverySettings(t *testing.T) {
 		name:             "test",
 		membersService:   mockMembersService,
 		DeliverySettings: "test-delivery-settings",
-		Logger:           log.New(os.Stdout, "", log.LstdFlags),
+		Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		callList:         mockCall.callList,
 		callInsert:       mockCall.callInsert,
 		callDelete:       mockCall.callDelete,
@@ -239,11 +395,23 @@ func TestInit(t *testing.T) {
 
 		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 			Name: "name",
-			Role: "role",
+			Role: "manager",
 		}, withMockAdminService(ctx, t))
 
 		require.NoError(t, err)
-		assert.Equal(t, "role", adapter.Role)
+		assert.Equal(t, "MANAGER", adapter.Role)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Init(ctx, map[gosync.ConfigKey]string{
+			Name: "name",
+			Role: "role",
+		}, withMockAdminService(ctx, t))
+
+		require.ErrorIs(t, err, gosync.ErrInvalidConfig)
+		require.ErrorContains(t, err, Role)
 	})
 
 	t.Run("delivery settings", func(t *testing.T) {
@@ -251,24 +419,57 @@ func TestInit(t *testing.T) {
 
 		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 			Name:             "name",
-			DeliverySettings: "delivery",
+			DeliverySettings: "DIGEST",
 		}, withMockAdminService(ctx, t))
 
 		require.NoError(t, err)
-		assert.Equal(t, "delivery", adapter.DeliverySettings)
+		assert.Equal(t, "DIGEST", adapter.DeliverySettings)
+	})
+
+	t.Run("invalid delivery settings", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Init(ctx, map[gosync.ConfigKey]string{
+			Name:             "name",
+			DeliverySettings: "delivery",
+		}, withMockAdminService(ctx, t))
+
+		require.ErrorIs(t, err, gosync.ErrInvalidConfig)
+		require.ErrorContains(t, err, DeliverySettings)
 	})
 
 	t.Run("with logger", func(t *testing.T) {
 		t.Parallel()
 
-		logger := log.New(os.Stderr, "custom logger", log.LstdFlags)
+		var buf bytes.Buffer
+
+		logger := log.New(&buf, "custom logger ", 0)
 
 		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 			Name: "name",
 		}, withMockAdminService(ctx, t), WithLogger(logger))
 
 		require.NoError(t, err)
-		assert.Equal(t, logger, adapter.Logger)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "custom logger INFO test")
+	})
+
+	t.Run("with slog logger", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
+			Name: "name",
+		}, withMockAdminService(ctx, t), WithSlogLogger(logger))
+
+		require.NoError(t, err)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "msg=test")
 	})
 
 	t.Run("default", func(t *testing.T) {
@@ -282,3 +483,16 @@ func TestInit(t *testing.T) {
 		assert.NotNil(t, adapter.membersService)
 	})
 }
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("google/group")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("google/group")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
@@ -3,6 +3,7 @@ package group_test
 import (
 	"context"
 	"log"
+	"log/slog"
 	"os"
 
 	admin "google.golang.org/api/admin/directory/v1"
@@ -61,3 +62,18 @@ func ExampleWithLogger() {
 
 	gosync.New(adapter)
 }
+
+func ExampleWithSlogLogger() {
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	adapter, err := group.Init(ctx, map[gosync.ConfigKey]string{
+		group.Name: "my-group",
+	}, group.WithSlogLogger(logger))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gosync.New(adapter)
+}
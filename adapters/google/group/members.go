@@ -0,0 +1,131 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	admin "google.golang.org/api/admin/directory/v1"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// RoleAttribute is the attribute of a [gosync.Item] that holds a member's role, e.g. OWNER.
+const RoleAttribute = "role"
+
+var (
+	// Ensure [group.Members] fully satisfies the [gosync.TypedAdapter] interface.
+	_ gosync.TypedAdapter[gosync.Item] = &Members{}
+	// Ensure [group.Members] fully satisfies the [gosync.Updater] interface.
+	_ gosync.Updater[gosync.Item] = &Members{}
+	// Ensure [group.Members] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Describer = &Members{}
+	// Ensure [group.Members] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Capable = &Members{}
+)
+
+/*
+Members is a [gosync.TypedAdapter] for the members of a Google Group and their roles, so that [gosync.TypedSync] can
+change the role of an existing member rather than removing and adding them again. Each member is a [gosync.Item] keyed
+by their email, with their role in the [group.RoleAttribute] attribute.
+
+Members that are added without a role are given the Group's Role.
+
+	err := gosync.NewTyped[gosync.Item](source).SyncWith(ctx, group.NewMembers(adapter))
+*/
+type Members struct {
+	group *Group
+}
+
+// NewMembers returns a typed adapter for the members of a Google Group and their roles.
+func NewMembers(group *Group) *Members {
+	return &Members{group: group}
+}
+
+// Get members of the Google Group, with their roles.
+func (m *Members) Get(ctx context.Context) ([]gosync.Item, error) {
+	items := make([]gosync.Item, 0)
+
+	err := m.group.list(ctx, func(members []*admin.Member) error {
+		for _, member := range members {
+			items = append(items, gosync.Item{
+				ID:         member.Email,
+				Attributes: map[string]string{RoleAttribute: member.Role},
+			})
+		}
+
+		return nil
+	})
+
+	return items, err
+}
+
+// Add members to the Google Group, with their roles.
+func (m *Members) Add(ctx context.Context, items []gosync.Item) error {
+	m.group.Logger.Info("Adding accounts to Google Group", slog.Int(gosync.LogKeyCount, len(items)))
+
+	for _, item := range items {
+		if err := m.group.insert(ctx, item.ID, m.role(item)); err != nil {
+			return err
+		}
+	}
+
+	m.group.Logger.Info("Finished adding accounts successfully")
+
+	return nil
+}
+
+// Remove members from the Google Group.
+func (m *Members) Remove(ctx context.Context, items []gosync.Item) error {
+	emails := make([]string, 0, len(items))
+
+	for _, item := range items {
+		emails = append(emails, item.ID)
+	}
+
+	return m.group.Remove(ctx, emails)
+}
+
+// Update the roles of existing members of the Google Group.
+func (m *Members) Update(ctx context.Context, items []gosync.Item) error {
+	m.group.Logger.Info("Updating roles in Google Group", slog.Int(gosync.LogKeyCount, len(items)))
+
+	for _, item := range items {
+		role := m.role(item)
+
+		m.group.Logger.Debug("Updating role", slog.String(gosync.LogKeyThing, item.ID), slog.String("role", role))
+
+		_, err := m.group.callPatch(ctx, m.group.membersService.Patch(m.group.name, item.ID, &admin.Member{Role: role}))
+		if err != nil {
+			return gosync.NewError(m.group, gosync.PhaseUpdate, []string{item.ID}, fmt.Errorf("patch -> %w", err))
+		}
+	}
+
+	m.group.Logger.Info("Finished updating roles successfully")
+
+	return nil
+}
+
+// role of a member, or the Group's Role if the item doesn't have one.
+func (m *Members) role(item gosync.Item) string {
+	if role := item.Attributes[RoleAttribute]; role != "" {
+		return role
+	}
+
+	return m.group.Role
+}
+
+// Kind of adapter.
+func (m *Members) Kind() string {
+	return m.group.Kind()
+}
+
+// Target returns the name of the Google Group.
+func (m *Members) Target() string {
+	return m.group.Target()
+}
+
+// Capabilities of the adapter.
+func (m *Members) Capabilities() gosync.Capabilities {
+	return m.group.Capabilities()
+}
@@ -0,0 +1,153 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	admin "google.golang.org/api/admin/directory/v1"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// newMockMembers returns a typed Members adapter for a Google Group with mocked API calls.
+func newMockMembers(t *testing.T) (*Members, *mockIMembersService, *mockCalls) {
+	t.Helper()
+
+	mockMembersService := newMockIMembersService(t)
+	mockCall := new(mockCalls)
+
+	group := &Group{
+		name:           "test",
+		membersService: mockMembersService,
+		Logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		Role:           "MEMBER",
+		callList:       mockCall.callList,
+		callInsert:     mockCall.callInsert,
+		callDelete:     mockCall.callDelete,
+		callPatch:      mockCall.callPatch,
+	}
+
+	return NewMembers(group), mockMembersService, mockCall
+}
+
+func TestMembers_Get(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	members, mockMembersService, mockCall := newMockMembers(t)
+	mockMembersService.EXPECT().List("test").Return(nil)
+	mockCall.On("callList", ctx, mock.Anything, "").Return(&admin.Members{
+		Members: []*admin.Member{{Email: "foo@email", Role: "OWNER"}, {Email: "bar@email", Role: "MEMBER"}},
+	}, nil)
+
+	items, err := members.Get(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []gosync.Item{
+		{ID: "foo@email", Attributes: map[string]string{RoleAttribute: "OWNER"}},
+		{ID: "bar@email", Attributes: map[string]string{RoleAttribute: "MEMBER"}},
+	}, items)
+}
+
+func TestMembers_Add(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	members, mockMembersService, mockCall := newMockMembers(t)
+	mockMembersService.EXPECT().Insert("test", &admin.Member{Email: "foo@email", Role: "OWNER"}).Return(nil)
+	mockMembersService.EXPECT().Insert("test", &admin.Member{Email: "bar@email", Role: "MEMBER"}).Return(nil)
+	mockCall.On("callInsert", ctx, mock.Anything).Return(&admin.Member{}, nil)
+
+	// Members without a role are given the Group's Role.
+	err := members.Add(ctx, []gosync.Item{
+		{ID: "foo@email", Attributes: map[string]string{RoleAttribute: "OWNER"}},
+		{ID: "bar@email"},
+	})
+
+	require.NoError(t, err)
+}
+
+func TestMembers_Update(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		members, mockMembersService, mockCall := newMockMembers(t)
+		mockMembersService.EXPECT().Patch("test", "foo@email", &admin.Member{Role: "MANAGER"}).Return(nil)
+		mockCall.On("callPatch", ctx, mock.Anything).Return(&admin.Member{}, nil)
+
+		err := members.Update(ctx, []gosync.Item{{ID: "foo@email", Attributes: map[string]string{RoleAttribute: "MANAGER"}}})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		members, mockMembersService, mockCall := newMockMembers(t)
+		mockMembersService.EXPECT().Patch("test", "foo@email", &admin.Member{Role: "MANAGER"}).Return(nil)
+		mockCall.On("callPatch", ctx, mock.Anything).Return(&admin.Member{}, testErr)
+
+		err := members.Update(ctx, []gosync.Item{{ID: "foo@email", Attributes: map[string]string{RoleAttribute: "MANAGER"}}})
+
+		var syncErr *gosync.Error
+
+		require.ErrorIs(t, err, testErr)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, gosync.PhaseUpdate, syncErr.Phase)
+		assert.Equal(t, []string{"foo@email"}, syncErr.Things)
+	})
+}
+
+func TestMembers_TypedSync(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	members, mockMembersService, mockCall := newMockMembers(t)
+	mockMembersService.EXPECT().List("test").Return(nil)
+	mockCall.On("callList", ctx, mock.Anything, "").Return(&admin.Members{
+		Members: []*admin.Member{{Email: "foo@email", Role: "MEMBER"}, {Email: "bar@email", Role: "MEMBER"}},
+	}, nil)
+
+	// Only the member whose role has changed is updated, rather than removed and added again.
+	mockMembersService.EXPECT().Patch("test", "foo@email", &admin.Member{Role: "OWNER"}).Return(nil)
+	mockCall.On("callPatch", ctx, mock.Anything).Once().Return(&admin.Member{}, nil)
+
+	err := gosync.NewTyped[gosync.Item](&staticMembers{items: []gosync.Item{
+		{ID: "foo@email", Attributes: map[string]string{RoleAttribute: "OWNER"}},
+		{ID: "bar@email", Attributes: map[string]string{RoleAttribute: "MEMBER"}},
+	}}).SyncWith(ctx, members)
+
+	require.NoError(t, err)
+}
+
+// staticMembers is a read-only TypedAdapter source of members.
+type staticMembers struct {
+	items []gosync.Item
+}
+
+func (s *staticMembers) Get(_ context.Context) ([]gosync.Item, error) {
+	return s.items, nil
+}
+
+func (s *staticMembers) Add(_ context.Context, _ []gosync.Item) error {
+	return gosync.ErrReadOnly
+}
+
+func (s *staticMembers) Remove(_ context.Context, _ []gosync.Item) error {
+	return gosync.ErrReadOnly
+}
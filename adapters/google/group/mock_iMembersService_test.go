@@ -166,6 +166,56 @@ func (_c *mockIMembersService_List_Call) RunAndReturn(run func(string) *admin.Me
 	return _c
 }
 
+// Patch provides a mock function with given fields: groupKey, memberKey, member
+func (_m *mockIMembersService) Patch(groupKey string, memberKey string, member *admin.Member) *admin.MembersPatchCall {
+	ret := _m.Called(groupKey, memberKey, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *admin.MembersPatchCall
+	if rf, ok := ret.Get(0).(func(string, string, *admin.Member) *admin.MembersPatchCall); ok {
+		r0 = rf(groupKey, memberKey, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.MembersPatchCall)
+		}
+	}
+
+	return r0
+}
+
+// mockIMembersService_Patch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Patch'
+type mockIMembersService_Patch_Call struct {
+	*mock.Call
+}
+
+// Patch is a helper method to define mock.On call
+//   - groupKey string
+//   - memberKey string
+//   - member *admin.Member
+func (_e *mockIMembersService_Expecter) Patch(groupKey interface{}, memberKey interface{}, member interface{}) *mockIMembersService_Patch_Call {
+	return &mockIMembersService_Patch_Call{Call: _e.mock.On("Patch", groupKey, memberKey, member)}
+}
+
+func (_c *mockIMembersService_Patch_Call) Run(run func(groupKey string, memberKey string, member *admin.Member)) *mockIMembersService_Patch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(*admin.Member))
+	})
+	return _c
+}
+
+func (_c *mockIMembersService_Patch_Call) Return(_a0 *admin.MembersPatchCall) *mockIMembersService_Patch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockIMembersService_Patch_Call) RunAndReturn(run func(string, string, *admin.Member) *admin.MembersPatchCall) *mockIMembersService_Patch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockIMembersService creates a new instance of mockIMembersService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockIMembersService(t interface {
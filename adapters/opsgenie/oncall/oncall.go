@@ -19,13 +19,16 @@ package oncall
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	gosync "github.com/ovotech/go-sync"
 )
@@ -40,9 +43,31 @@ const ScheduleID gosync.ConfigKey = "schedule_id"
 
 var (
 	_ gosync.Adapter         = &OnCall{} // Ensure [oncall.OnCall] fully satisfies the [gosync.Adapter] interface.
+	_ gosync.Describer       = &OnCall{} // Ensure [oncall.OnCall] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Capable         = &OnCall{} // Ensure [oncall.OnCall] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Validator       = &OnCall{} // Ensure [oncall.OnCall] fully satisfies the [gosync.Validator] interface.
 	_ gosync.InitFn[*OnCall] = Init      // Ensure [oncall.Init] fully satisfies the [gosync.InitFn] type.
 )
 
+// Schema describes the configuration that [oncall.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: OpsgenieAPIKey, Secret: true, Description: "Opsgenie API key, unless a client is passed with WithClient."},
+	{Key: ScheduleID, Required: true, Description: "ID of the schedule to read on-call participants from."},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("opsgenie/oncall", Init)
+	gosync.RegisterSchema("opsgenie/oncall", Schema)
+}
+
+// hasStatusCode returns true if the Opsgenie API responded to a request with an HTTP status code.
+func hasStatusCode(err error, code int) bool {
+	var apiErr *client.ApiError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
+}
+
 type iOpsgenieSchedule interface {
 	GetOnCalls(context context.Context, request *schedule.GetOnCallsRequest) (*schedule.GetOnCallsResult, error)
 }
@@ -51,12 +76,12 @@ type OnCall struct {
 	client     iOpsgenieSchedule
 	scheduleID string
 	getTime    func() time.Time
-	Logger     *log.Logger
+	Logger     *slog.Logger
 }
 
 // Get email addresses of users currently on-call.
 func (o *OnCall) Get(ctx context.Context) ([]string, error) {
-	o.Logger.Printf("Fetching users currently on-call in Opsgenie schedule %s", o.scheduleID)
+	o.Logger.Info("Fetching users currently on-call in Opsgenie schedule")
 
 	date := o.getTime()
 	flat := true
@@ -69,22 +94,41 @@ func (o *OnCall) Get(ctx context.Context) ([]string, error) {
 
 	result, err := o.client.GetOnCalls(ctx, onCallRequest)
 	if err != nil {
-		return nil, fmt.Errorf("opsgenie.oncall.get.getoncalls -> %w", err)
+		return nil, gosync.NewError(o, gosync.PhaseGet, nil, fmt.Errorf("getoncalls -> %w", err))
 	}
 
-	o.Logger.Println("Fetched on-call users successfully")
+	o.Logger.Info("Fetched on-call users successfully", slog.Int(gosync.LogKeyCount, len(result.OnCallRecipients)))
 
 	return result.OnCallRecipients, nil
 }
 
 // Add is not supported, as the on-call is readonly.
-func (o *OnCall) Add(_ context.Context, _ []string) error {
-	return gosync.ErrReadOnly
+func (o *OnCall) Add(_ context.Context, emails []string) error {
+	return gosync.NewError(o, gosync.PhaseAdd, emails, gosync.ErrReadOnly)
 }
 
 // Remove is not supported, as the on-call is readonly.
-func (o *OnCall) Remove(_ context.Context, _ []string) error {
-	return gosync.ErrReadOnly
+func (o *OnCall) Remove(_ context.Context, emails []string) error {
+	return gosync.NewError(o, gosync.PhaseRemove, emails, gosync.ErrReadOnly)
+}
+
+// Validate checks that the Opsgenie schedule exists, and that the API key is allowed to read it.
+func (o *OnCall) Validate(ctx context.Context) error {
+	_, err := o.client.GetOnCalls(ctx, &schedule.GetOnCallsRequest{
+		ScheduleIdentifierType: schedule.Id,
+		ScheduleIdentifier:     o.scheduleID,
+	})
+
+	switch {
+	case hasStatusCode(err, http.StatusNotFound):
+		return gosync.NewError(o, gosync.PhaseValidate, nil, fmt.Errorf("getoncalls -> %w", gosync.ErrNotFound))
+	case hasStatusCode(err, http.StatusForbidden):
+		return gosync.NewError(o, gosync.PhaseValidate, nil, fmt.Errorf("getoncalls -> %w", gosync.ErrMissingPermission))
+	case err != nil:
+		return gosync.NewError(o, gosync.PhaseValidate, nil, fmt.Errorf("getoncalls -> %w", err))
+	}
+
+	return nil
 }
 
 // WithClient passes a custom Opsgenie Schedule client to the adapter.
@@ -94,8 +138,30 @@ func WithClient(client *schedule.Client) gosync.ConfigFn[*OnCall] {
 	}
 }
 
-// WithLogger passes a custom logger to the adapter.
+// Kind of adapter.
+func (o *OnCall) Kind() string {
+	return "opsgenie/oncall"
+}
+
+// Target returns the Opsgenie schedule ID.
+func (o *OnCall) Target() string {
+	return o.scheduleID
+}
+
+// Capabilities of the adapter, which is read-only.
+func (o *OnCall) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
 func WithLogger(logger *log.Logger) gosync.ConfigFn[*OnCall] {
+	return func(o *OnCall) {
+		o.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*OnCall] {
 	return func(o *OnCall) {
 		o.Logger = logger
 	}
@@ -112,20 +178,20 @@ func Init(
 	config map[gosync.ConfigKey]string,
 	configFns ...gosync.ConfigFn[*OnCall],
 ) (*OnCall, error) {
-	for _, key := range []gosync.ConfigKey{ScheduleID} {
-		if _, ok := config[key]; !ok {
-			return nil, fmt.Errorf("opsgenie.oncall.init -> %w(%s)", gosync.ErrMissingConfig, key)
-		}
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("opsgenie.oncall.init -> %w", err)
 	}
 
 	adapter := &OnCall{
-		scheduleID: config[ScheduleID],
+		scheduleID: parsed.String(ScheduleID),
 		getTime:    time.Now,
 	}
 
-	if _, ok := config[OpsgenieAPIKey]; ok {
+	if parsed.Has(OpsgenieAPIKey) {
 		scheduleClient, err := schedule.NewClient(&client.Config{
-			ApiKey: config[OpsgenieAPIKey],
+			ApiKey:     parsed.String(OpsgenieAPIKey),
+			HttpClient: &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
 		})
 		if err != nil {
 			return nil, fmt.Errorf("opsgenie.oncall.init -> %w", err)
@@ -139,13 +205,11 @@ func Init(
 	}
 
 	if adapter.Logger == nil {
-		logger := log.New(
-			os.Stderr, "[go-sync/opsgenie/oncall] ", log.LstdFlags|log.Lshortfile|log.Lmsgprefix,
-		)
-
-		WithLogger(logger)(adapter)
+		WithSlogLogger(slog.Default())(adapter)
 	}
 
+	parsed.LogUnknown(adapter.Logger)
+
 	if adapter.client == nil {
 		return nil, fmt.Errorf("opsgenie.oncall.init -> %w(%s)", gosync.ErrMissingConfig, OpsgenieAPIKey)
 	}
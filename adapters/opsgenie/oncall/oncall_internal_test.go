@@ -1,10 +1,12 @@
 package oncall
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"log"
-	"os"
+	"log/slog"
+	"net/http"
 	"testing"
 	"time"
 
@@ -103,10 +105,57 @@ func TestOnCall_Remove(t *testing.T) {
 
 	err := adapter.Remove(ctx, []string{"example@bar.com"})
 
+	var syncErr *gosync.Error
+
 	require.ErrorIs(t, err, gosync.ErrReadOnly)
+	require.ErrorAs(t, err, &syncErr)
+	assert.Equal(t, gosync.PhaseRemove, syncErr.Phase)
+	assert.Equal(t, "opsgenie/oncall", syncErr.Kind)
+	assert.Equal(t, []string{"example@bar.com"}, syncErr.Things)
 	assert.Zero(t, scheduleClient.Calls)
 }
 
+func TestOnCall_Capabilities(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	adapter, _ := createMockedAdapter(ctx, t, time.Now())
+
+	assert.True(t, gosync.CapabilitiesOf(adapter).ReadOnly())
+}
+
+func TestOnCall_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		err  error
+		want error
+	}{
+		"Success":   {},
+		"Forbidden": {err: &client.ApiError{StatusCode: http.StatusForbidden}, want: gosync.ErrMissingPermission},
+		"Not found": {err: &client.ApiError{StatusCode: http.StatusNotFound}, want: gosync.ErrNotFound},
+		"Failure":   {err: errGetOnCall, want: errGetOnCall},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			adapter, scheduleClient := createMockedAdapter(ctx, t, time.Now())
+			scheduleClient.EXPECT().GetOnCalls(ctx, &schedule.GetOnCallsRequest{
+				ScheduleIdentifierType: schedule.Id,
+				ScheduleIdentifier:     "test",
+			}).Return(&schedule.GetOnCallsResult{}, test.err)
+
+			err := adapter.Validate(ctx)
+
+			require.ErrorIs(t, err, test.want)
+		})
+	}
+}
+
 func TestInit(t *testing.T) {
 	t.Parallel()
 
@@ -154,7 +203,9 @@ func TestInit(t *testing.T) {
 	t.Run("with logger", func(t *testing.T) {
 		t.Parallel()
 
-		logger := log.New(os.Stderr, "custom logger", log.LstdFlags)
+		var buf bytes.Buffer
+
+		logger := log.New(&buf, "custom logger ", 0)
 
 		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 			OpsgenieAPIKey: "test",
@@ -162,7 +213,27 @@ func TestInit(t *testing.T) {
 		}, WithLogger(logger))
 
 		require.NoError(t, err)
-		assert.Equal(t, logger, adapter.Logger)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "custom logger INFO test")
+	})
+
+	t.Run("with slog logger", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
+			OpsgenieAPIKey: "test",
+			ScheduleID:     "schedule",
+		}, WithSlogLogger(logger))
+
+		require.NoError(t, err)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "msg=test")
 	})
 
 	t.Run("with client", func(t *testing.T) {
@@ -181,3 +252,16 @@ func TestInit(t *testing.T) {
 		assert.Equal(t, scheduleClient, adapter.client)
 	})
 }
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("opsgenie/oncall")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("opsgenie/oncall")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
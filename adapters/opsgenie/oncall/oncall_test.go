@@ -3,6 +3,7 @@ package oncall_test
 import (
 	"context"
 	"log"
+	"log/slog"
 	"os"
 
 	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
@@ -61,3 +62,19 @@ func ExampleWithLogger() {
 
 	gosync.New(adapter)
 }
+
+func ExampleWithSlogLogger() {
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	adapter, err := oncall.Init(ctx, map[gosync.ConfigKey]string{
+		oncall.OpsgenieAPIKey: "default",
+		oncall.ScheduleID:     "opsgenie-schedule-id",
+	}, oncall.WithSlogLogger(logger))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gosync.New(adapter)
+}
@@ -24,11 +24,13 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"net/http"
 
 	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
 	"github.com/opsgenie/opsgenie-go-sdk-v2/og"
 	ogSchedule "github.com/opsgenie/opsgenie-go-sdk-v2/schedule"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/exp/slices"
 
 	gosync "github.com/ovotech/go-sync"
@@ -43,13 +45,36 @@ const OpsgenieAPIKey gosync.ConfigKey = "opsgenie_api_key" //nolint:gosec
 const ScheduleID gosync.ConfigKey = "schedule_id"
 
 var (
-	_ gosync.Adapter           = &Schedule{} // Ensure [schedule.Schedule] fully satisfies the [gosync.Adapter] interface.
-	_ gosync.InitFn[*Schedule] = Init        // Ensure [schedule.Init] fully satisfies the [gosync.InitFn] type.
+	_ gosync.Adapter   = &Schedule{} // Ensure [schedule.Schedule] fully satisfies the [gosync.Adapter] interface.
+	_ gosync.Describer = &Schedule{} // Ensure [schedule.Schedule] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Capable   = &Schedule{} // Ensure [schedule.Schedule] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Validator = &Schedule{} // Ensure [schedule.Schedule] fully satisfies the [gosync.Validator] interface.
+
+	_ gosync.InitFn[*Schedule] = Init // Ensure [schedule.Init] fully satisfies the [gosync.InitFn] type.
 
 	ErrMultipleRotations = errors.New("gosync can only manage schedules with a single rotation")
 	ErrNoRotations       = errors.New("gosync cannot create rotations - you must have 1 already defined for schedule")
 )
 
+// Schema describes the configuration that [schedule.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: OpsgenieAPIKey, Secret: true, Description: "Opsgenie API key, unless a client is passed with WithClient."},
+	{Key: ScheduleID, Required: true, Description: "ID of the Opsgenie schedule."},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("opsgenie/schedule", Init)
+	gosync.RegisterSchema("opsgenie/schedule", Schema)
+}
+
+// hasStatusCode returns true if the Opsgenie API responded to a request with an HTTP status code.
+func hasStatusCode(err error, code int) bool {
+	var apiErr *client.ApiError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
+}
+
 type iOpsgenieSchedule interface {
 	Get(ctx context.Context, request *ogSchedule.GetRequest) (*ogSchedule.GetResult, error)
 	UpdateRotation(
@@ -62,16 +87,16 @@ type Schedule struct {
 	client     iOpsgenieSchedule
 	scheduleID string
 	schedule   *ogSchedule.GetResult
-	Logger     *log.Logger
+	Logger     *slog.Logger
 }
 
 // Get a flattened list of all participants, even across multiple rotations.
 func (s *Schedule) Get(ctx context.Context) ([]string, error) {
-	s.Logger.Printf("Getting all participants in the Opsgenie schedule %s", s.scheduleID)
+	s.Logger.Info("Getting all participants in the Opsgenie schedule")
 
 	result, err := s.fetchSchedule(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("opsgenie.schedule.get.fetchschedule -> %w", err)
+		return nil, gosync.NewError(s, gosync.PhaseGet, nil, fmt.Errorf("fetchschedule -> %w", err))
 	}
 
 	emails := make([]string, 0)
@@ -84,23 +109,23 @@ func (s *Schedule) Get(ctx context.Context) ([]string, error) {
 		}
 	}
 
-	s.Logger.Printf("Found %d participants of schedule %s: %s", len(emails), s.scheduleID, emails)
+	s.Logger.Info("Found participants of schedule", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	return emails, nil
 }
 
 // Add new participants to a rotation, but the schedule must only have 1 rotation defined.
 func (s *Schedule) Add(ctx context.Context, emails []string) error {
-	s.Logger.Printf("Adding %d users to schedule %s: %s", len(emails), s.scheduleID, emails)
+	s.Logger.Info("Adding users to schedule", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	result, err := s.fetchSchedule(ctx)
 	if err != nil {
-		return fmt.Errorf("opsgenie.schedule.add.fetchschedule -> %w", err)
+		return gosync.NewError(s, gosync.PhaseAdd, emails, fmt.Errorf("fetchschedule -> %w", err))
 	}
 
 	rotation, err := s.getRotation(result)
 	if err != nil {
-		return fmt.Errorf("opsgenie.schedule.add.getrotation -> %w", err)
+		return gosync.NewError(s, gosync.PhaseAdd, emails, fmt.Errorf("getrotation -> %w", err))
 	}
 
 	// Get current participants
@@ -115,13 +140,15 @@ func (s *Schedule) Add(ctx context.Context, emails []string) error {
 	// Add any new participants
 	for _, email := range emails {
 		if !slices.Contains(updatedParticipants, email) {
+			s.Logger.Debug("Adding user", slog.String(gosync.LogKeyThing, email))
+
 			updatedParticipants = append(updatedParticipants, email)
 		}
 	}
 
 	err = s.updateParticipants(ctx, rotation, updatedParticipants)
 	if err != nil {
-		return fmt.Errorf("opsgenie.schedule.add.updateparticipants -> %w", err)
+		return gosync.NewError(s, gosync.PhaseAdd, emails, fmt.Errorf("updateparticipants -> %w", err))
 	}
 
 	return nil
@@ -129,30 +156,38 @@ func (s *Schedule) Add(ctx context.Context, emails []string) error {
 
 // Remove participants from a rotation, but the schedule must only have 1 rotation defined.
 func (s *Schedule) Remove(ctx context.Context, emails []string) error {
-	s.Logger.Printf("Removing %d users from schedule %s: %s", len(emails), s.scheduleID, emails)
+	s.Logger.Info("Removing users from schedule", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	result, err := s.fetchSchedule(ctx)
 	if err != nil {
-		return fmt.Errorf("opsgenie.schedule.remove.fetchschedule -> %w", err)
+		return gosync.NewError(s, gosync.PhaseRemove, emails, fmt.Errorf("fetchschedule -> %w", err))
 	}
 
 	rotation, err := s.getRotation(result)
 	if err != nil {
-		return fmt.Errorf("opsgenie.schedule.remove.getrotation -> %w", err)
+		return gosync.NewError(s, gosync.PhaseRemove, emails, fmt.Errorf("getrotation -> %w", err))
 	}
 
 	// Build up the participants list - removing any that are to be removed
 	updatedParticipants := make([]string, 0)
 
 	for _, participant := range rotation.Participants {
-		if participant.Type == og.User && !slices.Contains(emails, participant.Username) {
-			updatedParticipants = append(updatedParticipants, participant.Username)
+		if participant.Type != og.User {
+			continue
 		}
+
+		if slices.Contains(emails, participant.Username) {
+			s.Logger.Debug("Removing user", slog.String(gosync.LogKeyThing, participant.Username))
+
+			continue
+		}
+
+		updatedParticipants = append(updatedParticipants, participant.Username)
 	}
 
 	err = s.updateParticipants(ctx, rotation, updatedParticipants)
 	if err != nil {
-		return fmt.Errorf("opsgenie.schedule.remove.updateparticipants -> %w", err)
+		return gosync.NewError(s, gosync.PhaseRemove, emails, fmt.Errorf("updateparticipants -> %w", err))
 	}
 
 	return nil
@@ -172,7 +207,7 @@ func (s *Schedule) getRotation(result *ogSchedule.GetResult) (*og.Rotation, erro
 
 func (s *Schedule) fetchSchedule(ctx context.Context) (*ogSchedule.GetResult, error) {
 	if s.schedule == nil {
-		s.Logger.Printf("Fetching schedule %s from Opsgenie", s.scheduleID)
+		s.Logger.Debug("Fetching schedule from Opsgenie")
 
 		scheduleRequest := &ogSchedule.GetRequest{
 			IdentifierType:  ogSchedule.Id,
@@ -186,7 +221,7 @@ func (s *Schedule) fetchSchedule(ctx context.Context) (*ogSchedule.GetResult, er
 
 		s.schedule = result
 	} else {
-		s.Logger.Printf("Already have schedule %s cached", s.scheduleID)
+		s.Logger.Debug("Already have schedule cached")
 	}
 
 	return s.schedule, nil
@@ -210,7 +245,10 @@ func (s *Schedule) updateParticipants(ctx context.Context, rotation *og.Rotation
 		},
 	}
 
-	s.Logger.Printf("Updating rotation %s of schedule %s with participants: %s", rotation.Id, s.scheduleID, emails)
+	s.Logger.Info("Updating rotation participants",
+		slog.String("rotation", rotation.Id),
+		slog.Int(gosync.LogKeyCount, len(emails)),
+	)
 
 	_, err := s.client.UpdateRotation(ctx, request)
 	if err != nil {
@@ -220,6 +258,53 @@ func (s *Schedule) updateParticipants(ctx context.Context, rotation *og.Rotation
 	return nil
 }
 
+/*
+Validate checks that the Opsgenie schedule exists, and that the API key is allowed to read it. Opsgenie can't list the
+access rights of an API key, so if the schedule has a single rotation that it could synchronise, the key's Update right
+is probed by saving the rotation with the participants it already has.
+*/
+func (s *Schedule) Validate(ctx context.Context) error {
+	result, err := s.fetchSchedule(ctx)
+
+	switch {
+	case hasStatusCode(err, http.StatusNotFound):
+		return gosync.NewError(s, gosync.PhaseValidate, nil, fmt.Errorf("fetchschedule -> %w", gosync.ErrNotFound))
+	case hasStatusCode(err, http.StatusForbidden):
+		return gosync.NewError(s, gosync.PhaseValidate, nil, fmt.Errorf(
+			"fetchschedule -> %w",
+			gosync.ErrMissingPermission,
+		))
+	case err != nil:
+		return gosync.NewError(s, gosync.PhaseValidate, nil, fmt.Errorf("fetchschedule -> %w", err))
+	}
+
+	// Schedules with several rotations can only be used as a source, so they don't need the Update right.
+	if len(result.Schedule.Rotations) != 1 {
+		return nil
+	}
+
+	rotation := result.Schedule.Rotations[0]
+
+	_, err = s.client.UpdateRotation(ctx, &ogSchedule.UpdateRotationRequest{
+		ScheduleIdentifierType:  ogSchedule.Id,
+		ScheduleIdentifierValue: s.scheduleID,
+		RotationId:              rotation.Id,
+		Rotation:                &og.Rotation{Participants: rotation.Participants},
+	})
+
+	switch {
+	case hasStatusCode(err, http.StatusForbidden):
+		return gosync.NewError(s, gosync.PhaseValidate, nil, fmt.Errorf(
+			"updaterotation -> %w(Update)",
+			gosync.ErrMissingPermission,
+		))
+	case err != nil:
+		return gosync.NewError(s, gosync.PhaseValidate, nil, fmt.Errorf("updaterotation -> %w", err))
+	}
+
+	return nil
+}
+
 // WithClient passes a custom Opsgenie Schedule client to the adapter.
 func WithClient(client *ogSchedule.Client) gosync.ConfigFn[*Schedule] {
 	return func(s *Schedule) {
@@ -227,8 +312,34 @@ func WithClient(client *ogSchedule.Client) gosync.ConfigFn[*Schedule] {
 	}
 }
 
-// WithLogger passes a custom logger to the adapter.
+// Kind of adapter.
+func (s *Schedule) Kind() string {
+	return "opsgenie/schedule"
+}
+
+// Target returns the Opsgenie schedule ID.
+func (s *Schedule) Target() string {
+	return s.scheduleID
+}
+
+// Capabilities of the adapter. The rotation's participants are replaced on each Add and Remove.
+func (s *Schedule) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+		ReplacesList:   true,
+	}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
 func WithLogger(logger *log.Logger) gosync.ConfigFn[*Schedule] {
+	return func(s *Schedule) {
+		s.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*Schedule] {
 	return func(s *Schedule) {
 		s.Logger = logger
 	}
@@ -245,19 +356,19 @@ func Init(
 	config map[gosync.ConfigKey]string,
 	configFns ...gosync.ConfigFn[*Schedule],
 ) (*Schedule, error) {
-	for _, key := range []gosync.ConfigKey{ScheduleID} {
-		if _, ok := config[key]; !ok {
-			return nil, fmt.Errorf("opsgenie.schedule.init -> %w(%s)", gosync.ErrMissingConfig, key)
-		}
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("opsgenie.schedule.init -> %w", err)
 	}
 
 	adapter := &Schedule{
-		scheduleID: config[ScheduleID],
+		scheduleID: parsed.String(ScheduleID),
 	}
 
-	if _, ok := config[OpsgenieAPIKey]; ok {
+	if parsed.Has(OpsgenieAPIKey) {
 		scheduleClient, err := ogSchedule.NewClient(&client.Config{
-			ApiKey: config[OpsgenieAPIKey],
+			ApiKey:     parsed.String(OpsgenieAPIKey),
+			HttpClient: &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
 		})
 		if err != nil {
 			return nil, fmt.Errorf("opsgenie.schedule.init -> %w", err)
@@ -271,13 +382,11 @@ func Init(
 	}
 
 	if adapter.Logger == nil {
-		logger := log.New(
-			os.Stderr, "[go-sync/opsgenie/schedule] ", log.LstdFlags|log.Lshortfile|log.Lmsgprefix,
-		)
-
-		WithLogger(logger)(adapter)
+		WithSlogLogger(slog.Default())(adapter)
 	}
 
+	parsed.LogUnknown(adapter.Logger)
+
 	if adapter.client == nil {
 		return nil, fmt.Errorf("opsgenie.schedule.init -> %w(%s)", gosync.ErrMissingConfig, OpsgenieAPIKey)
 	}
@@ -1,11 +1,13 @@
 package schedule
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"net/http"
 	"testing"
 
 	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
@@ -16,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adaptertest"
 )
 
 var errResponse = errors.New("an example error")
@@ -332,6 +335,107 @@ func TestSchedule_Remove(t *testing.T) {
 	})
 }
 
+func TestSchedule_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	adapter, scheduleClient := createMockedAdapter(ctx, t)
+
+	expectedScheduleResult := testBuildScheduleGetResult(1, "example1@example.com", "example2@example.com")
+	scheduleClient.EXPECT().Get(ctx, mock.Anything).Return(expectedScheduleResult, nil)
+	scheduleClient.EXPECT().UpdateRotation(
+		ctx,
+		testBuildExpectedUpdateRotationRequest("example1@example.com", "example2@example.com"),
+	).Return(nil, nil)
+
+	adaptertest.Idempotent(ctx, t, adapter, []string{"example1@example.com"}, []string{"example3@example.com"})
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		err  error
+		want error
+	}{
+		"Success":   {},
+		"Forbidden": {err: &client.ApiError{StatusCode: http.StatusForbidden}, want: gosync.ErrMissingPermission},
+		"Not found": {err: &client.ApiError{StatusCode: http.StatusNotFound}, want: gosync.ErrNotFound},
+		"Failure":   {err: errResponse, want: errResponse},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			adapter, scheduleClient := createMockedAdapter(ctx, t)
+			scheduleClient.EXPECT().Get(ctx, mock.Anything).Return(&schedule.GetResult{}, test.err)
+
+			err := adapter.Validate(ctx)
+
+			require.ErrorIs(t, err, test.want)
+		})
+	}
+}
+
+func TestSchedule_Validate_Update(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	participants := []og.Participant{{Type: og.User, Username: "foo@email"}, {Type: og.Team, Name: "bar"}}
+
+	tests := map[string]struct {
+		rotations []og.Rotation
+		err       error
+		want      error
+	}{
+		"Success": {
+			rotations: []og.Rotation{{Id: "rotation", Participants: participants}},
+		},
+		"Forbidden": {
+			rotations: []og.Rotation{{Id: "rotation", Participants: participants}},
+			err:       &client.ApiError{StatusCode: http.StatusForbidden},
+			want:      gosync.ErrMissingPermission,
+		},
+		"Failure": {
+			rotations: []og.Rotation{{Id: "rotation", Participants: participants}},
+			err:       errResponse,
+			want:      errResponse,
+		},
+		"Multiple rotations": {
+			rotations: []og.Rotation{{Id: "first"}, {Id: "second"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			adapter, scheduleClient := createMockedAdapter(ctx, t)
+			scheduleClient.EXPECT().Get(ctx, mock.Anything).Return(&schedule.GetResult{
+				Schedule: schedule.Schedule{Rotations: test.rotations},
+			}, nil)
+
+			if len(test.rotations) == 1 {
+				// The rotation is saved with the participants that it already has, including teams.
+				scheduleClient.EXPECT().UpdateRotation(ctx, &schedule.UpdateRotationRequest{
+					ScheduleIdentifierType:  schedule.Id,
+					ScheduleIdentifierValue: adapter.scheduleID,
+					RotationId:              "rotation",
+					Rotation:                &og.Rotation{Participants: participants},
+				}).Return(&schedule.UpdateRotationResult{}, test.err)
+			}
+
+			err := adapter.Validate(ctx)
+
+			require.ErrorIs(t, err, test.want)
+		})
+	}
+}
+
 func TestInit(t *testing.T) {
 	t.Parallel()
 
@@ -379,7 +483,9 @@ func TestInit(t *testing.T) {
 	t.Run("with logger", func(t *testing.T) {
 		t.Parallel()
 
-		logger := log.New(os.Stderr, "custom logger", log.LstdFlags)
+		var buf bytes.Buffer
+
+		logger := log.New(&buf, "custom logger ", 0)
 
 		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 			OpsgenieAPIKey: "test",
@@ -387,7 +493,27 @@ func TestInit(t *testing.T) {
 		}, WithLogger(logger))
 
 		require.NoError(t, err)
-		assert.Equal(t, logger, adapter.Logger)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "custom logger INFO test")
+	})
+
+	t.Run("with slog logger", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
+			OpsgenieAPIKey: "test",
+			ScheduleID:     "schedule",
+		}, WithSlogLogger(logger))
+
+		require.NoError(t, err)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "msg=test")
 	})
 
 	t.Run("with client", func(t *testing.T) {
@@ -406,3 +532,16 @@ func TestInit(t *testing.T) {
 		assert.Equal(t, scheduleClient, adapter.client)
 	})
 }
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("opsgenie/schedule")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("opsgenie/schedule")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
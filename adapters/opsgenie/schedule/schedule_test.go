@@ -3,6 +3,7 @@ package schedule_test
 import (
 	"context"
 	"log"
+	"log/slog"
 	"os"
 
 	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
@@ -61,3 +62,19 @@ func ExampleWithLogger() {
 
 	gosync.New(adapter)
 }
+
+func ExampleWithSlogLogger() {
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	adapter, err := schedule.Init(ctx, map[gosync.ConfigKey]string{
+		schedule.OpsgenieAPIKey: "default",
+		schedule.ScheduleID:     "opsgenie-schedule-id",
+	}, schedule.WithSlogLogger(logger))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gosync.New(adapter)
+}
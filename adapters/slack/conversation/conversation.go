@@ -33,14 +33,16 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"math"
-	"os"
+	"log/slog"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/slack-go/slack"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/slack/internal/scopes"
 )
 
 /*
@@ -60,17 +62,57 @@ const MuteRestrictedErrOnKickFromPublic gosync.ConfigKey = "mute_restricted_err_
 var (
 	// Ensure [conversation.Conversation] fully satisfies the [gosync.Adapter] interface.
 	_ gosync.Adapter = &Conversation{}
+	// Ensure [conversation.Conversation] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Describer = &Conversation{}
+	// Ensure [conversation.Conversation] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Capable = &Conversation{}
+	// Ensure [conversation.Conversation] fully satisfies the [gosync.Validator] interface.
+	_ gosync.Validator = &Conversation{}
+	// Ensure [conversation.Conversation] fully satisfies the [gosync.Streamer] interface.
+	_ gosync.Streamer = &Conversation{}
 	// Ensure [conversation.Init] fully satisfies the [gosync.InitFn] type.
 	_ gosync.InitFn[*Conversation] = Init
 )
 
+// Schema describes the configuration that [conversation.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: SlackAPIKey, Secret: true, Description: "Slack API key. Not needed if a client is passed with WithClient."},
+	{Key: Name, Required: true, Description: "Name of the Slack conversation, without the leading #."},
+	{
+		Key:         MuteRestrictedErrOnKickFromPublic,
+		Type:        gosync.ConfigBool,
+		Description: "Mute the error when Slack prevents kicking users from public conversations.",
+	},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("slack/conversation", Init)
+	gosync.RegisterSchema("slack/conversation", Schema)
+}
+
+// requiredScopes are the OAuth scopes that the Slack token must be granted.
+var requiredScopes = []string{
+	"users:read",
+	"users:read.email",
+	"channels:manage",
+	"channels:read",
+	"groups:read",
+	"groups:write",
+	"im:write",
+	"mpim:write",
+}
+
 // iSlackConversation is a subset of the Slack Client, and used to build mocks for easy testing.
 type iSlackConversation interface {
-	GetUsersInConversation(params *slack.GetUsersInConversationParameters) ([]string, string, error)
-	GetUsersInfo(users ...string) (*[]slack.User, error)
-	GetUserByEmail(email string) (*slack.User, error)
-	InviteUsersToConversation(channelID string, users ...string) (*slack.Channel, error)
-	KickUserFromConversation(channelID string, user string) error
+	GetUsersInConversationContext(
+		ctx context.Context,
+		params *slack.GetUsersInConversationParameters,
+	) ([]string, string, error)
+	GetUsersInfoContext(ctx context.Context, users ...string) (*[]slack.User, error)
+	GetUserByEmailContext(ctx context.Context, email string) (*slack.User, error)
+	InviteUsersToConversationContext(ctx context.Context, channelID string, users ...string) (*slack.Channel, error)
+	KickUserFromConversationContext(ctx context.Context, channelID string, user string) error
 }
 
 type Conversation struct {
@@ -79,177 +121,241 @@ type Conversation struct {
 	conversationName                  string
 	// cache stores the Slack ID -> email mapping for use with the Remove method.
 	cache  map[string]string
-	Logger *log.Logger
+	scopes scopes.Fetcher // scopes fetches the scopes granted to the Slack token, if it was set with Init.
+	Logger *slog.Logger
 }
 
-// getListOfSlackUsernames gets a list of Slack users in a conversation, and paginates through the results.
-func (c *Conversation) getListOfSlackUsernames() ([]string, error) {
-	var (
-		cursor string
-		users  []string
-		err    error
-	)
+// usersInfoPageSize is the number of Slack users requested in each call to GetUsersInfo.
+const usersInfoPageSize = 30
 
-	for {
-		params := &slack.GetUsersInConversationParameters{
-			ChannelID: c.conversationName,
-			Cursor:    cursor,
-			Limit:     50, //nolint:gomnd,mnd
-		}
+// getEmails converts Slack users into their email addresses, skipping bots and caching their IDs for Remove.
+func (c *Conversation) getEmails(ctx context.Context, slackUsers []string) ([]string, error) {
+	c.Logger.Debug("Calling GetUsersInfo", slog.Int(gosync.LogKeyCount, len(slackUsers)))
 
-		var pageOfUsers []string
+	users, err := c.client.GetUsersInfoContext(ctx, slackUsers...)
+	if err != nil {
+		return nil, fmt.Errorf("getusersinfo -> %w", err)
+	}
 
-		pageOfUsers, cursor, err = c.client.GetUsersInConversation(params)
-		if err != nil {
-			return nil, fmt.Errorf("getusersinconversation(%s) -> %w", c.conversationName, err)
-		}
+	emails := make([]string, 0, len(*users))
 
-		users = append(users, pageOfUsers...)
+	for _, user := range *users {
+		if !user.IsBot {
+			emails = append(emails, strings.ToLower(user.Profile.Email))
 
-		if cursor == "" {
-			break
+			// Add the email -> ID map for use with Remove method.
+			c.cache[strings.ToLower(user.Profile.Email)] = user.ID
 		}
 	}
 
-	return users, nil
+	return emails, nil
 }
 
-// paginateUsersInfo requests.
-func (c *Conversation) paginateUsersInfo(slackUsers []string) (*[]slack.User, error) {
-	currentPage := 0
-	pageSize := 30
-	out := make([]slack.User, 0, cap(slackUsers))
-	totalPages := math.Floor(float64(len(slackUsers)) / float64(pageSize))
+/*
+inviteUsers invites Slack users to the conversation. Slack rejects the whole invite if any of the users are already in
+the conversation, so each user is then invited on their own and existing members are skipped.
+*/
+func (c *Conversation) inviteUsers(ctx context.Context, slackIds []string) error {
+	_, err := c.client.InviteUsersToConversationContext(ctx, c.conversationName, slackIds...)
+	if err == nil || !strings.Contains(err.Error(), "already_in_channel") {
+		return err //nolint:wrapcheck
+	}
 
-	for {
-		c.Logger.Printf("Calling GetUsersInfo page %v of %v", currentPage+1, totalPages+1)
+	if len(slackIds) == 1 {
+		c.Logger.Debug("Account is already a member, skipping", slog.String("id", slackIds[0]))
 
-		start := currentPage * pageSize
-		end := (currentPage * pageSize) + pageSize
+		return nil
+	}
 
-		if end > cap(slackUsers) {
-			end = cap(slackUsers)
+	for _, id := range slackIds {
+		if err := c.inviteUsers(ctx, []string{id}); err != nil {
+			return err
 		}
+	}
 
-		// Get a page of slackUsers to send up to the API.
-		page := slackUsers[start:end]
+	return nil
+}
+
+// Get email addresses in a Slack Conversation.
+func (c *Conversation) Get(ctx context.Context) ([]string, error) {
+	return gosync.Collect(ctx, c) //nolint:wrapcheck
+}
+
+/*
+Stream email addresses in a Slack Conversation. Members of the conversation are fetched a page at a time, and their
+emails are passed to fn in batches as they're looked up.
+*/
+func (c *Conversation) Stream(ctx context.Context, fn func(emails []string) error) error {
+	c.Logger.Info("Fetching accounts from Slack conversation")
+
+	// Initialise the cache.
+	c.cache = make(map[string]string)
 
-		// Request only the page of users.
-		users, err := c.client.GetUsersInfo(page...)
+	var (
+		cursor  string
+		pending []string // Slack users that haven't been converted to emails yet.
+		count   int
+	)
+
+	// emit converts a batch of Slack users into emails, and passes them to fn.
+	emit := func(slackUsers []string) error {
+		emails, err := c.getEmails(ctx, slackUsers)
 		if err != nil {
-			return nil, fmt.Errorf("paginateusersinfo -> %w", err)
+			return gosync.NewError(c, gosync.PhaseGet, nil, fmt.Errorf("getemails -> %w", err))
 		}
 
-		// Append the results to combined output.
-		out = append(out, *users...)
+		count += len(emails)
 
-		// When the output matches the number of input slack users, end the loop.
-		if len(out) == len(slackUsers) {
-			break
+		if err = fn(emails); err != nil {
+			return gosync.NewError(c, gosync.PhaseGet, nil, fmt.Errorf("stream -> %w", err))
 		}
 
-		// Increment the current page.
-		currentPage++
+		return nil
 	}
 
-	return &out, nil
-}
+	for {
+		params := &slack.GetUsersInConversationParameters{
+			ChannelID: c.conversationName,
+			Cursor:    cursor,
+			Limit:     50, //nolint:gomnd,mnd
+		}
 
-// Get email addresses in a Slack Conversation.
-func (c *Conversation) Get(_ context.Context) ([]string, error) {
-	c.Logger.Printf("Fetching accounts from Slack conversation %s", c.conversationName)
+		pageOfUsers, nextCursor, err := c.client.GetUsersInConversationContext(ctx, params)
+		if err != nil {
+			return gosync.NewError(c, gosync.PhaseGet, nil, fmt.Errorf("getusersinconversation -> %w", err))
+		}
 
-	// Initialise the cache.
-	c.cache = make(map[string]string)
+		pending = append(pending, pageOfUsers...)
 
-	slackUsers, err := c.getListOfSlackUsernames()
-	if err != nil {
-		return nil, fmt.Errorf("slack.conversation.get.getlistofslackusernames -> %w", err)
-	}
+		for len(pending) >= usersInfoPageSize {
+			if err = emit(pending[:usersInfoPageSize]); err != nil {
+				return err
+			}
 
-	if len(slackUsers) == 0 {
-		c.Logger.Println("Fetched no accounts from conversation")
+			pending = pending[usersInfoPageSize:]
+		}
 
-		return []string{}, nil
-	}
+		cursor = nextCursor
 
-	users, err := c.paginateUsersInfo(slackUsers)
-	if err != nil {
-		return nil, fmt.Errorf("slack.conversation.get.getusersinfo -> %w", err)
+		if cursor == "" {
+			break
+		}
 	}
 
-	emails := make([]string, 0, len(*users))
-
-	for _, user := range *users {
-		if !user.IsBot {
-			emails = append(emails, strings.ToLower(user.Profile.Email))
-
-			// Add the email -> ID map for use with Remove method.
-			c.cache[strings.ToLower(user.Profile.Email)] = user.ID
+	if len(pending) > 0 {
+		if err := emit(pending); err != nil {
+			return err
 		}
 	}
 
-	c.Logger.Println("Fetched accounts successfully")
+	c.Logger.Info("Fetched accounts successfully", slog.Int(gosync.LogKeyCount, count))
 
-	return emails, nil
+	return nil
 }
 
 // Add email addresses to a Slack Conversation.
-func (c *Conversation) Add(_ context.Context, emails []string) error {
-	c.Logger.Printf("Adding %s to Slack conversation %s", emails, c.conversationName)
+func (c *Conversation) Add(ctx context.Context, emails []string) error {
+	c.Logger.Info("Adding accounts to Slack conversation", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	slackIds := make([]string, len(emails))
 
 	for index, email := range emails {
-		user, err := c.client.GetUserByEmail(strings.ToLower(email))
+		c.Logger.Debug("Adding account", slog.String(gosync.LogKeyThing, email))
+
+		user, err := c.client.GetUserByEmailContext(ctx, strings.ToLower(email))
 		if err != nil {
-			return fmt.Errorf("slack.conversation.add.getuserbyemail(%s) -> %w", email, err)
+			return gosync.NewError(c, gosync.PhaseAdd, []string{email}, fmt.Errorf("getuserbyemail -> %w", err))
 		}
 
 		slackIds[index] = user.ID
 	}
 
-	_, err := c.client.InviteUsersToConversation(c.conversationName, slackIds...)
+	err := c.inviteUsers(ctx, slackIds)
 	if err != nil {
-		return fmt.Errorf("slack.conversation.add.inviteuserstoconversation(%s, ...) -> %w", c.conversationName, err)
+		return gosync.NewError(c, gosync.PhaseAdd, emails, fmt.Errorf("inviteuserstoconversation -> %w", err))
 	}
 
-	c.Logger.Println("Finished adding accounts successfully")
+	c.Logger.Info("Finished adding accounts successfully")
 
 	return nil
 }
 
 // Remove email addresses from a Slack Conversation.
-func (c *Conversation) Remove(_ context.Context, emails []string) error {
-	c.Logger.Printf("Removing %s from Slack conversation %s", emails, c.conversationName)
+func (c *Conversation) Remove(ctx context.Context, emails []string) error {
+	c.Logger.Info("Removing accounts from Slack conversation", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	// If the cache hasn't been generated, regenerate it.
 	if c.cache == nil {
-		return fmt.Errorf("slack.conversation.remove -> %w", gosync.ErrCacheEmpty)
+		return gosync.NewError(c, gosync.PhaseRemove, emails, gosync.ErrCacheEmpty)
 	}
 
 	for _, email := range emails {
-		err := c.client.KickUserFromConversation(c.conversationName, c.cache[strings.ToLower(email)])
-		if err != nil {
+		id, ok := c.cache[strings.ToLower(email)]
+		if !ok {
+			c.Logger.Debug("Account isn't a member, skipping", slog.String(gosync.LogKeyThing, email))
+
+			continue
+		}
+
+		c.Logger.Debug("Removing account", slog.String(gosync.LogKeyThing, email))
+
+		err := c.client.KickUserFromConversationContext(ctx, c.conversationName, id)
+		if err != nil && strings.Contains(err.Error(), "not_in_channel") {
+			c.Logger.Debug("Account isn't a member, skipping", slog.String(gosync.LogKeyThing, email))
+
+			continue
+		} else if err != nil {
 			if c.MuteRestrictedErrOnKickFromPublic && strings.Contains(err.Error(), "restricted_action") {
-				c.Logger.Println("Cannot kick from public channel, but error is muted by configuration - continuing")
+				c.Logger.Warn("Cannot kick from public channel, but error is muted by configuration - continuing")
 
 				return nil
 			}
 
-			return fmt.Errorf(
-				"slack.conversation.remove.kickuserfromconversation(%s, %s) -> %w",
-				c.conversationName,
-				c.cache[email],
+			return gosync.NewError(c, gosync.PhaseRemove, []string{email}, fmt.Errorf(
+				"kickuserfromconversation(%s) -> %w",
+				id,
 				err,
-			)
+			))
 		}
 
 		// To prevent rate limiting, sleep for 1 second after each kick.
 		time.Sleep(1 * time.Second)
 	}
 
-	c.Logger.Println("Finished removing accounts successfully")
+	c.Logger.Info("Finished removing accounts successfully")
+
+	return nil
+}
+
+/*
+Validate checks that the Slack token has been granted the required scopes, and that the conversation exists. Scopes
+are only checked if the Slack client was created by Init.
+*/
+func (c *Conversation) Validate(ctx context.Context) error {
+	if c.scopes != nil {
+		granted, err := c.scopes(ctx)
+		if err != nil {
+			return gosync.NewError(c, gosync.PhaseValidate, nil, fmt.Errorf("scopes -> %w", err))
+		}
+
+		if missing := scopes.Missing(granted, requiredScopes...); len(missing) > 0 {
+			return gosync.NewError(c, gosync.PhaseValidate, nil, fmt.Errorf(
+				"scopes -> %w(%s)",
+				gosync.ErrMissingPermission,
+				strings.Join(missing, ", "),
+			))
+		}
+	}
+
+	_, _, err := c.client.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+		ChannelID: c.conversationName,
+		Limit:     1,
+	})
+	if err != nil && strings.Contains(err.Error(), "channel_not_found") {
+		return gosync.NewError(c, gosync.PhaseValidate, nil, fmt.Errorf("getusersinconversation -> %w", gosync.ErrNotFound))
+	} else if err != nil {
+		return gosync.NewError(c, gosync.PhaseValidate, nil, fmt.Errorf("getusersinconversation -> %w", err))
+	}
 
 	return nil
 }
@@ -261,8 +367,35 @@ func WithClient(client *slack.Client) gosync.ConfigFn[*Conversation] {
 	}
 }
 
-// WithLogger passes a custom logger to the adapter.
+// Kind of adapter.
+func (c *Conversation) Kind() string {
+	return "slack/conversation"
+}
+
+// Target returns the Slack conversation name.
+func (c *Conversation) Target() string {
+	return c.conversationName
+}
+
+// Capabilities of the adapter. Remove uses the cache populated by Get, and Slack invites up to 1000 users at once.
+func (c *Conversation) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+		RequiresGet:    true,
+		MaxBatchSize:   1000, //nolint:gomnd,mnd
+	}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
 func WithLogger(logger *log.Logger) gosync.ConfigFn[*Conversation] {
+	return func(c *Conversation) {
+		c.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*Conversation] {
 	return func(c *Conversation) {
 		c.Logger = logger
 	}
@@ -279,40 +412,40 @@ func Init(
 	config map[gosync.ConfigKey]string,
 	configFns ...gosync.ConfigFn[*Conversation],
 ) (*Conversation, error) {
-	for _, key := range []gosync.ConfigKey{Name} {
-		if _, ok := config[key]; !ok {
-			return nil, fmt.Errorf("slack.conversation.init -> %w(%s)", gosync.ErrMissingConfig, key)
-		}
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("slack.conversation.init -> %w", err)
 	}
 
 	adapter := &Conversation{
 		MuteRestrictedErrOnKickFromPublic: false,
-		conversationName:                  config[Name],
+		conversationName:                  parsed.String(Name),
 		cache:                             make(map[string]string),
 	}
 
-	if _, ok := config[SlackAPIKey]; ok {
-		client := slack.New(config[SlackAPIKey])
+	if parsed.Has(SlackAPIKey) {
+		httpClient := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+		client := slack.New(parsed.String(SlackAPIKey), slack.OptionHTTPClient(httpClient))
 
 		WithClient(client)(adapter)
+
+		adapter.scopes = scopes.New(httpClient, slack.APIURL, parsed.String(SlackAPIKey))
 	}
 
 	for _, configFn := range configFns {
 		configFn(adapter)
 	}
 
-	if val, ok := config[MuteRestrictedErrOnKickFromPublic]; ok {
-		adapter.MuteRestrictedErrOnKickFromPublic = strings.ToLower(val) == "true"
+	if parsed.Has(MuteRestrictedErrOnKickFromPublic) {
+		adapter.MuteRestrictedErrOnKickFromPublic = parsed.Bool(MuteRestrictedErrOnKickFromPublic)
 	}
 
 	if adapter.Logger == nil {
-		logger := log.New(
-			os.Stderr, "[go-sync/slack/conversation] ", log.LstdFlags|log.Lshortfile|log.Lmsgprefix,
-		)
-
-		WithLogger(logger)(adapter)
+		WithSlogLogger(slog.Default())(adapter)
 	}
 
+	parsed.LogUnknown(adapter.Logger)
+
 	if adapter.client == nil {
 		return nil, fmt.Errorf("slack.conversation.init -> %w(%s)", gosync.ErrMissingConfig, SlackAPIKey)
 	}
@@ -1,9 +1,12 @@
 package conversation
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"strconv"
 	"testing"
@@ -13,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adaptertest"
 )
 
 func TestNew(t *testing.T) {
@@ -23,7 +27,7 @@ func TestNew(t *testing.T) {
 	adapter := &Conversation{
 		client:           slackClient,
 		conversationName: "test",
-		Logger:           log.New(os.Stdout, "", log.LstdFlags),
+		Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	assert.Equal(t, "test", adapter.conversationName)
@@ -34,35 +38,37 @@ func TestNew(t *testing.T) {
 func TestConversation_Get(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.TODO()
+
 	slackClient := newMockISlackConversation(t)
 
 	adapter := &Conversation{
 		client:           slackClient,
 		conversationName: "test",
-		Logger:           log.New(os.Stdout, "", log.LstdFlags),
+		Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	// First page.
-	slackClient.EXPECT().GetUsersInConversation(&slack.GetUsersInConversationParameters{
+	slackClient.EXPECT().GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
 		ChannelID: "test",
 		Cursor:    "",
 		Limit:     50,
 	}).Return([]string{"slack-foo"}, "page-2", nil)
 
 	// Second page.
-	slackClient.EXPECT().GetUsersInConversation(&slack.GetUsersInConversationParameters{
+	slackClient.EXPECT().GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
 		ChannelID: "test",
 		Cursor:    "page-2",
 		Limit:     50,
 	}).Return([]string{"slack-bar"}, "", nil)
 
 	// Users info response.
-	slackClient.EXPECT().GetUsersInfo("slack-foo", "slack-bar").Return(&[]slack.User{
+	slackClient.EXPECT().GetUsersInfoContext(ctx, "slack-foo", "slack-bar").Return(&[]slack.User{
 		{ID: "foo", IsBot: false, Profile: slack.UserProfile{Email: "foo@email"}},
 		{ID: "bar", IsBot: false, Profile: slack.UserProfile{Email: "bar@email"}},
 	}, nil)
 
-	accounts, err := adapter.Get(context.TODO())
+	accounts, err := adapter.Get(ctx)
 
 	require.NoError(t, err)
 	assert.ElementsMatch(t, accounts, []string{"foo@email", "bar@email"})
@@ -72,12 +78,14 @@ func TestConversation_Get(t *testing.T) {
 func TestConversation_Get_Pagination(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.TODO()
+
 	slackClient := newMockISlackConversation(t)
 
 	adapter := &Conversation{
 		client:           slackClient,
 		conversationName: "test",
-		Logger:           log.New(os.Stdout, "", log.LstdFlags),
+		Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	incrementingSlice := make([]string, 60)
@@ -102,40 +110,100 @@ func TestConversation_Get_Pagination(t *testing.T) {
 		}
 	}
 
-	slackClient.EXPECT().GetUsersInConversation(&slack.GetUsersInConversationParameters{
+	slackClient.EXPECT().GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
 		ChannelID: "test",
 		Cursor:    "",
 		Limit:     50,
 	}).Return(incrementingSlice, "", nil)
 
-	slackClient.EXPECT().GetUsersInfo(firstPage...).Return(&firstResponse, nil)
-	slackClient.EXPECT().GetUsersInfo(secondPage...).Return(&secondResponse, nil)
+	slackClient.EXPECT().GetUsersInfoContext(ctx, firstPage...).Return(&firstResponse, nil)
+	slackClient.EXPECT().GetUsersInfoContext(ctx, secondPage...).Return(&secondResponse, nil)
+
+	_, err := adapter.Get(ctx)
+
+	require.NoError(t, err)
+}
+
+func TestConversation_Stream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	slackClient := newMockISlackConversation(t)
+
+	adapter := &Conversation{
+		client:           slackClient,
+		conversationName: "test",
+		Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	// newUsers creates Slack IDs and users numbered from start to end.
+	newUsers := func(start, end int) ([]string, []interface{}, []slack.User) {
+		ids, args, users := []string{}, []interface{}{}, []slack.User{}
+
+		for idx := start; idx < end; idx++ {
+			ids = append(ids, strconv.Itoa(idx))
+			args = append(args, strconv.Itoa(idx))
+			users = append(users, slack.User{ID: strconv.Itoa(idx), Profile: slack.UserProfile{Email: strconv.Itoa(idx)}})
+		}
+
+		return ids, args, users
+	}
+
+	firstPage, _, _ := newUsers(0, 40)
+	secondPage, _, _ := newUsers(40, 60)
+	_, firstArgs, firstUsers := newUsers(0, 30)
+	_, secondArgs, secondUsers := newUsers(30, 60)
+
+	var events []string
+
+	slackClient.EXPECT().GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+		ChannelID: "test",
+		Limit:     50,
+	}).Return(firstPage, "page-2", nil)
+	slackClient.EXPECT().GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+		ChannelID: "test",
+		Cursor:    "page-2",
+		Limit:     50,
+	}).Run(func(_ context.Context, _ *slack.GetUsersInConversationParameters) {
+		events = append(events, "fetch page-2")
+	}).Return(secondPage, "", nil)
+	slackClient.EXPECT().GetUsersInfoContext(ctx, firstArgs...).Return(&firstUsers, nil)
+	slackClient.EXPECT().GetUsersInfoContext(ctx, secondArgs...).Return(&secondUsers, nil)
+
+	err := adapter.Stream(ctx, func(emails []string) error {
+		events = append(events, fmt.Sprintf("emails %s-%s", emails[0], emails[len(emails)-1]))
 
-	_, err := adapter.Get(context.TODO())
+		return nil
+	})
 
 	require.NoError(t, err)
+	assert.Equal(t, []string{"emails 0-29", "fetch page-2", "emails 30-59"}, events)
+	assert.Len(t, adapter.cache, 60)
 }
 
 func TestConversation_Add(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.TODO()
+
 	slackClient := newMockISlackConversation(t)
 
 	adapter := &Conversation{
 		client:           slackClient,
 		conversationName: "test",
-		Logger:           log.New(os.Stdout, "", log.LstdFlags),
+		Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
-	slackClient.EXPECT().GetUserByEmail("foo@email").Return(&slack.User{
+	slackClient.EXPECT().GetUserByEmailContext(ctx, "foo@email").Return(&slack.User{
 		ID: "foo",
 	}, nil)
-	slackClient.EXPECT().GetUserByEmail("bar@email").Return(&slack.User{
+	slackClient.EXPECT().GetUserByEmailContext(ctx, "bar@email").Return(&slack.User{
 		ID: "bar",
 	}, nil)
-	slackClient.EXPECT().InviteUsersToConversation("test", "foo", "bar").Return(nil, nil)
+	slackClient.EXPECT().InviteUsersToConversationContext(ctx, "test", "foo", "bar").Return(nil, nil)
 
-	err := adapter.Add(context.TODO(), []string{"foo@email", "bar@email"})
+	err := adapter.Add(ctx, []string{"foo@email", "bar@email"})
 
 	require.NoError(t, err)
 }
@@ -154,11 +222,11 @@ func TestConversation_Remove(t *testing.T) {
 			client:           slackClient,
 			conversationName: "test",
 			cache:            map[string]string{"foo@email": "foo", "bar@email": "bar"},
-			Logger:           log.New(os.Stdout, "", log.LstdFlags),
+			Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		}
 
-		slackClient.EXPECT().KickUserFromConversation("test", "foo").Return(nil)
-		slackClient.EXPECT().KickUserFromConversation("test", "bar").Return(nil)
+		slackClient.EXPECT().KickUserFromConversationContext(ctx, "test", "foo").Return(nil)
+		slackClient.EXPECT().KickUserFromConversationContext(ctx, "test", "bar").Return(nil)
 
 		err := adapter.Remove(ctx, []string{"foo@email", "bar@email"})
 
@@ -175,18 +243,24 @@ func TestConversation_Remove(t *testing.T) {
 			client:           slackClient,
 			conversationName: "test",
 			cache:            map[string]string{"foo@email": "foo", "bar@email": "bar"},
-			Logger:           log.New(os.Stdout, "", log.LstdFlags),
+			Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		}
 
-		slackClient.EXPECT().KickUserFromConversation("test", "foo").Maybe().Return(restrictedAction)
-		slackClient.EXPECT().KickUserFromConversation("test", "bar").Maybe().Return(restrictedAction)
+		slackClient.EXPECT().KickUserFromConversationContext(ctx, "test", "foo").Maybe().Return(restrictedAction)
+		slackClient.EXPECT().KickUserFromConversationContext(ctx, "test", "bar").Maybe().Return(restrictedAction)
 
 		adapter.MuteRestrictedErrOnKickFromPublic = false
 
 		err := adapter.Remove(ctx, []string{"foo@email", "bar@email"})
 
+		var syncErr *gosync.Error
+
 		require.Error(t, err)
 		require.ErrorIs(t, err, restrictedAction)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, gosync.PhaseRemove, syncErr.Phase)
+		assert.Equal(t, "slack/conversation", syncErr.Kind)
+		assert.Len(t, syncErr.Things, 1)
 
 		adapter.MuteRestrictedErrOnKickFromPublic = true
 
@@ -203,16 +277,16 @@ func TestConversation_Remove(t *testing.T) {
 		adapter := &Conversation{
 			client:           slackClient,
 			conversationName: "test",
-			Logger:           log.New(os.Stdout, "", log.LstdFlags),
+			Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		}
 
-		slackClient.EXPECT().GetUsersInConversation(&slack.GetUsersInConversationParameters{
+		slackClient.EXPECT().GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
 			ChannelID: "test",
 			Cursor:    "",
 			Limit:     50,
 		}).Return([]string{"foo", "bar"}, "", nil)
 
-		slackClient.EXPECT().GetUsersInfo("foo", "bar").Return(&[]slack.User{
+		slackClient.EXPECT().GetUsersInfoContext(ctx, "foo", "bar").Return(&[]slack.User{
 			// Capitalise the letter E in email.
 			{ID: "foo", IsBot: false, Profile: slack.UserProfile{Email: "foo@Email"}},
 			{ID: "bar", IsBot: false, Profile: slack.UserProfile{Email: "bar@Email"}},
@@ -220,8 +294,8 @@ func TestConversation_Remove(t *testing.T) {
 
 		_, _ = adapter.Get(ctx)
 
-		slackClient.EXPECT().KickUserFromConversation("test", "foo").Return(nil)
-		slackClient.EXPECT().KickUserFromConversation("test", "bar").Return(nil)
+		slackClient.EXPECT().KickUserFromConversationContext(ctx, "test", "foo").Return(nil)
+		slackClient.EXPECT().KickUserFromConversationContext(ctx, "test", "bar").Return(nil)
 
 		// Capitalise the first letter of each email.
 		err := adapter.Remove(ctx, []string{"Foo@email", "Bar@email"})
@@ -230,6 +304,90 @@ func TestConversation_Remove(t *testing.T) {
 	})
 }
 
+func TestConversation_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	slackClient := newMockISlackConversation(t)
+
+	adapter := &Conversation{
+		client:           slackClient,
+		conversationName: "test",
+		cache:            map[string]string{"bar@email": "bar"},
+		Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	alreadyInChannel := errors.New("already_in_channel") //nolint:goerr113
+	notInChannel := errors.New("not_in_channel")         //nolint:goerr113
+
+	slackClient.EXPECT().GetUserByEmailContext(ctx, "foo@email").Return(&slack.User{ID: "foo"}, nil)
+	slackClient.EXPECT().GetUserByEmailContext(ctx, "new@email").Return(&slack.User{ID: "new"}, nil)
+	slackClient.EXPECT().InviteUsersToConversationContext(ctx, "test", "foo", "new").Return(nil, alreadyInChannel)
+	slackClient.EXPECT().InviteUsersToConversationContext(ctx, "test", "foo").Return(nil, alreadyInChannel)
+	slackClient.EXPECT().InviteUsersToConversationContext(ctx, "test", "new").Return(&slack.Channel{}, nil)
+	slackClient.EXPECT().KickUserFromConversationContext(ctx, "test", "bar").Return(notInChannel)
+
+	adaptertest.Idempotent(ctx, t, adapter, []string{"foo@email", "new@email"}, []string{"bar@email", "baz@email"})
+}
+
+func TestConversation_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	granted := func(scopes ...string) func(context.Context) ([]string, error) {
+		return func(context.Context) ([]string, error) {
+			return scopes, nil
+		}
+	}
+	params := &slack.GetUsersInConversationParameters{ChannelID: "test", Limit: 1}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		slackClient := newMockISlackConversation(t)
+		slackClient.EXPECT().GetUsersInConversationContext(ctx, params).Return([]string{"foo"}, "", nil)
+
+		adapter := &Conversation{client: slackClient, conversationName: "test", scopes: granted(requiredScopes...)}
+
+		require.NoError(t, adapter.Validate(ctx))
+	})
+
+	t.Run("Missing scopes", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := &Conversation{
+			client:           newMockISlackConversation(t),
+			conversationName: "test",
+			scopes:           granted("users:read", "users:read.email", "channels:read"),
+		}
+
+		err := adapter.Validate(ctx)
+
+		require.ErrorIs(t, err, gosync.ErrMissingPermission)
+		assert.ErrorContains(t, err, "channels:manage")
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+
+		slackClient := newMockISlackConversation(t)
+		slackClient.EXPECT().GetUsersInConversationContext(ctx, params).
+			Return(nil, "", errors.New("channel_not_found")) //nolint:goerr113
+
+		adapter := &Conversation{client: slackClient, conversationName: "test"}
+
+		err := adapter.Validate(ctx)
+
+		var syncErr *gosync.Error
+
+		require.ErrorIs(t, err, gosync.ErrNotFound)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, gosync.PhaseValidate, syncErr.Phase)
+	})
+}
+
 func TestInit(t *testing.T) {
 	t.Parallel()
 
@@ -277,7 +435,7 @@ func TestInit(t *testing.T) {
 		t.Run("MuteRestrictedErrOnKickFromPublic", func(t *testing.T) {
 			t.Parallel()
 
-			for _, test := range []string{"", "false", "FALSE", "False", "foobar", "test"} {
+			for _, test := range []string{"false", "FALSE", "False", "0"} {
 				adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 					SlackAPIKey:                       "test",
 					Name:                              "conversation",
@@ -298,13 +456,26 @@ func TestInit(t *testing.T) {
 				require.NoError(t, err)
 				assert.True(t, adapter.MuteRestrictedErrOnKickFromPublic, test)
 			}
+
+			for _, test := range []string{"", "foobar", "test"} {
+				_, err := Init(ctx, map[gosync.ConfigKey]string{
+					SlackAPIKey:                       "test",
+					Name:                              "conversation",
+					MuteRestrictedErrOnKickFromPublic: test,
+				})
+
+				require.ErrorIs(t, err, gosync.ErrInvalidConfig, test)
+				require.ErrorContains(t, err, MuteRestrictedErrOnKickFromPublic)
+			}
 		})
 	})
 
 	t.Run("with logger", func(t *testing.T) {
 		t.Parallel()
 
-		logger := log.New(os.Stderr, "custom logger", log.LstdFlags)
+		var buf bytes.Buffer
+
+		logger := log.New(&buf, "custom logger ", 0)
 
 		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 			SlackAPIKey: "test",
@@ -312,7 +483,27 @@ func TestInit(t *testing.T) {
 		}, WithLogger(logger))
 
 		require.NoError(t, err)
-		assert.Equal(t, logger, adapter.Logger)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "custom logger INFO test")
+	})
+
+	t.Run("with slog logger", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
+			SlackAPIKey: "test",
+			Name:        "conversation",
+		}, WithSlogLogger(logger))
+
+		require.NoError(t, err)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "msg=test")
 	})
 
 	t.Run("with client", func(t *testing.T) {
@@ -328,3 +519,16 @@ func TestInit(t *testing.T) {
 		assert.Equal(t, client, adapter.client)
 	})
 }
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("slack/conversation")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("slack/conversation")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
@@ -3,6 +3,7 @@ package conversation_test
 import (
 	"context"
 	"log"
+	"log/slog"
 	"os"
 
 	"github.com/slack-go/slack"
@@ -55,3 +56,19 @@ func ExampleWithLogger() {
 
 	gosync.New(adapter)
 }
+
+func ExampleWithSlogLogger() {
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	adapter, err := conversation.Init(ctx, map[gosync.ConfigKey]string{
+		conversation.SlackAPIKey: "my-slack-token",
+		conversation.Name:        "C0123ABC456",
+	}, conversation.WithSlogLogger(logger))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gosync.New(adapter)
+}
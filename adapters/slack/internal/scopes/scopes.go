@@ -0,0 +1,70 @@
+// Package scopes checks the OAuth scopes granted to a Slack token.
+package scopes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// Header is returned by the Slack API with a comma separated list of the scopes granted to a token.
+const Header = "X-OAuth-Scopes"
+
+// Fetcher returns the scopes granted to the token authenticating with Slack.
+type Fetcher func(ctx context.Context) ([]string, error)
+
+// New creates a Fetcher, which calls the auth.test endpoint of the Slack API at apiURL with a token.
+func New(client *http.Client, apiURL string, token string) Fetcher {
+	return func(ctx context.Context) ([]string, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL+"auth.test", nil)
+		if err != nil {
+			return nil, fmt.Errorf("scopes.newrequest -> %w", err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("scopes.authtest -> %w", err)
+		}
+		defer resp.Body.Close()
+
+		var body slack.SlackResponse
+
+		if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return nil, fmt.Errorf("scopes.decode -> %w", err)
+		}
+
+		if !body.Ok {
+			return nil, fmt.Errorf("scopes.authtest -> %w", slack.SlackErrorResponse{Err: body.Error})
+		}
+
+		granted := make([]string, 0)
+
+		for _, scope := range strings.Split(resp.Header.Get(Header), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				granted = append(granted, scope)
+			}
+		}
+
+		return granted, nil
+	}
+}
+
+// Missing returns the required scopes that haven't been granted.
+func Missing(granted []string, required ...string) []string {
+	missing := make([]string, 0)
+
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+
+	return missing
+}
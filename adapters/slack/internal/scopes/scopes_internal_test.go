@@ -0,0 +1,52 @@
+package scopes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/auth.test", req.URL.Path)
+
+		if req.Header.Get("Authorization") != "Bearer valid" {
+			_, _ = writer.Write([]byte(`{"ok": false, "error": "invalid_auth"}`))
+
+			return
+		}
+
+		writer.Header().Set(Header, "users:read, users:read.email,usergroups:read")
+		_, _ = writer.Write([]byte(`{"ok": true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	granted, err := New(server.Client(), server.URL+"/", "valid")(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users:read", "users:read.email", "usergroups:read"}, granted)
+
+	_, err = New(server.Client(), server.URL+"/", "invalid")(ctx)
+
+	require.ErrorContains(t, err, "invalid_auth")
+}
+
+func TestMissing(t *testing.T) {
+	t.Parallel()
+
+	granted := []string{"users:read", "usergroups:read"}
+
+	assert.Empty(t, Missing(granted, "users:read"))
+	assert.Equal(t, []string{"users:read.email", "usergroups:write"}, Missing(
+		granted,
+		"users:read", "users:read.email", "usergroups:read", "usergroups:write",
+	))
+}
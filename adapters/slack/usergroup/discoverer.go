@@ -0,0 +1,87 @@
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// Ensure [usergroup.Discoverer] fully satisfies the [gosync.Discoverer] interface.
+var _ gosync.Discoverer = &Discoverer{}
+
+// Discoverer finds Slack UserGroups whose handles match a selector, and initialises an adapter for each.
+type Discoverer struct {
+	client    iSlackUserGroup
+	config    map[gosync.ConfigKey]string
+	configFns []gosync.ConfigFn[*UserGroup]
+}
+
+/*
+NewDiscoverer creates a [gosync.Discoverer] for Slack UserGroups. It accepts the same config as [Init], except
+[usergroup.UserGroupID], which is set to the ID of each discovered UserGroup.
+
+	discoverer, err := usergroup.NewDiscoverer(ctx, map[gosync.ConfigKey]string{
+		usergroup.SlackAPIKey: "my-slack-token",
+	})
+
+	err = gosync.FanOut(ctx, gosync.Discovery{Discoverer: discoverer, Selector: "oncall-*", Source: source})
+*/
+func NewDiscoverer(
+	ctx context.Context,
+	config map[gosync.ConfigKey]string,
+	configFns ...gosync.ConfigFn[*UserGroup],
+) (*Discoverer, error) {
+	// Initialise an adapter without a UserGroup, to check the config and create the Slack client.
+	adapter, err := Init(ctx, withUserGroupID(config, ""), configFns...)
+	if err != nil {
+		return nil, fmt.Errorf("slack.usergroup.newdiscoverer -> %w", err)
+	}
+
+	return &Discoverer{client: adapter.client, config: config, configFns: configFns}, nil
+}
+
+/*
+Discover enabled Slack UserGroups whose handles match the selector, keyed by handle. See [gosync.MatchSelector].
+*/
+func (d *Discoverer) Discover(ctx context.Context, selector string) (map[string]gosync.Adapter, error) {
+	userGroups, err := d.client.GetUserGroupsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("slack.usergroup.discover.getusergroups -> %w", err)
+	}
+
+	out := make(map[string]gosync.Adapter)
+
+	for _, userGroup := range userGroups {
+		matched, err := gosync.MatchSelector(selector, userGroup.Handle)
+		if err != nil {
+			return nil, fmt.Errorf("slack.usergroup.discover -> %w", err)
+		}
+
+		if !matched {
+			continue
+		}
+
+		adapter, err := Init(ctx, withUserGroupID(d.config, userGroup.ID), d.configFns...)
+		if err != nil {
+			return nil, fmt.Errorf("slack.usergroup.discover(%s) -> %w", userGroup.Handle, err)
+		}
+
+		out[userGroup.Handle] = adapter
+	}
+
+	return out, nil
+}
+
+// withUserGroupID returns a copy of config with the UserGroup ID set.
+func withUserGroupID(config map[gosync.ConfigKey]string, userGroupID string) map[gosync.ConfigKey]string {
+	out := maps.Clone(config)
+	if out == nil {
+		out = make(map[gosync.ConfigKey]string)
+	}
+
+	out[UserGroupID] = userGroupID
+
+	return out
+}
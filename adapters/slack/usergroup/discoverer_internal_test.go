@@ -0,0 +1,76 @@
+//go:build !integration
+
+package usergroup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+func TestDiscoverer_Discover(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	newDiscoverer := func(t *testing.T) (*Discoverer, *mockISlackUserGroup) {
+		t.Helper()
+
+		slackClient := newMockISlackUserGroup(t)
+
+		discoverer, err := NewDiscoverer(ctx, map[gosync.ConfigKey]string{}, func(u *UserGroup) {
+			u.client = slackClient
+		})
+		require.NoError(t, err)
+
+		return discoverer, slackClient
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		discoverer, slackClient := newDiscoverer(t)
+
+		slackClient.EXPECT().GetUserGroupsContext(ctx).Return([]slack.UserGroup{
+			{ID: "S1", Handle: "oncall-foo"},
+			{ID: "S2", Handle: "oncall-bar"},
+			{ID: "S3", Handle: "team-foo"},
+		}, nil)
+
+		adapters, err := discoverer.Discover(ctx, "oncall-*")
+
+		require.NoError(t, err)
+		assert.Len(t, adapters, 2)
+		assert.Equal(t, "S1", adapters["oncall-foo"].(*UserGroup).userGroupID) //nolint:forcetypeassert
+		assert.Equal(t, "S2", adapters["oncall-bar"].(*UserGroup).userGroupID) //nolint:forcetypeassert
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		discoverer, slackClient := newDiscoverer(t)
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		slackClient.EXPECT().GetUserGroupsContext(ctx).Return(nil, testErr)
+
+		_, err := discoverer.Discover(ctx, "oncall-*")
+
+		require.ErrorIs(t, err, testErr)
+	})
+
+	t.Run("Missing config", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewDiscoverer(ctx, map[gosync.ConfigKey]string{})
+
+		require.ErrorIs(t, err, gosync.ErrMissingConfig)
+		require.ErrorContains(t, err, SlackAPIKey)
+	})
+}
@@ -140,6 +140,79 @@ func (_c *mockISlackUserGroup_GetUserGroupMembersContext_Call) RunAndReturn(run
 	return _c
 }
 
+// GetUserGroupsContext provides a mock function with given fields: ctx, options
+func (_m *mockISlackUserGroup) GetUserGroupsContext(ctx context.Context, options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserGroupsContext")
+	}
+
+	var r0 []slack.UserGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...slack.GetUserGroupsOption) ([]slack.UserGroup, error)); ok {
+		return rf(ctx, options...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...slack.GetUserGroupsOption) []slack.UserGroup); ok {
+		r0 = rf(ctx, options...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]slack.UserGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...slack.GetUserGroupsOption) error); ok {
+		r1 = rf(ctx, options...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockISlackUserGroup_GetUserGroupsContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserGroupsContext'
+type mockISlackUserGroup_GetUserGroupsContext_Call struct {
+	*mock.Call
+}
+
+// GetUserGroupsContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options ...slack.GetUserGroupsOption
+func (_e *mockISlackUserGroup_Expecter) GetUserGroupsContext(ctx interface{}, options ...interface{}) *mockISlackUserGroup_GetUserGroupsContext_Call {
+	return &mockISlackUserGroup_GetUserGroupsContext_Call{Call: _e.mock.On("GetUserGroupsContext",
+		append([]interface{}{ctx}, options...)...)}
+}
+
+func (_c *mockISlackUserGroup_GetUserGroupsContext_Call) Run(run func(ctx context.Context, options ...slack.GetUserGroupsOption)) *mockISlackUserGroup_GetUserGroupsContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]slack.GetUserGroupsOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(slack.GetUserGroupsOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockISlackUserGroup_GetUserGroupsContext_Call) Return(_a0 []slack.UserGroup, _a1 error) *mockISlackUserGroup_GetUserGroupsContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockISlackUserGroup_GetUserGroupsContext_Call) RunAndReturn(run func(context.Context, ...slack.GetUserGroupsOption) ([]slack.UserGroup, error)) *mockISlackUserGroup_GetUserGroupsContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUsersInfoContext provides a mock function with given fields: ctx, users
 func (_m *mockISlackUserGroup) GetUsersInfoContext(ctx context.Context, users ...string) (*[]slack.User, error) {
 	_va := make([]interface{}, len(users))
@@ -43,14 +43,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"math"
-	"os"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/slack-go/slack"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/slack/internal/scopes"
 )
 
 /*
@@ -67,23 +70,51 @@ const MuteGroupCannotBeEmpty gosync.ConfigKey = "mute_group_cannot_be_empty"
 var (
 	// Ensure [usergroup.UserGroup] fully satisfies the [gosync.Adapter] interface.
 	_ gosync.Adapter = &UserGroup{}
+	// Ensure [usergroup.UserGroup] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Describer = &UserGroup{}
+	// Ensure [usergroup.UserGroup] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Capable = &UserGroup{}
+	// Ensure [usergroup.UserGroup] fully satisfies the [gosync.Validator] interface.
+	_ gosync.Validator = &UserGroup{}
 	// Ensure the [usergroup.Init] function fully satisfies the [gosync.InitFn] type.
 	_ gosync.InitFn[*UserGroup] = Init
 )
 
+// Schema describes the configuration that [usergroup.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: SlackAPIKey, Secret: true, Description: "Slack API key. Not needed if a client is passed with WithClient."},
+	{Key: UserGroupID, Required: true, Description: "ID of the Slack UserGroup, such as S0123456789."},
+	{
+		Key:         MuteGroupCannotBeEmpty,
+		Type:        gosync.ConfigBool,
+		Description: "Mute the error when every user would be removed from the UserGroup.",
+	},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("slack/usergroup", Init)
+	gosync.RegisterSchema("slack/usergroup", Schema)
+}
+
+// requiredScopes are the OAuth scopes that the Slack token must be granted.
+var requiredScopes = []string{"users:read", "users:read.email", "usergroups:read", "usergroups:write"}
+
 // iSlackUserGroup is a subset of the Slack Client, and used to build mocks for easy testing.
 type iSlackUserGroup interface {
 	GetUserGroupMembersContext(ctx context.Context, userGroup string) ([]string, error)
 	GetUsersInfoContext(ctx context.Context, users ...string) (*[]slack.User, error)
 	GetUserByEmailContext(ctx context.Context, email string) (*slack.User, error)
 	UpdateUserGroupMembersContext(ctx context.Context, userGroup string, members string) (slack.UserGroup, error)
+	GetUserGroupsContext(ctx context.Context, options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error)
 }
 
 type UserGroup struct {
 	client      iSlackUserGroup
 	userGroupID string
 	cache       map[string]string
-	Logger      *log.Logger
+	scopes      scopes.Fetcher // scopes fetches the scopes granted to the Slack token, if it was set with Init.
+	Logger      *slog.Logger
 
 	MuteGroupCannotBeEmpty bool // See [usergroup.MuteGroupCannotBeEmpty]
 }
@@ -96,7 +127,7 @@ func (u *UserGroup) paginateUsersInfo(ctx context.Context, slackUsers ...string)
 	totalPages := math.Floor(float64(len(slackUsers)) / float64(pageSize))
 
 	for {
-		u.Logger.Printf("Calling GetUsersInfo page %v of %v", currentPage+1, totalPages+1)
+		u.Logger.Debug("Calling GetUsersInfo", slog.Int("page", currentPage+1), slog.Float64("pages", totalPages+1))
 
 		start := currentPage * pageSize
 		end := (currentPage * pageSize) + pageSize
@@ -131,7 +162,7 @@ func (u *UserGroup) paginateUsersInfo(ctx context.Context, slackUsers ...string)
 
 // Get email addresses in a Slack UserGroup.
 func (u *UserGroup) Get(ctx context.Context) ([]string, error) {
-	u.Logger.Printf("Fetching accounts from Slack UserGroup %s", u.userGroupID)
+	u.Logger.Info("Fetching accounts from Slack UserGroup")
 
 	// Initialise the cache.
 	u.cache = make(map[string]string)
@@ -139,13 +170,13 @@ func (u *UserGroup) Get(ctx context.Context) ([]string, error) {
 	// Retrieve a plain list of Slack IDs in the UserGroup.
 	groupMembers, err := u.client.GetUserGroupMembersContext(ctx, u.userGroupID)
 	if err != nil {
-		return nil, fmt.Errorf("slack.usergroup.get.getusergroupmembers -> %w", err)
+		return nil, gosync.NewError(u, gosync.PhaseGet, nil, fmt.Errorf("getusergroupmembers -> %w", err))
 	}
 
 	// Get the user info for each of the users.
 	users, err := u.paginateUsersInfo(ctx, groupMembers...)
 	if err != nil {
-		return nil, fmt.Errorf("slack.usergroup.get.getusersinfo -> %w", err)
+		return nil, gosync.NewError(u, gosync.PhaseGet, nil, fmt.Errorf("getusersinfo -> %w", err))
 	}
 
 	emails := make([]string, 0, len(*users))
@@ -155,17 +186,17 @@ func (u *UserGroup) Get(ctx context.Context) ([]string, error) {
 		u.cache[user.Profile.Email] = user.ID
 	}
 
-	u.Logger.Println("Fetched accounts successfully")
+	u.Logger.Info("Fetched accounts successfully", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	return emails, nil
 }
 
 // Add email addresses to a Slack UserGroup.
 func (u *UserGroup) Add(ctx context.Context, emails []string) error {
-	u.Logger.Printf("Adding %s to Slack UserGroup %s", emails, u.userGroupID)
+	u.Logger.Info("Adding accounts to Slack UserGroup", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	if u.cache == nil {
-		return fmt.Errorf("slack.usergroup.add -> %w", gosync.ErrCacheEmpty)
+		return gosync.NewError(u, gosync.PhaseAdd, emails, gosync.ErrCacheEmpty)
 	}
 
 	// The updatedUserGroup is existing users + new users.
@@ -182,11 +213,13 @@ func (u *UserGroup) Add(ctx context.Context, emails []string) error {
 	for _, email := range emails {
 		user, err := u.client.GetUserByEmailContext(ctx, email)
 		if err != nil {
-			return fmt.Errorf("slack.usergroup.add.getuserbyemail(%s) -> %w", email, err)
+			return gosync.NewError(u, gosync.PhaseAdd, []string{email}, fmt.Errorf("getuserbyemail -> %w", err))
 		}
 
 		_, ok := u.cache[email]
 		if !ok {
+			u.Logger.Debug("Adding account", slog.String(gosync.LogKeyThing, email))
+
 			// Add the new email user IDs to the list.
 			updatedUserGroup = append(updatedUserGroup, user.ID)
 
@@ -207,12 +240,12 @@ func (u *UserGroup) Add(ctx context.Context, emails []string) error {
 
 		_, err := u.client.UpdateUserGroupMembersContext(ctx, u.userGroupID, joinedSlackIds)
 		if err != nil {
-			return fmt.Errorf("slack.usergroup.add.updateusergroupmembers(%s) -> %w", u.userGroupID, err)
+			return gosync.NewError(u, gosync.PhaseAdd, emails, fmt.Errorf("updateusergroupmembers -> %w", err))
 		}
 
-		u.Logger.Println("Finished adding accounts successfully")
+		u.Logger.Info("Finished adding accounts successfully")
 	} else {
-		u.Logger.Println("No change to UserGroup")
+		u.Logger.Info("No change to UserGroup")
 	}
 
 	return nil
@@ -220,10 +253,10 @@ func (u *UserGroup) Add(ctx context.Context, emails []string) error {
 
 // Remove email addresses from a Slack UserGroup.
 func (u *UserGroup) Remove(ctx context.Context, emails []string) error {
-	u.Logger.Printf("Removing %s from Slack UserGroup %s", emails, u.userGroupID)
+	u.Logger.Info("Removing accounts from Slack UserGroup", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	if u.cache == nil {
-		return fmt.Errorf("slack.usergroup.remove -> %w", gosync.ErrCacheEmpty)
+		return gosync.NewError(u, gosync.PhaseRemove, emails, gosync.ErrCacheEmpty)
 	}
 
 	// Convert the list of email addresses into a map to efficiently lookup emails to remove.
@@ -245,6 +278,7 @@ func (u *UserGroup) Remove(ctx context.Context, emails []string) error {
 		if !mapOfEmailsToRemove[email] {
 			updatedUserGroup = append(updatedUserGroup, slackID)
 		} else {
+			u.Logger.Debug("Removing account", slog.String(gosync.LogKeyThing, email))
 			delete(u.cache, email)
 		}
 	}
@@ -255,15 +289,45 @@ func (u *UserGroup) Remove(ctx context.Context, emails []string) error {
 	_, err := u.client.UpdateUserGroupMembersContext(ctx, u.userGroupID, concatUserList)
 	if err != nil {
 		if strings.Contains(err.Error(), "invalid_arguments") && u.MuteGroupCannotBeEmpty {
-			u.Logger.Println("Cannot remove all members from usergroup, but error is muted by configuration - continuing")
+			u.Logger.Warn("Cannot remove all members from usergroup, but error is muted by configuration - continuing")
 
 			return nil
 		}
 
-		return fmt.Errorf("slack.usergroup.remove.updateusergroupmembers(%s, ...) -> %w", u.userGroupID, err)
+		return gosync.NewError(u, gosync.PhaseRemove, emails, fmt.Errorf("updateusergroupmembers -> %w", err))
 	}
 
-	u.Logger.Println("Finished removing accounts successfully")
+	u.Logger.Info("Finished removing accounts successfully")
+
+	return nil
+}
+
+/*
+Validate checks that the Slack token has been granted the required scopes, and that the UserGroup exists. Scopes are
+only checked if the Slack client was created by Init.
+*/
+func (u *UserGroup) Validate(ctx context.Context) error {
+	if u.scopes != nil {
+		granted, err := u.scopes(ctx)
+		if err != nil {
+			return gosync.NewError(u, gosync.PhaseValidate, nil, fmt.Errorf("scopes -> %w", err))
+		}
+
+		if missing := scopes.Missing(granted, requiredScopes...); len(missing) > 0 {
+			return gosync.NewError(u, gosync.PhaseValidate, nil, fmt.Errorf(
+				"scopes -> %w(%s)",
+				gosync.ErrMissingPermission,
+				strings.Join(missing, ", "),
+			))
+		}
+	}
+
+	_, err := u.client.GetUserGroupMembersContext(ctx, u.userGroupID)
+	if err != nil && strings.Contains(err.Error(), "no_such_subteam") {
+		return gosync.NewError(u, gosync.PhaseValidate, nil, fmt.Errorf("getusergroupmembers -> %w", gosync.ErrNotFound))
+	} else if err != nil {
+		return gosync.NewError(u, gosync.PhaseValidate, nil, fmt.Errorf("getusergroupmembers -> %w", err))
+	}
 
 	return nil
 }
@@ -275,8 +339,35 @@ func WithClient(client *slack.Client) gosync.ConfigFn[*UserGroup] {
 	}
 }
 
-// WithLogger passes a custom logger to the adapter.
+// Kind of adapter.
+func (u *UserGroup) Kind() string {
+	return "slack/usergroup"
+}
+
+// Target returns the Slack UserGroup ID.
+func (u *UserGroup) Target() string {
+	return u.userGroupID
+}
+
+// Capabilities of the adapter. The UserGroup's members are replaced using the cache populated by Get.
+func (u *UserGroup) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+		RequiresGet:    true,
+		ReplacesList:   true,
+	}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
 func WithLogger(logger *log.Logger) gosync.ConfigFn[*UserGroup] {
+	return func(u *UserGroup) {
+		u.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*UserGroup] {
 	return func(u *UserGroup) {
 		u.Logger = logger
 	}
@@ -293,40 +384,40 @@ func Init(
 	config map[gosync.ConfigKey]string,
 	configFns ...gosync.ConfigFn[*UserGroup],
 ) (*UserGroup, error) {
-	for _, key := range []gosync.ConfigKey{UserGroupID} {
-		if _, ok := config[key]; !ok {
-			return nil, fmt.Errorf("slack.conversation.init -> %w(%s)", gosync.ErrMissingConfig, key)
-		}
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("slack.usergroup.init -> %w", err)
 	}
 
 	adapter := &UserGroup{
-		userGroupID:            config[UserGroupID],
+		userGroupID:            parsed.String(UserGroupID),
 		cache:                  make(map[string]string),
 		MuteGroupCannotBeEmpty: false,
 	}
 
-	if _, ok := config[SlackAPIKey]; ok {
-		client := slack.New(config[SlackAPIKey])
+	if parsed.Has(SlackAPIKey) {
+		httpClient := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+		client := slack.New(parsed.String(SlackAPIKey), slack.OptionHTTPClient(httpClient))
 
 		WithClient(client)(adapter)
+
+		adapter.scopes = scopes.New(httpClient, slack.APIURL, parsed.String(SlackAPIKey))
 	}
 
 	for _, configFn := range configFns {
 		configFn(adapter)
 	}
 
-	if val, ok := config[MuteGroupCannotBeEmpty]; ok {
-		adapter.MuteGroupCannotBeEmpty = strings.ToLower(val) == "true"
+	if parsed.Has(MuteGroupCannotBeEmpty) {
+		adapter.MuteGroupCannotBeEmpty = parsed.Bool(MuteGroupCannotBeEmpty)
 	}
 
 	if adapter.Logger == nil {
-		logger := log.New(
-			os.Stderr, "[go-sync/slack/usergroup] ", log.LstdFlags|log.Lshortfile|log.Lmsgprefix,
-		)
-
-		WithLogger(logger)(adapter)
+		WithSlogLogger(slog.Default())(adapter)
 	}
 
+	parsed.LogUnknown(adapter.Logger)
+
 	if adapter.client == nil {
 		return nil, fmt.Errorf("user.init -> %w(%s)", gosync.ErrMissingConfig, SlackAPIKey)
 	}
@@ -3,9 +3,11 @@
 package usergroup
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"log"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
@@ -17,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adaptertest"
 )
 
 func TestUserGroup_Get(t *testing.T) {
@@ -29,7 +32,7 @@ func TestUserGroup_Get(t *testing.T) {
 	adapter := &UserGroup{
 		client:      slackClient,
 		userGroupID: "test",
-		Logger:      log.New(os.Stdout, "", log.LstdFlags),
+		Logger:      slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	slackClient.EXPECT().GetUserGroupMembersContext(ctx, "test").Return([]string{"foo", "bar"}, nil)
@@ -58,7 +61,7 @@ func TestUserGroup_Get_Pagination(t *testing.T) {
 	adapter := &UserGroup{
 		client:      slackClient,
 		userGroupID: "test",
-		Logger:      log.New(os.Stdout, "", log.LstdFlags),
+		Logger:      slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	incrementingSlice := make([]string, 60)
@@ -106,7 +109,7 @@ func TestUserGroup_Add(t *testing.T) {
 		adapter := &UserGroup{
 			client:      slackClient,
 			userGroupID: "test",
-			Logger:      log.New(os.Stdout, "", log.LstdFlags),
+			Logger:      slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		}
 
 		err := adapter.Add(ctx, []string{"foo", "bar"})
@@ -123,7 +126,7 @@ func TestUserGroup_Add(t *testing.T) {
 		adapter := &UserGroup{
 			client:      slackClient,
 			userGroupID: "test",
-			Logger:      log.New(os.Stdout, "", log.LstdFlags),
+			Logger:      slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		}
 
 		slackClient.EXPECT().GetUserByEmailContext(ctx, "fizz@email").Return(&slack.User{ID: "fizz"}, nil)
@@ -151,7 +154,7 @@ func TestUserGroup_Add(t *testing.T) {
 		adapter := &UserGroup{
 			client:      slackClient,
 			userGroupID: "test",
-			Logger:      log.New(os.Stdout, "", log.LstdFlags),
+			Logger:      slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		}
 
 		slackClient.EXPECT().GetUserByEmailContext(ctx, "foo@email").Return(&slack.User{ID: "foo"}, nil)
@@ -180,13 +183,20 @@ func TestUserGroup_Remove(t *testing.T) {
 		adapter := &UserGroup{
 			client:      slackClient,
 			userGroupID: "test",
-			Logger:      log.New(os.Stdout, "", log.LstdFlags),
+			Logger:      slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		}
 
 		err := adapter.Remove(ctx, []string{"foo@email"})
 
+		var syncErr *gosync.Error
+
 		require.Error(t, err)
 		require.ErrorIs(t, err, gosync.ErrCacheEmpty)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, gosync.PhaseRemove, syncErr.Phase)
+		assert.Equal(t, "slack/usergroup", syncErr.Kind)
+		assert.Equal(t, "test", syncErr.Target)
+		assert.Equal(t, []string{"foo@email"}, syncErr.Things)
 	})
 
 	t.Run("Remove accounts", func(t *testing.T) {
@@ -198,7 +208,7 @@ func TestUserGroup_Remove(t *testing.T) {
 			client:      slackClient,
 			userGroupID: "test",
 			cache:       map[string]string{"foo@email": "foo", "bar@email": "bar"},
-			Logger:      log.New(os.Stdout, "", log.LstdFlags),
+			Logger:      slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		}
 
 		slackClient.EXPECT().UpdateUserGroupMembersContext(ctx, "test", "foo").Return(slack.UserGroup{}, nil)
@@ -223,7 +233,7 @@ func TestUserGroup_Remove(t *testing.T) {
 			userGroupID:            "test",
 			cache:                  map[string]string{"foo@email": "foo"},
 			MuteGroupCannotBeEmpty: false,
-			Logger:                 log.New(os.Stdout, "", log.LstdFlags),
+			Logger:                 slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		}
 
 		slackClient.EXPECT().UpdateUserGroupMembersContext(ctx, "test", "").Return(slack.UserGroup{}, errInvalidArguments)
@@ -241,6 +251,84 @@ func TestUserGroup_Remove(t *testing.T) {
 	})
 }
 
+func TestUserGroup_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	slackClient := newMockISlackUserGroup(t)
+
+	adapter := &UserGroup{
+		client:      slackClient,
+		userGroupID: "test",
+		cache:       map[string]string{"foo@email": "foo"},
+		Logger:      slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	slackClient.EXPECT().GetUserByEmailContext(ctx, "foo@email").Return(&slack.User{ID: "foo"}, nil)
+	slackClient.EXPECT().UpdateUserGroupMembersContext(ctx, "test", "foo").Return(slack.UserGroup{}, nil)
+
+	adaptertest.Idempotent(ctx, t, adapter, []string{"foo@email"}, []string{"bar@email"})
+
+	assert.Equal(t, map[string]string{"foo@email": "foo"}, adapter.cache)
+}
+
+func TestUserGroup_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	granted := func(scopes ...string) func(context.Context) ([]string, error) {
+		return func(context.Context) ([]string, error) {
+			return scopes, nil
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		slackClient := newMockISlackUserGroup(t)
+		slackClient.EXPECT().GetUserGroupMembersContext(ctx, "test").Return([]string{"foo"}, nil)
+
+		adapter := &UserGroup{client: slackClient, userGroupID: "test", scopes: granted(requiredScopes...)}
+
+		require.NoError(t, adapter.Validate(ctx))
+	})
+
+	t.Run("Missing scopes", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := &UserGroup{
+			client:      newMockISlackUserGroup(t),
+			userGroupID: "test",
+			scopes:      granted("users:read", "usergroups:read"),
+		}
+
+		err := adapter.Validate(ctx)
+
+		require.ErrorIs(t, err, gosync.ErrMissingPermission)
+		assert.ErrorContains(t, err, "users:read.email, usergroups:write")
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+
+		slackClient := newMockISlackUserGroup(t)
+		slackClient.EXPECT().GetUserGroupMembersContext(ctx, "test").
+			Return(nil, errors.New("no_such_subteam")) //nolint:goerr113
+
+		adapter := &UserGroup{client: slackClient, userGroupID: "test"}
+
+		err := adapter.Validate(ctx)
+
+		var syncErr *gosync.Error
+
+		require.ErrorIs(t, err, gosync.ErrNotFound)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, gosync.PhaseValidate, syncErr.Phase)
+	})
+}
+
 func TestInit(t *testing.T) {
 	t.Parallel()
 
@@ -289,7 +377,7 @@ func TestInit(t *testing.T) {
 	t.Run("MuteRestrictedErrOnKickFromPublic", func(t *testing.T) {
 		t.Parallel()
 
-		for _, test := range []string{"", "false", "FALSE", "False", "foobar", "test"} {
+		for _, test := range []string{"false", "FALSE", "False", "0"} {
 			adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 				SlackAPIKey:            "test",
 				UserGroupID:            "usergroup",
@@ -310,12 +398,25 @@ func TestInit(t *testing.T) {
 			require.NoError(t, err)
 			assert.True(t, adapter.MuteGroupCannotBeEmpty, test)
 		}
+
+		for _, test := range []string{"", "foobar", "test"} {
+			_, err := Init(ctx, map[gosync.ConfigKey]string{
+				SlackAPIKey:            "test",
+				UserGroupID:            "usergroup",
+				MuteGroupCannotBeEmpty: test,
+			})
+
+			require.ErrorIs(t, err, gosync.ErrInvalidConfig, test)
+			require.ErrorContains(t, err, MuteGroupCannotBeEmpty)
+		}
 	})
 
 	t.Run("with logger", func(t *testing.T) {
 		t.Parallel()
 
-		logger := log.New(os.Stderr, "custom logger", log.LstdFlags)
+		var buf bytes.Buffer
+
+		logger := log.New(&buf, "custom logger ", 0)
 
 		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 			SlackAPIKey: "test",
@@ -323,7 +424,27 @@ func TestInit(t *testing.T) {
 		}, WithLogger(logger))
 
 		require.NoError(t, err)
-		assert.Equal(t, logger, adapter.Logger)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "custom logger INFO test")
+	})
+
+	t.Run("with slog logger", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
+			SlackAPIKey: "test",
+			UserGroupID: "usergroup",
+		}, WithSlogLogger(logger))
+
+		require.NoError(t, err)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "msg=test")
 	})
 
 	t.Run("with client", func(t *testing.T) {
@@ -339,3 +460,16 @@ func TestInit(t *testing.T) {
 		assert.Equal(t, client, adapter.client)
 	})
 }
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("slack/usergroup")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("slack/usergroup")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
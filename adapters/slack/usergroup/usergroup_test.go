@@ -5,6 +5,7 @@ package usergroup_test
 import (
 	"context"
 	"log"
+	"log/slog"
 	"os"
 
 	"github.com/slack-go/slack"
@@ -57,3 +58,19 @@ func ExampleWithLogger() {
 
 	gosync.New(adapter)
 }
+
+func ExampleWithSlogLogger() {
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	adapter, err := usergroup.Init(ctx, map[gosync.ConfigKey]string{
+		usergroup.SlackAPIKey: "my-slack-token",
+		usergroup.UserGroupID: "S0123ABC456",
+	}, usergroup.WithSlogLogger(logger))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gosync.New(adapter)
+}
@@ -0,0 +1,548 @@
+/*
+Package usergroups synchronises emails with many Slack UserGroups at once, keyed by their handles.
+
+Unlike the [usergroup] adapter, which synchronises a single UserGroup, this adapter is a [gosync.MultiAdapter] for use
+with [gosync.MultiSync]. Every UserGroup is fetched with a single call, and each Slack user is only looked up once,
+however many UserGroups they're a member of.
+
+# Managing UserGroups
+
+The adapter implements [gosync.GroupManager], so MultiSync can create UserGroups that are missing from Slack, and
+disable UserGroups that are no longer in the source. Slack doesn't allow UserGroups to be deleted, so disabled
+UserGroups with a matching handle are enabled rather than created again.
+
+To avoid changing UserGroups that aren't managed by Go Sync, set [usergroups.HandlePrefix], and only UserGroups whose
+handles start with the prefix will be synchronised.
+
+# Requirements
+
+In order to synchronise with Slack, you'll need to [create a Slack app] with the following OAuth Bot Token permissions:
+  - [users:read]
+  - [users:read.email]
+  - [usergroups:read]
+  - [usergroups:write]
+
+# Examples
+
+See [Init].
+
+[usergroup]: https://pkg.go.dev/github.com/ovotech/go-sync/adapters/slack/usergroup
+[create a Slack app]: https://api.slack.com/authentication/basics
+[users:read]: https://api.slack.com/scopes/users:read
+[users:read.email]: https://api.slack.com/scopes/users:read.email
+[usergroups:read]: https://api.slack.com/scopes/usergroups:read
+[usergroups:write]: https://api.slack.com/scopes/usergroups:write
+*/
+package usergroups
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/slack/internal/scopes"
+)
+
+/*
+SlackAPIKey is an API key for authenticating with Slack.
+*/
+const SlackAPIKey gosync.ConfigKey = "slack_api_key" //nolint:gosec
+
+// HandlePrefix limits the adapter to UserGroups whose handles start with the prefix (optional).
+const HandlePrefix gosync.ConfigKey = "handle_prefix"
+
+// MuteGroupCannotBeEmpty silences errors when removing all users from a UserGroup.
+const MuteGroupCannotBeEmpty gosync.ConfigKey = "mute_group_cannot_be_empty"
+
+var (
+	// Ensure [usergroups.UserGroups] fully satisfies the [gosync.MultiAdapter] interface.
+	_ gosync.MultiAdapter = &UserGroups{}
+	// Ensure [usergroups.UserGroups] fully satisfies the [gosync.GroupManager] interface.
+	_ gosync.GroupManager = &UserGroups{}
+	// Ensure [usergroups.UserGroups] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Describer = &UserGroups{}
+	// Ensure [usergroups.UserGroups] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Capable = &UserGroups{}
+	// Ensure [usergroups.UserGroups] fully satisfies the [gosync.Validator] interface.
+	_ gosync.Validator = &UserGroups{}
+	// Ensure the [usergroups.Init] function fully satisfies the [gosync.InitFn] type.
+	_ gosync.InitFn[*UserGroups] = Init
+)
+
+// Schema describes the configuration that [usergroups.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: SlackAPIKey, Secret: true, Description: "Slack API key. Not needed if a client is passed with WithClient."},
+	{Key: HandlePrefix, Description: "Only UserGroups whose handles start with this prefix are synchronised."},
+	{
+		Key:         MuteGroupCannotBeEmpty,
+		Type:        gosync.ConfigBool,
+		Description: "Mute the error when every user would be removed from a UserGroup.",
+	},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.LookupMulti].
+func init() { //nolint:gochecknoinits
+	gosync.RegisterMulti("slack/usergroups", Init)
+	gosync.RegisterSchema("slack/usergroups", Schema)
+}
+
+// requiredScopes are the OAuth scopes that the Slack token must be granted.
+var requiredScopes = []string{"users:read", "users:read.email", "usergroups:read", "usergroups:write"}
+
+// usersInfoPageSize is the number of Slack users requested in each call to GetUsersInfo.
+const usersInfoPageSize = 30
+
+// iSlackUserGroups is a subset of the Slack Client, and used to build mocks for easy testing.
+type iSlackUserGroups interface {
+	GetUserGroupsContext(ctx context.Context, options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error)
+	GetUsersInfoContext(ctx context.Context, users ...string) (*[]slack.User, error)
+	GetUserByEmailContext(ctx context.Context, email string) (*slack.User, error)
+	UpdateUserGroupMembersContext(ctx context.Context, userGroup string, members string) (slack.UserGroup, error)
+	CreateUserGroupContext(ctx context.Context, userGroup slack.UserGroup) (slack.UserGroup, error)
+	EnableUserGroupContext(ctx context.Context, userGroup string) (slack.UserGroup, error)
+	DisableUserGroupContext(ctx context.Context, userGroup string) (slack.UserGroup, error)
+}
+
+// userGroup is a UserGroup fetched from Slack.
+type userGroup struct {
+	id      string
+	members []string // members are the Slack IDs of users in the UserGroup.
+}
+
+type UserGroups struct {
+	client      iSlackUserGroups
+	prefix      string
+	groups      map[string]*userGroup // groups are enabled UserGroups, by handle.
+	disabled    map[string]string     // disabled are the IDs of disabled UserGroups, by handle.
+	emails      map[string]string     // emails are users' emails by Slack ID, shared between UserGroups.
+	ids         map[string]string     // ids are users' Slack IDs by email, shared between UserGroups.
+	lookupDelay time.Duration         // lookupDelay is the time to wait after each call to GetUserByEmail.
+	scopes      scopes.Fetcher        // scopes fetches the scopes granted to the Slack token, if it was set with Init.
+	Logger      *slog.Logger
+
+	MuteGroupCannotBeEmpty bool // See [usergroups.MuteGroupCannotBeEmpty]
+}
+
+// lookupUsers fetches the emails of Slack users that haven't already been looked up.
+func (u *UserGroups) lookupUsers(ctx context.Context, slackIDs []string) error {
+	unknown := make([]string, 0, len(slackIDs))
+	seen := make(map[string]bool, len(slackIDs))
+
+	for _, slackID := range slackIDs {
+		if _, ok := u.emails[slackID]; !ok && !seen[slackID] {
+			unknown = append(unknown, slackID)
+			seen[slackID] = true
+		}
+	}
+
+	for start := 0; start < len(unknown); start += usersInfoPageSize {
+		page := unknown[start:min(start+usersInfoPageSize, len(unknown))]
+
+		u.Logger.Debug("Calling GetUsersInfo", slog.Int(gosync.LogKeyCount, len(page)))
+
+		users, err := u.client.GetUsersInfoContext(ctx, page...)
+		if err != nil {
+			return fmt.Errorf("getusersinfo -> %w", err)
+		}
+
+		for _, user := range *users {
+			u.emails[user.ID] = user.Profile.Email
+			u.ids[user.Profile.Email] = user.ID
+		}
+	}
+
+	return nil
+}
+
+// lookupEmail returns the Slack ID of a user, only calling Slack if the user hasn't already been looked up.
+func (u *UserGroups) lookupEmail(ctx context.Context, email string) (string, error) {
+	if slackID, ok := u.ids[email]; ok {
+		return slackID, nil
+	}
+
+	user, err := u.client.GetUserByEmailContext(ctx, email)
+	if err != nil {
+		return "", fmt.Errorf("getuserbyemail(%s) -> %w", email, err)
+	}
+
+	u.emails[user.ID] = email
+	u.ids[email] = user.ID
+
+	// Calls to GetUserByEmail are heavily rate limited, so sleep to avoid this.
+	time.Sleep(u.lookupDelay)
+
+	return user.ID, nil
+}
+
+// Get email addresses in each Slack UserGroup, by handle.
+func (u *UserGroups) Get(ctx context.Context) (map[string][]string, error) {
+	u.Logger.Info("Fetching UserGroups from Slack")
+
+	userGroups, err := u.client.GetUserGroupsContext(ctx,
+		slack.GetUserGroupsOptionIncludeUsers(true),
+		slack.GetUserGroupsOptionIncludeDisabled(true),
+	)
+	if err != nil {
+		return nil, gosync.NewGroupError(u, "", gosync.PhaseGet, nil, fmt.Errorf("getusergroups -> %w", err))
+	}
+
+	u.groups = make(map[string]*userGroup)
+	u.disabled = make(map[string]string)
+
+	members := make([]string, 0)
+
+	for _, group := range userGroups {
+		if !strings.HasPrefix(group.Handle, u.prefix) {
+			continue
+		}
+
+		if group.DateDelete != 0 {
+			u.disabled[group.Handle] = group.ID
+
+			continue
+		}
+
+		u.groups[group.Handle] = &userGroup{id: group.ID, members: group.Users}
+		members = append(members, group.Users...)
+	}
+
+	// Users are only looked up once, however many UserGroups they're a member of.
+	if err = u.lookupUsers(ctx, members); err != nil {
+		return nil, gosync.NewGroupError(u, "", gosync.PhaseGet, nil, err)
+	}
+
+	out := make(map[string][]string, len(u.groups))
+
+	for handle, group := range u.groups {
+		out[handle] = make([]string, 0, len(group.members))
+
+		for _, slackID := range group.members {
+			if email := u.emails[slackID]; email != "" {
+				out[handle] = append(out[handle], email)
+			}
+		}
+	}
+
+	u.Logger.Info("Fetched UserGroups successfully", slog.Int(gosync.LogKeyCount, len(out)))
+
+	return out, nil
+}
+
+// group returns a UserGroup that has been fetched by Get.
+func (u *UserGroups) group(handle string) (*userGroup, error) {
+	if u.groups == nil {
+		return nil, gosync.ErrCacheEmpty
+	}
+
+	group, ok := u.groups[handle]
+	if !ok {
+		return nil, fmt.Errorf("%w(%s)", gosync.ErrNotFound, handle)
+	}
+
+	return group, nil
+}
+
+// update replaces the members of a UserGroup.
+func (u *UserGroups) update(ctx context.Context, group *userGroup, members []string) error {
+	_, err := u.client.UpdateUserGroupMembersContext(ctx, group.id, strings.Join(members, ","))
+	if err != nil {
+		return fmt.Errorf("updateusergroupmembers -> %w", err)
+	}
+
+	group.members = members
+
+	return nil
+}
+
+// Add email addresses to Slack UserGroups, by handle.
+func (u *UserGroups) Add(ctx context.Context, things map[string][]string) error {
+	for _, handle := range handles(things) {
+		u.Logger.Info("Adding accounts to Slack UserGroup",
+			slog.String("handle", handle),
+			slog.Int(gosync.LogKeyCount, len(things[handle])),
+		)
+
+		group, err := u.group(handle)
+		if err != nil {
+			return gosync.NewGroupError(u, handle, gosync.PhaseAdd, things[handle], err)
+		}
+
+		members := slices.Clone(group.members)
+
+		for _, email := range things[handle] {
+			slackID, err := u.lookupEmail(ctx, email)
+			if err != nil {
+				return gosync.NewGroupError(u, handle, gosync.PhaseAdd, []string{email}, err)
+			}
+
+			if !slices.Contains(members, slackID) {
+				u.Logger.Debug("Adding account", slog.String(gosync.LogKeyThing, email))
+
+				members = append(members, slackID)
+			}
+		}
+
+		if len(members) == len(group.members) {
+			u.Logger.Info("No change to UserGroup", slog.String("handle", handle))
+
+			continue
+		}
+
+		if err = u.update(ctx, group, members); err != nil {
+			return gosync.NewGroupError(u, handle, gosync.PhaseAdd, things[handle], err)
+		}
+	}
+
+	u.Logger.Info("Finished adding accounts successfully")
+
+	return nil
+}
+
+// Remove email addresses from Slack UserGroups, by handle.
+func (u *UserGroups) Remove(ctx context.Context, things map[string][]string) error {
+	for _, handle := range handles(things) {
+		u.Logger.Info("Removing accounts from Slack UserGroup",
+			slog.String("handle", handle),
+			slog.Int(gosync.LogKeyCount, len(things[handle])),
+		)
+
+		group, err := u.group(handle)
+		if err != nil {
+			return gosync.NewGroupError(u, handle, gosync.PhaseRemove, things[handle], err)
+		}
+
+		remove := make(map[string]bool, len(things[handle]))
+		for _, email := range things[handle] {
+			remove[email] = true
+		}
+
+		members := make([]string, 0, len(group.members))
+
+		for _, slackID := range group.members {
+			if email, ok := u.emails[slackID]; ok && remove[email] {
+				u.Logger.Debug("Removing account", slog.String(gosync.LogKeyThing, email))
+
+				continue
+			}
+
+			members = append(members, slackID)
+		}
+
+		err = u.update(ctx, group, members)
+		if err != nil && strings.Contains(err.Error(), "invalid_arguments") && u.MuteGroupCannotBeEmpty {
+			u.Logger.Warn("Cannot remove all members from usergroup, but error is muted by configuration - continuing",
+				slog.String("handle", handle),
+			)
+		} else if err != nil {
+			return gosync.NewGroupError(u, handle, gosync.PhaseRemove, things[handle], err)
+		}
+	}
+
+	u.Logger.Info("Finished removing accounts successfully")
+
+	return nil
+}
+
+// CreateGroups creates Slack UserGroups with the given handles, or enables them if they've been disabled.
+func (u *UserGroups) CreateGroups(ctx context.Context, groups []string) error {
+	if u.groups == nil {
+		return gosync.NewGroupError(u, "", gosync.PhaseCreate, groups, gosync.ErrCacheEmpty)
+	}
+
+	for _, handle := range groups {
+		if slackID, ok := u.disabled[handle]; ok {
+			u.Logger.Info("Enabling Slack UserGroup", slog.String("handle", handle))
+
+			if _, err := u.client.EnableUserGroupContext(ctx, slackID); err != nil {
+				return gosync.NewGroupError(u, "", gosync.PhaseCreate, []string{handle},
+					fmt.Errorf("enableusergroup -> %w", err))
+			}
+
+			// The UserGroup's previous members are replaced when things are added to it.
+			u.groups[handle] = &userGroup{id: slackID, members: nil}
+			delete(u.disabled, handle)
+
+			continue
+		}
+
+		u.Logger.Info("Creating Slack UserGroup", slog.String("handle", handle))
+
+		group, err := u.client.CreateUserGroupContext(ctx, slack.UserGroup{Name: handle, Handle: handle})
+		if err != nil {
+			return gosync.NewGroupError(u, "", gosync.PhaseCreate, []string{handle},
+				fmt.Errorf("createusergroup -> %w", err))
+		}
+
+		u.groups[handle] = &userGroup{id: group.ID, members: nil}
+	}
+
+	return nil
+}
+
+// DeleteGroups disables the Slack UserGroups with the given handles, as Slack doesn't allow them to be deleted.
+func (u *UserGroups) DeleteGroups(ctx context.Context, groups []string) error {
+	for _, handle := range groups {
+		group, err := u.group(handle)
+		if err != nil {
+			return gosync.NewGroupError(u, "", gosync.PhaseDelete, []string{handle}, err)
+		}
+
+		u.Logger.Info("Disabling Slack UserGroup", slog.String("handle", handle))
+
+		if _, err = u.client.DisableUserGroupContext(ctx, group.id); err != nil {
+			return gosync.NewGroupError(u, "", gosync.PhaseDelete, []string{handle},
+				fmt.Errorf("disableusergroup -> %w", err))
+		}
+
+		u.disabled[handle] = group.id
+		delete(u.groups, handle)
+	}
+
+	return nil
+}
+
+/*
+Validate checks that the Slack token has been granted the required scopes, and that UserGroups can be listed. Scopes
+are only checked if the Slack client was created by Init.
+*/
+func (u *UserGroups) Validate(ctx context.Context) error {
+	if u.scopes != nil {
+		granted, err := u.scopes(ctx)
+		if err != nil {
+			return gosync.NewGroupError(u, "", gosync.PhaseValidate, nil, fmt.Errorf("scopes -> %w", err))
+		}
+
+		if missing := scopes.Missing(granted, requiredScopes...); len(missing) > 0 {
+			return gosync.NewGroupError(u, "", gosync.PhaseValidate, nil, fmt.Errorf(
+				"scopes -> %w(%s)",
+				gosync.ErrMissingPermission,
+				strings.Join(missing, ", "),
+			))
+		}
+	}
+
+	if _, err := u.client.GetUserGroupsContext(ctx); err != nil {
+		return gosync.NewGroupError(u, "", gosync.PhaseValidate, nil, fmt.Errorf("getusergroups -> %w", err))
+	}
+
+	return nil
+}
+
+// handles returns the handles of UserGroups being changed in order.
+func handles(things map[string][]string) []string {
+	out := make([]string, 0, len(things))
+
+	for handle := range things {
+		out = append(out, handle)
+	}
+
+	slices.Sort(out)
+
+	return out
+}
+
+// WithClient passes a custom Slack client to the adapter.
+func WithClient(client *slack.Client) gosync.ConfigFn[*UserGroups] {
+	return func(u *UserGroups) {
+		u.client = client
+	}
+}
+
+// Kind of adapter.
+func (u *UserGroups) Kind() string {
+	return "slack/usergroups"
+}
+
+// Target returns the handle prefix of the UserGroups being synchronised.
+func (u *UserGroups) Target() string {
+	return u.prefix
+}
+
+// Capabilities of the adapter. UserGroups' members are replaced using the cache populated by Get.
+func (u *UserGroups) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+		RequiresGet:    true,
+		ReplacesList:   true,
+	}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
+func WithLogger(logger *log.Logger) gosync.ConfigFn[*UserGroups] {
+	return func(u *UserGroups) {
+		u.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*UserGroups] {
+	return func(u *UserGroups) {
+		u.Logger = logger
+	}
+}
+
+/*
+Init a new Slack UserGroups [gosync.MultiAdapter].
+
+Optional config:
+  - [usergroups.HandlePrefix]
+  - [usergroups.MuteGroupCannotBeEmpty]
+*/
+func Init(
+	_ context.Context,
+	config map[gosync.ConfigKey]string,
+	configFns ...gosync.ConfigFn[*UserGroups],
+) (*UserGroups, error) {
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("slack.usergroups.init -> %w", err)
+	}
+
+	adapter := &UserGroups{
+		prefix:                 parsed.String(HandlePrefix),
+		emails:                 make(map[string]string),
+		ids:                    make(map[string]string),
+		lookupDelay:            2 * time.Second, //nolint:gomnd,mnd
+		MuteGroupCannotBeEmpty: false,
+	}
+
+	if parsed.Has(SlackAPIKey) {
+		httpClient := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+		client := slack.New(parsed.String(SlackAPIKey), slack.OptionHTTPClient(httpClient))
+
+		WithClient(client)(adapter)
+
+		adapter.scopes = scopes.New(httpClient, slack.APIURL, parsed.String(SlackAPIKey))
+	}
+
+	for _, configFn := range configFns {
+		configFn(adapter)
+	}
+
+	if parsed.Has(MuteGroupCannotBeEmpty) {
+		adapter.MuteGroupCannotBeEmpty = parsed.Bool(MuteGroupCannotBeEmpty)
+	}
+
+	if adapter.Logger == nil {
+		WithSlogLogger(slog.Default())(adapter)
+	}
+
+	adapter.Logger = adapter.Logger.With(slog.String(gosync.LogKeyAdapter, adapter.Kind()))
+	parsed.LogUnknown(adapter.Logger)
+
+	if adapter.client == nil {
+		return nil, fmt.Errorf("slack.usergroups.init -> %w(%s)", gosync.ErrMissingConfig, SlackAPIKey)
+	}
+
+	return adapter, nil
+}
@@ -0,0 +1,325 @@
+//go:build !integration
+
+package usergroups
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+func newTestAdapter(t *testing.T) (*UserGroups, *mockISlackUserGroups) {
+	t.Helper()
+
+	slackClient := newMockISlackUserGroups(t)
+
+	return &UserGroups{
+		client: slackClient,
+		prefix: "team-",
+		emails: make(map[string]string),
+		ids:    make(map[string]string),
+		Logger: slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}, slackClient
+}
+
+// expectGet sets up the adapter's Get call with two UserGroups that share a member.
+func expectGet(ctx context.Context, slackClient *mockISlackUserGroups) {
+	slackClient.EXPECT().GetUserGroupsContext(ctx, mock.Anything, mock.Anything).Return([]slack.UserGroup{
+		{ID: "S1", Handle: "team-foo", Users: []string{"U1", "U2"}},
+		{ID: "S2", Handle: "team-bar", Users: []string{"U2", "U3"}},
+		{ID: "S3", Handle: "team-old", Users: []string{"U4"}, DateDelete: 1},
+		{ID: "S4", Handle: "other", Users: []string{"U5"}},
+	}, nil)
+	slackClient.EXPECT().GetUsersInfoContext(ctx, "U1", "U2", "U3").Return(&[]slack.User{
+		{ID: "U1", Profile: slack.UserProfile{Email: "foo@email"}},
+		{ID: "U2", Profile: slack.UserProfile{Email: "bar@email"}},
+		{ID: "U3", Profile: slack.UserProfile{Email: "baz@email"}},
+	}, nil)
+}
+
+func TestUserGroups_Get(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	adapter, slackClient := newTestAdapter(t)
+
+	expectGet(ctx, slackClient)
+
+	things, err := adapter.Get(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"team-foo": {"foo@email", "bar@email"},
+		"team-bar": {"bar@email", "baz@email"},
+	}, things)
+	assert.Equal(t, map[string]string{"team-old": "S3"}, adapter.disabled)
+}
+
+func TestUserGroups_Add(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Looks up each user once", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, slackClient := newTestAdapter(t)
+
+		expectGet(ctx, slackClient)
+		slackClient.EXPECT().GetUserByEmailContext(ctx, "qux@email").Once().Return(&slack.User{ID: "U6"}, nil)
+		slackClient.EXPECT().UpdateUserGroupMembersContext(ctx, "S1", "U1,U2,U6").Return(slack.UserGroup{}, nil)
+		slackClient.EXPECT().UpdateUserGroupMembersContext(ctx, "S2", "U2,U3,U6,U1").Return(slack.UserGroup{}, nil)
+
+		_, err := adapter.Get(ctx)
+		require.NoError(t, err)
+
+		err = adapter.Add(ctx, map[string][]string{
+			"team-foo": {"qux@email"},
+			"team-bar": {"qux@email", "foo@email"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"U1", "U2", "U6"}, adapter.groups["team-foo"].members)
+	})
+
+	t.Run("Missing cache", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, _ := newTestAdapter(t)
+
+		err := adapter.Add(ctx, map[string][]string{"team-foo": {"foo@email"}})
+
+		require.ErrorIs(t, err, gosync.ErrCacheEmpty)
+	})
+
+	t.Run("Unknown UserGroup", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, slackClient := newTestAdapter(t)
+
+		expectGet(ctx, slackClient)
+
+		_, err := adapter.Get(ctx)
+		require.NoError(t, err)
+
+		err = adapter.Add(ctx, map[string][]string{"team-baz": {"foo@email"}})
+
+		var syncErr *gosync.Error
+
+		require.ErrorIs(t, err, gosync.ErrNotFound)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, "slack/usergroups", syncErr.Kind)
+		assert.Equal(t, "team-baz", syncErr.Target)
+		assert.Equal(t, gosync.PhaseAdd, syncErr.Phase)
+		assert.Equal(t, []string{"foo@email"}, syncErr.Things)
+	})
+}
+
+func TestUserGroups_Remove(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Removes users", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, slackClient := newTestAdapter(t)
+
+		expectGet(ctx, slackClient)
+		slackClient.EXPECT().UpdateUserGroupMembersContext(ctx, "S1", "U1").Return(slack.UserGroup{}, nil)
+
+		_, err := adapter.Get(ctx)
+		require.NoError(t, err)
+
+		err = adapter.Remove(ctx, map[string][]string{"team-foo": {"bar@email"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"U2", "U3"}, adapter.groups["team-bar"].members)
+	})
+
+	t.Run("Mute group cannot be empty", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, slackClient := newTestAdapter(t)
+		adapter.MuteGroupCannotBeEmpty = true
+
+		expectGet(ctx, slackClient)
+		slackClient.EXPECT().UpdateUserGroupMembersContext(ctx, "S2", "").
+			Return(slack.UserGroup{}, errors.New("invalid_arguments")) //nolint:goerr113
+
+		_, err := adapter.Get(ctx)
+		require.NoError(t, err)
+
+		err = adapter.Remove(ctx, map[string][]string{"team-bar": {"bar@email", "baz@email"}})
+
+		require.NoError(t, err)
+	})
+}
+
+func TestUserGroups_CreateGroups(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	adapter, slackClient := newTestAdapter(t)
+
+	expectGet(ctx, slackClient)
+	slackClient.EXPECT().EnableUserGroupContext(ctx, "S3").Return(slack.UserGroup{}, nil)
+	slackClient.EXPECT().CreateUserGroupContext(ctx, slack.UserGroup{Name: "team-new", Handle: "team-new"}).
+		Return(slack.UserGroup{ID: "S5"}, nil)
+
+	_, err := adapter.Get(ctx)
+	require.NoError(t, err)
+
+	err = adapter.CreateGroups(ctx, []string{"team-old", "team-new"})
+
+	require.NoError(t, err)
+	assert.Equal(t, &userGroup{id: "S3"}, adapter.groups["team-old"])
+	assert.Equal(t, &userGroup{id: "S5"}, adapter.groups["team-new"])
+	assert.Empty(t, adapter.disabled)
+}
+
+func TestUserGroups_DeleteGroups(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	adapter, slackClient := newTestAdapter(t)
+
+	expectGet(ctx, slackClient)
+	slackClient.EXPECT().DisableUserGroupContext(ctx, "S1").Return(slack.UserGroup{}, nil)
+
+	_, err := adapter.Get(ctx)
+	require.NoError(t, err)
+
+	err = adapter.DeleteGroups(ctx, []string{"team-foo"})
+
+	require.NoError(t, err)
+	assert.NotContains(t, adapter.groups, "team-foo")
+	assert.Equal(t, "S1", adapter.disabled["team-foo"])
+}
+
+func TestUserGroups_MultiSync(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	adapter, slackClient := newTestAdapter(t)
+
+	expectGet(ctx, slackClient)
+	slackClient.EXPECT().GetUserByEmailContext(ctx, "qux@email").Once().Return(&slack.User{ID: "U6"}, nil)
+	slackClient.EXPECT().UpdateUserGroupMembersContext(ctx, "S1", "U1,U6").Return(slack.UserGroup{}, nil)
+	slackClient.EXPECT().UpdateUserGroupMembersContext(ctx, "S1", "U1").Return(slack.UserGroup{}, nil)
+	slackClient.EXPECT().UpdateUserGroupMembersContext(ctx, "S2", "U2,U3,U6").Return(slack.UserGroup{}, nil)
+
+	source := gosync.Multi(map[string]gosync.Adapter{
+		"team-foo": &staticAdapter{"foo@email", "qux@email"},
+		"team-bar": &staticAdapter{"bar@email", "baz@email", "qux@email"},
+	})
+
+	err := gosync.NewMulti(source).SyncWith(ctx, adapter)
+
+	require.NoError(t, err)
+}
+
+// staticAdapter is a source adapter with a fixed list of things.
+type staticAdapter []string
+
+func (s *staticAdapter) Get(_ context.Context) ([]string, error) {
+	return *s, nil
+}
+
+func (s *staticAdapter) Add(_ context.Context, _ []string) error {
+	return gosync.ErrReadOnly
+}
+
+func (s *staticAdapter) Remove(_ context.Context, _ []string) error {
+	return gosync.ErrReadOnly
+}
+
+func TestUserGroups_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, slackClient := newTestAdapter(t)
+		adapter.scopes = func(_ context.Context) ([]string, error) {
+			return requiredScopes, nil
+		}
+
+		slackClient.EXPECT().GetUserGroupsContext(ctx).Return(nil, nil)
+
+		require.NoError(t, adapter.Validate(ctx))
+	})
+
+	t.Run("Missing scopes", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, _ := newTestAdapter(t)
+		adapter.scopes = func(_ context.Context) ([]string, error) {
+			return []string{"users:read"}, nil
+		}
+
+		err := adapter.Validate(ctx)
+
+		require.ErrorIs(t, err, gosync.ErrMissingPermission)
+		assert.ErrorContains(t, err, "users:read.email, usergroups:read, usergroups:write")
+	})
+}
+
+func TestInit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
+			SlackAPIKey:            "test",
+			HandlePrefix:           "team-",
+			MuteGroupCannotBeEmpty: "true",
+		})
+
+		require.NoError(t, err)
+		assert.IsType(t, &UserGroups{}, adapter)
+		assert.Equal(t, "team-", adapter.prefix)
+		assert.True(t, adapter.MuteGroupCannotBeEmpty)
+		assert.Equal(t, "slack/usergroups", adapter.Kind())
+	})
+
+	t.Run("Missing config", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Init(ctx, map[gosync.ConfigKey]string{})
+
+		require.ErrorIs(t, err, gosync.ErrMissingConfig)
+		require.ErrorContains(t, err, SlackAPIKey)
+	})
+}
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.LookupMulti("slack/usergroups")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("slack/usergroups")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
@@ -0,0 +1,33 @@
+//go:build !integration
+
+package usergroups_test
+
+import (
+	"context"
+	"log"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/slack/usergroups"
+)
+
+func ExampleInit() {
+	ctx := context.Background()
+
+	adapter, err := usergroups.Init(ctx, map[gosync.ConfigKey]string{
+		usergroups.SlackAPIKey:  "my-slack-token",
+		usergroups.HandlePrefix: "team-",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source := gosync.Multi(map[string]gosync.Adapter{})
+
+	multiSync := gosync.NewMulti(source)
+	multiSync.CreateGroups = true
+
+	err = multiSync.SyncWith(ctx, adapter)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
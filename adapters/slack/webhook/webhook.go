@@ -0,0 +1,69 @@
+/*
+Package webhook sends summaries of syncs to a Slack channel with an [incoming webhook], so that people know when things
+have been changed, or a sync has failed.
+
+Webhook implements [gosync.Notifier], and can be passed to a [gosync.Runner], or wrapped in [gosync.Filter] to only
+notify about some destinations or changes. Messages are rendered from a [text/template], which is passed a
+[gosync.Summary]. If a Webhook isn't given a template, it uses [gosync.DefaultNotifyTemplate].
+
+# Requirements
+
+You will need to create a Slack app with an [incoming webhook] for the channel to notify.
+
+[incoming webhook]: https://api.slack.com/messaging/webhooks
+*/
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"text/template"
+
+	"github.com/slack-go/slack"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// Ensure [webhook.Webhook] fully satisfies the [gosync.Notifier] interface.
+var _ gosync.Notifier = &Webhook{}
+
+// Webhook posts a summary of each sync to a Slack incoming webhook.
+type Webhook struct {
+	URL      string             // URL of the incoming webhook.
+	Username string             // Username that messages are posted as. Default is the Slack app's name.
+	Template *template.Template // Template for each message. Default is gosync.DefaultNotifyTemplate.
+	Client   *http.Client       // Client used to post messages. Default is http.DefaultClient.
+}
+
+// New creates a new Slack Webhook notifier.
+func New(url string, optsFn ...func(*Webhook)) *Webhook {
+	webhook := &Webhook{
+		URL:    url,
+		Client: http.DefaultClient,
+	}
+
+	for _, fn := range optsFn {
+		fn(webhook)
+	}
+
+	return webhook
+}
+
+// Notify posts the summary to the Slack channel.
+func (w *Webhook) Notify(ctx context.Context, summary gosync.Summary) error {
+	text, err := gosync.RenderNotification(w.Template, summary)
+	if err != nil {
+		return fmt.Errorf("slack.webhook -> %w", err)
+	}
+
+	err = slack.PostWebhookCustomHTTPContext(ctx, w.URL, w.Client, &slack.WebhookMessage{
+		Username: w.Username,
+		Text:     text,
+	})
+	if err != nil {
+		return fmt.Errorf("slack.webhook.postwebhook -> %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+func TestWebhook_Notify(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	summary := gosync.Summary{
+		Job: "oncall",
+		Destinations: []gosync.DestinationReport{{
+			Adapter: "slack/usergroup",
+			Target:  "S123",
+			Changes: []gosync.Change{{Operation: gosync.PhaseAdd, Count: 1, Things: []string{"foo@example.com"}}},
+		}},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		var message slack.WebhookMessage
+
+		server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+			assert.NoError(t, json.NewDecoder(request.Body).Decode(&message))
+		}))
+		defer server.Close()
+
+		webhook := New(server.URL, func(w *Webhook) {
+			w.Username = "Go Sync"
+		})
+
+		require.NoError(t, webhook.Notify(ctx, summary))
+		assert.Equal(t, "Go Sync", message.Username)
+		assert.Equal(t, "Go Sync job oncall finished\n\nslack/usergroup(S123)\n  add: foo@example.com\n", message.Text)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			writer.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		require.Error(t, New(server.URL).Notify(ctx, summary))
+	})
+}
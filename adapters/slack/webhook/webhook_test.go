@@ -0,0 +1,27 @@
+package webhook_test
+
+import (
+	"context"
+	"log"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/slack/webhook"
+)
+
+func ExampleNew() {
+	ctx := context.Background()
+
+	// Only notify the channel when people are added to or removed from the platform team.
+	notifier := gosync.Filter(webhook.New("https://hooks.slack.com/services/T000/B000/XXXX"), gosync.NotifyFilter{
+		Destinations: []string{"github/team(platform)"},
+		Operations:   []gosync.Phase{gosync.PhaseAdd, gosync.PhaseRemove},
+	})
+
+	runner := gosync.NewRunner([]gosync.Job{}, func(r *gosync.Runner) {
+		r.Notifiers = []gosync.Notifier{notifier}
+	})
+
+	if err := runner.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -14,11 +14,15 @@ package membership
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"net/http"
+	"strings"
 
 	"github.com/hashicorp/go-tfe"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	gosync "github.com/ovotech/go-sync"
 )
@@ -31,9 +35,25 @@ const Organisation gosync.ConfigKey = "terraform_cloud_organisation"
 
 var (
 	_ gosync.Adapter             = &Membership{} // Ensure [team.Team] fully satisfies the [gosync.Adapter] interface.
+	_ gosync.Describer           = &Membership{} // Ensure [team.Team] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Capable             = &Membership{} // Ensure [team.Team] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Validator           = &Membership{} // Ensure [team.Team] fully satisfies the [gosync.Validator] interface.
+	_ gosync.Streamer            = &Membership{} // Ensure [team.Team] fully satisfies the [gosync.Streamer] interface.
 	_ gosync.InitFn[*Membership] = Init          // Ensure [team.Init] fully satisfies the [gosync.InitFn] type.
 )
 
+// Schema describes the configuration that [membership.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: Token, Secret: true, Description: "Terraform Cloud API token, unless a client is passed with WithClient."},
+	{Key: Organisation, Required: true, Description: "Name of the organisation whose members are synchronised."},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("terraformcloud/membership", Init)
+	gosync.RegisterSchema("terraformcloud/membership", Schema)
+}
+
 // iOrganizationMemberships is a subset of Terraform Enterprise
 // OrganizationMemberships, and used to build mocks for easy testing.
 type iOrganizationMemberships interface {
@@ -45,10 +65,15 @@ type iOrganizationMemberships interface {
 	Delete(ctx context.Context, organizationMembershipID string) error
 }
 
+// isAlreadyTaken returns true if Terraform Cloud rejected a request because the resource already exists.
+func isAlreadyTaken(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "has already been taken")
+}
+
 type Membership struct {
 	organisation            string
 	organizationMemberships iOrganizationMemberships
-	Logger                  *log.Logger
+	Logger                  *slog.Logger
 }
 
 // getOrgIDsFromEmails takes a slice of emails, and returns a slice of Organisational Membership IDs.
@@ -56,7 +81,7 @@ func (m *Membership) getOrgIDsFromEmails(ctx context.Context, emails []string) (
 	pageNumber := 1
 	ids := make([]string, 0, len(emails))
 
-	m.Logger.Printf("Fetching IDs from Terraform Cloud organisation %s", m.organisation)
+	m.Logger.Debug("Fetching IDs from Terraform Cloud organisation")
 
 	for {
 		users, err := m.organizationMemberships.List(ctx, m.organisation, &tfe.OrganizationMembershipListOptions{
@@ -67,7 +92,7 @@ func (m *Membership) getOrgIDsFromEmails(ctx context.Context, emails []string) (
 			return nil, fmt.Errorf("terraformcloud.membership.getOrgIDsFromEmails(%s).list -> %w", emails, err)
 		}
 
-		m.Logger.Printf("Fetching page %v in %v", users.CurrentPage, users.TotalPages)
+		m.Logger.Debug("Fetching page", slog.Int("page", users.CurrentPage), slog.Int("pages", users.TotalPages))
 
 		for _, user := range users.Items {
 			ids = append(ids, user.ID)
@@ -80,17 +105,22 @@ func (m *Membership) getOrgIDsFromEmails(ctx context.Context, emails []string) (
 		}
 	}
 
-	m.Logger.Println("Finished fetching users")
+	m.Logger.Debug("Finished fetching users")
 
 	return ids, nil
 }
 
 // Get memberships in a Terraform Cloud organisation.
 func (m *Membership) Get(ctx context.Context) ([]string, error) {
+	return gosync.Collect(ctx, m) //nolint:wrapcheck
+}
+
+// Stream memberships in a Terraform Cloud organisation, a page at a time.
+func (m *Membership) Stream(ctx context.Context, fn func(emails []string) error) error {
 	pageNumber := 1
-	memberships := make([]string, 0)
+	count := 0
 
-	m.Logger.Printf("Fetching members in Terraform Cloud organisation %s", m.organisation)
+	m.Logger.Info("Fetching members in Terraform Cloud organisation")
 
 	for {
 		listOptions := &tfe.OrganizationMembershipListOptions{
@@ -101,68 +131,105 @@ func (m *Membership) Get(ctx context.Context) ([]string, error) {
 
 		tfeMemberships, err := m.organizationMemberships.List(ctx, m.organisation, listOptions)
 		if err != nil {
-			return nil, fmt.Errorf("terraformcloud.membership.get.list(%s) -> %w", m.organisation, err)
+			return gosync.NewError(m, gosync.PhaseGet, nil, fmt.Errorf("list -> %w", err))
 		}
 
+		memberships := make([]string, 0, len(tfeMemberships.Items))
+
 		for _, membership := range tfeMemberships.Items {
 			memberships = append(memberships, membership.Email)
 		}
 
+		if err = fn(memberships); err != nil {
+			return gosync.NewError(m, gosync.PhaseGet, nil, fmt.Errorf("stream -> %w", err))
+		}
+
+		count += len(memberships)
 		pageNumber = tfeMemberships.NextPage
 
 		if tfeMemberships.CurrentPage >= tfeMemberships.TotalPages {
 			break
 		}
 
-		m.Logger.Printf("Fetching page %v in %v", tfeMemberships.CurrentPage, tfeMemberships.TotalPages)
+		m.Logger.Debug("Fetching page",
+			slog.Int("page", tfeMemberships.CurrentPage),
+			slog.Int("pages", tfeMemberships.TotalPages),
+		)
 	}
 
-	m.Logger.Println("Fetched memberships successfully")
+	m.Logger.Info("Fetched memberships successfully", slog.Int(gosync.LogKeyCount, count))
 
-	return memberships, nil
+	return nil
 }
 
 // Add members to a Terraform Cloud organisation.
 func (m *Membership) Add(ctx context.Context, emails []string) error {
-	m.Logger.Printf("Adding %s to Terraform Cloud organisation %s", emails, m.organisation)
+	m.Logger.Info("Adding members to Terraform Cloud organisation", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	for _, email := range emails {
+		m.Logger.Debug("Adding member", slog.String(gosync.LogKeyThing, email))
+
 		options := tfe.OrganizationMembershipCreateOptions{
 			Email: &email,
 			Type:  "organization-memberships",
 		}
 
 		_, err := m.organizationMemberships.Create(ctx, m.organisation, options)
-		if err != nil {
-			return fmt.Errorf("terraformcloud.membership.add(%s).create(%s) -> %w", emails, email, err)
+		if isAlreadyTaken(err) {
+			m.Logger.Debug("Member already exists, skipping", slog.String(gosync.LogKeyThing, email))
+
+			continue
+		} else if err != nil {
+			return gosync.NewError(m, gosync.PhaseAdd, []string{email}, fmt.Errorf("create -> %w", err))
 		}
 	}
 
-	m.Logger.Println("Finished adding members successfully")
+	m.Logger.Info("Finished adding members successfully")
 
 	return nil
 }
 
 // Remove members from the Terraform Cloud organisation.
 func (m *Membership) Remove(ctx context.Context, emails []string) error {
-	m.Logger.Printf("Removing %s from Terraform Cloud organisation %s", emails, m.organisation)
+	m.Logger.Info("Removing members from Terraform Cloud organisation", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	ids, err := m.getOrgIDsFromEmails(ctx, emails)
 	if err != nil {
-		return fmt.Errorf(
-			"terraformcloud.membership.remove(%s).getorgidsfromemails -> %w",
-			emails, err,
-		)
+		return gosync.NewError(m, gosync.PhaseRemove, emails, fmt.Errorf("getorgidsfromemails -> %w", err))
 	}
 
 	for _, id := range ids {
+		m.Logger.Debug("Removing member", slog.String("id", id))
+
 		err = m.organizationMemberships.Delete(ctx, id)
-		if err != nil {
-			return fmt.Errorf("terraformcloud.membership.remove(%s).delete(%s) -> %w", emails, id, err)
+		if errors.Is(err, tfe.ErrResourceNotFound) {
+			m.Logger.Debug("Member doesn't exist, skipping", slog.String("id", id))
+
+			continue
+		} else if err != nil {
+			return gosync.NewError(m, gosync.PhaseRemove, emails, fmt.Errorf("delete(%s) -> %w", id, err))
 		}
 	}
 
-	m.Logger.Println("Finished removing members successfully")
+	m.Logger.Info("Finished removing members successfully")
+
+	return nil
+}
+
+// Validate checks that the Terraform Cloud organisation exists, and that the token is allowed to list its members.
+func (m *Membership) Validate(ctx context.Context) error {
+	_, err := m.organizationMemberships.List(ctx, m.organisation, &tfe.OrganizationMembershipListOptions{
+		ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 1},
+	})
+
+	switch {
+	case errors.Is(err, tfe.ErrResourceNotFound):
+		return gosync.NewError(m, gosync.PhaseValidate, nil, fmt.Errorf("list -> %w", gosync.ErrNotFound))
+	case errors.Is(err, tfe.ErrUnauthorized):
+		return gosync.NewError(m, gosync.PhaseValidate, nil, fmt.Errorf("list -> %w", gosync.ErrMissingPermission))
+	case err != nil:
+		return gosync.NewError(m, gosync.PhaseValidate, nil, fmt.Errorf("list -> %w", err))
+	}
 
 	return nil
 }
@@ -174,8 +241,33 @@ func WithClient(client *tfe.Client) gosync.ConfigFn[*Membership] {
 	}
 }
 
-// WithLogger passes a custom logger to the adapter.
+// Kind of adapter.
+func (m *Membership) Kind() string {
+	return "terraformcloud/membership"
+}
+
+// Target returns the Terraform Cloud organisation.
+func (m *Membership) Target() string {
+	return m.organisation
+}
+
+// Capabilities of the adapter.
+func (m *Membership) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+	}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
 func WithLogger(logger *log.Logger) gosync.ConfigFn[*Membership] {
+	return func(u *Membership) {
+		u.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*Membership] {
 	return func(u *Membership) {
 		u.Logger = logger
 	}
@@ -193,18 +285,20 @@ func Init(
 	config map[gosync.ConfigKey]string,
 	configFns ...gosync.ConfigFn[*Membership],
 ) (*Membership, error) {
-	for _, key := range []gosync.ConfigKey{Organisation} {
-		if _, ok := config[key]; !ok {
-			return nil, fmt.Errorf("terraformcloud.membership.init -> %w(%s)", gosync.ErrMissingConfig, key)
-		}
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("terraformcloud.membership.init -> %w", err)
 	}
 
 	adapter := &Membership{
-		organisation: config[Organisation],
+		organisation: parsed.String(Organisation),
 	}
 
-	if _, ok := config[Token]; ok {
-		client, err := tfe.NewClient(&tfe.Config{Token: config[Token]})
+	if parsed.Has(Token) {
+		client, err := tfe.NewClient(&tfe.Config{
+			Token:      parsed.String(Token),
+			HTTPClient: &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
+		})
 		if err != nil {
 			return nil, fmt.Errorf("user.init.newclient -> %w", err)
 		}
@@ -217,12 +311,10 @@ func Init(
 	}
 
 	if adapter.Logger == nil {
-		logger := log.New(
-			os.Stderr, "[go-sync/terraformcloud/membership] ", log.LstdFlags|log.Lshortfile|log.Lmsgprefix,
-		)
-
-		WithLogger(logger)(adapter)
+		WithSlogLogger(slog.Default())(adapter)
 	}
 
+	parsed.LogUnknown(adapter.Logger)
+
 	return adapter, nil
 }
@@ -2,7 +2,9 @@ package membership
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"os"
 	"testing"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adaptertest"
 )
 
 func TestNew(t *testing.T) {
@@ -26,7 +29,7 @@ func TestMembership_Get(t *testing.T) {
 	adapter := &Membership{
 		organisation:            "org",
 		organizationMemberships: memberships,
-		Logger:                  log.New(os.Stdout, "", log.LstdFlags),
+		Logger:                  slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	memberships.EXPECT().List(ctx, "org", &tfe.OrganizationMembershipListOptions{
@@ -68,6 +71,39 @@ func TestMembership_Get(t *testing.T) {
 	})
 }
 
+func TestMembership_Stream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	memberships := newMockIOrganizationMemberships(t)
+
+	adapter := &Membership{
+		organisation:            "org",
+		organizationMemberships: memberships,
+		Logger:                  slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	for page := 1; page <= 2; page++ {
+		memberships.EXPECT().List(ctx, "org", &tfe.OrganizationMembershipListOptions{
+			ListOptions: tfe.ListOptions{PageNumber: page},
+		}).Return(&tfe.OrganizationMembershipList{
+			Pagination: &tfe.Pagination{CurrentPage: page, NextPage: page + 1, TotalPages: 2},
+			Items:      []*tfe.OrganizationMembership{{Email: fmt.Sprintf("page%d@email", page)}},
+		}, nil)
+	}
+
+	var pages [][]string
+
+	err := adapter.Stream(ctx, func(emails []string) error {
+		pages = append(pages, emails)
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"page1@email"}, {"page2@email"}}, pages)
+}
+
 func TestMembership_Add(t *testing.T) {
 	t.Parallel()
 
@@ -77,7 +113,7 @@ func TestMembership_Add(t *testing.T) {
 	adapter := &Membership{
 		organisation:            "org",
 		organizationMemberships: memberships,
-		Logger:                  log.New(os.Stdout, "", log.LstdFlags),
+		Logger:                  slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	memberships.EXPECT().Create(ctx, "org", tfe.OrganizationMembershipCreateOptions{
@@ -102,7 +138,7 @@ func TestMembership_Remove(t *testing.T) {
 	adapter := &Membership{
 		organisation:            "org",
 		organizationMemberships: memberships,
-		Logger:                  log.New(os.Stdout, "", log.LstdFlags),
+		Logger:                  slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	memberships.EXPECT().List(ctx, "org", &tfe.OrganizationMembershipListOptions{
@@ -126,6 +162,84 @@ func TestMembership_Remove(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMembership_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	memberships := newMockIOrganizationMemberships(t)
+
+	adapter := &Membership{
+		organisation:            "org",
+		organizationMemberships: memberships,
+		Logger:                  slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	memberships.EXPECT().Create(ctx, "org", tfe.OrganizationMembershipCreateOptions{
+		Email: tfe.String("foo@email"),
+		Type:  "organization-memberships",
+	}).Return(nil, errors.New("invalid attribute\n\nEmail has already been taken")) //nolint:goerr113
+
+	memberships.EXPECT().List(ctx, "org", &tfe.OrganizationMembershipListOptions{
+		ListOptions: tfe.ListOptions{PageNumber: 1},
+		Emails:      []string{"bar@email", "baz@email"},
+	}).Return(&tfe.OrganizationMembershipList{
+		Pagination: &tfe.Pagination{
+			CurrentPage: 1,
+			NextPage:    1,
+			TotalPages:  1,
+		},
+		Items: []*tfe.OrganizationMembership{
+			{Email: "bar@email", ID: "bar-id"},
+		},
+	}, nil)
+
+	memberships.EXPECT().Delete(ctx, "bar-id").Return(tfe.ErrResourceNotFound)
+
+	adaptertest.Idempotent(ctx, t, adapter, []string{"foo@email"}, []string{"bar@email", "baz@email"})
+}
+
+func TestMembership_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	tests := map[string]struct {
+		err  error
+		want error
+	}{
+		"Success":      {},
+		"Not found":    {err: tfe.ErrResourceNotFound, want: gosync.ErrNotFound},
+		"Unauthorised": {err: tfe.ErrUnauthorized, want: gosync.ErrMissingPermission},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mockMemberships := newMockIOrganizationMemberships(t)
+			mockMemberships.EXPECT().List(ctx, "org", &tfe.OrganizationMembershipListOptions{
+				ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 1},
+			}).Return(&tfe.OrganizationMembershipList{}, test.err)
+
+			adapter := &Membership{organisation: "org", organizationMemberships: mockMemberships}
+
+			err := adapter.Validate(ctx)
+
+			if test.want == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var syncErr *gosync.Error
+
+			require.ErrorIs(t, err, test.want)
+			require.ErrorAs(t, err, &syncErr)
+			assert.Equal(t, gosync.PhaseValidate, syncErr.Phase)
+		})
+	}
+}
+
 func TestInit(t *testing.T) {
 	t.Parallel()
 
@@ -154,3 +268,16 @@ func TestInit(t *testing.T) {
 		require.ErrorContains(t, err, Organisation)
 	})
 }
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("terraformcloud/membership")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("terraformcloud/membership")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
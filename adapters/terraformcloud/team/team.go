@@ -14,11 +14,15 @@ package team
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"net/http"
+	"strings"
 
 	"github.com/hashicorp/go-tfe"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	gosync "github.com/ovotech/go-sync"
 )
@@ -31,9 +35,24 @@ const Organisation gosync.ConfigKey = "terraform_cloud_organisation"
 
 var (
 	_ gosync.Adapter       = &Team{} // Ensure [team.Team] fully satisfies the [gosync.Adapter] interface.
+	_ gosync.Describer     = &Team{} // Ensure [team.Team] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Capable       = &Team{} // Ensure [team.Team] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Validator     = &Team{} // Ensure [team.Team] fully satisfies the [gosync.Validator] interface.
 	_ gosync.InitFn[*Team] = Init    // Ensure [team.Init] fully satisfies the [gosync.InitFn] type.
 )
 
+// Schema describes the configuration that [team.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: Token, Secret: true, Description: "Terraform Cloud API token, unless a client is passed with WithClient."},
+	{Key: Organisation, Required: true, Description: "Name of the organisation whose teams are synchronised."},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("terraformcloud/team", Init)
+	gosync.RegisterSchema("terraformcloud/team", Schema)
+}
+
 // iTeams is a subset of Terraform Enterprise Teams, and used to build mocks for easy testing.
 type iTeams interface {
 	List(ctx context.Context, organization string, options *tfe.TeamListOptions) (*tfe.TeamList, error)
@@ -41,33 +60,38 @@ type iTeams interface {
 	Delete(ctx context.Context, teamID string) error
 }
 
+// isAlreadyTaken returns true if Terraform Cloud rejected a request because the resource already exists.
+func isAlreadyTaken(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "has already been taken")
+}
+
 type Team struct {
 	organisation string
 	teams        iTeams
 	cache        map[string]string // Cache maps team names to IDs in case they're to be removed.
-	Logger       *log.Logger
+	Logger       *slog.Logger
 }
 
 // Get teams in a Terraform Cloud organisation.
 func (t *Team) Get(ctx context.Context) ([]string, error) {
-	t.Logger.Printf("Fetching teams in Terraform Cloud organisation %s", t.organisation)
+	t.Logger.Info("Fetching teams in Terraform Cloud organisation")
 
 	pageNumber := 1
 	teams := make([]string, 0)
 
 	t.cache = make(map[string]string)
 
-	t.Logger.Printf("Fetching first page")
+	t.Logger.Debug("Fetching first page")
 
 	for {
 		tfeTeams, err := t.teams.List(ctx, t.organisation, &tfe.TeamListOptions{
 			ListOptions: tfe.ListOptions{PageNumber: pageNumber},
 		})
 		if err != nil {
-			return nil, fmt.Errorf("teams.list(%s) -> %w", t.organisation, err)
+			return nil, gosync.NewError(t, gosync.PhaseGet, nil, fmt.Errorf("list -> %w", err))
 		}
 
-		t.Logger.Printf("Fetched page %v in %v", tfeTeams.CurrentPage, tfeTeams.TotalPages)
+		t.Logger.Debug("Fetched page", slog.Int("page", tfeTeams.CurrentPage), slog.Int("pages", tfeTeams.TotalPages))
 
 		for _, team := range tfeTeams.Items {
 			teams = append(teams, team.Name)
@@ -81,39 +105,76 @@ func (t *Team) Get(ctx context.Context) ([]string, error) {
 		}
 	}
 
-	t.Logger.Println("Fetched teams successfully")
+	t.Logger.Info("Fetched teams successfully", slog.Int(gosync.LogKeyCount, len(teams)))
 
 	return teams, nil
 }
 
 // Add teams to a Terraform Cloud organisation.
 func (t *Team) Add(ctx context.Context, teams []string) error {
-	t.Logger.Printf("Adding %s to Terraform Cloud organisation %s", teams, t.organisation)
+	t.Logger.Info("Adding teams to Terraform Cloud organisation", slog.Int(gosync.LogKeyCount, len(teams)))
 
 	for _, team := range teams {
+		t.Logger.Debug("Adding team", slog.String(gosync.LogKeyThing, team))
+
 		_, err := t.teams.Create(ctx, t.organisation, tfe.TeamCreateOptions{Name: &team})
-		if err != nil {
-			return fmt.Errorf("terraformcloud.team.add -> %w", err)
+		if isAlreadyTaken(err) {
+			t.Logger.Debug("Team already exists, skipping", slog.String(gosync.LogKeyThing, team))
+
+			continue
+		} else if err != nil {
+			return gosync.NewError(t, gosync.PhaseAdd, []string{team}, fmt.Errorf("create -> %w", err))
 		}
 	}
 
-	t.Logger.Println("Finished adding teams successfully")
+	t.Logger.Info("Finished adding teams successfully")
 
 	return nil
 }
 
 // Remove teams from a Terraform Cloud organisation.
 func (t *Team) Remove(ctx context.Context, teams []string) error {
-	t.Logger.Printf("Removing %s from Terraform Cloud organisation %s", teams, t.organisation)
+	t.Logger.Info("Removing teams from Terraform Cloud organisation", slog.Int(gosync.LogKeyCount, len(teams)))
 
 	for _, team := range teams {
-		err := t.teams.Delete(ctx, t.cache[team])
-		if err != nil {
-			return fmt.Errorf("terraformcloud.team.remove -> %w", err)
+		t.Logger.Debug("Removing team", slog.String(gosync.LogKeyThing, team))
+
+		id, ok := t.cache[team]
+		if !ok {
+			t.Logger.Debug("Team doesn't exist, skipping", slog.String(gosync.LogKeyThing, team))
+
+			continue
+		}
+
+		err := t.teams.Delete(ctx, id)
+		if errors.Is(err, tfe.ErrResourceNotFound) {
+			t.Logger.Debug("Team doesn't exist, skipping", slog.String(gosync.LogKeyThing, team))
+
+			continue
+		} else if err != nil {
+			return gosync.NewError(t, gosync.PhaseRemove, []string{team}, fmt.Errorf("delete -> %w", err))
 		}
 	}
 
-	t.Logger.Println("Finished removing teams successfully")
+	t.Logger.Info("Finished removing teams successfully")
+
+	return nil
+}
+
+// Validate checks that the Terraform Cloud organisation exists, and that the token is allowed to list its teams.
+func (t *Team) Validate(ctx context.Context) error {
+	_, err := t.teams.List(ctx, t.organisation, &tfe.TeamListOptions{
+		ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 1},
+	})
+
+	switch {
+	case errors.Is(err, tfe.ErrResourceNotFound):
+		return gosync.NewError(t, gosync.PhaseValidate, nil, fmt.Errorf("list -> %w", gosync.ErrNotFound))
+	case errors.Is(err, tfe.ErrUnauthorized):
+		return gosync.NewError(t, gosync.PhaseValidate, nil, fmt.Errorf("list -> %w", gosync.ErrMissingPermission))
+	case err != nil:
+		return gosync.NewError(t, gosync.PhaseValidate, nil, fmt.Errorf("list -> %w", err))
+	}
 
 	return nil
 }
@@ -125,8 +186,34 @@ func WithClient(client *tfe.Client) gosync.ConfigFn[*Team] {
 	}
 }
 
-// WithLogger passes a custom logger to the adapter.
+// Kind of adapter.
+func (t *Team) Kind() string {
+	return "terraformcloud/team"
+}
+
+// Target returns the Terraform Cloud organisation.
+func (t *Team) Target() string {
+	return t.organisation
+}
+
+// Capabilities of the adapter. Remove uses the cache populated by Get.
+func (t *Team) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+		RequiresGet:    true,
+	}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
 func WithLogger(logger *log.Logger) gosync.ConfigFn[*Team] {
+	return func(t *Team) {
+		t.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*Team] {
 	return func(t *Team) {
 		t.Logger = logger
 	}
@@ -139,19 +226,21 @@ Required config:
   - [team.Organisation]
 */
 func Init(_ context.Context, config map[gosync.ConfigKey]string, configFns ...gosync.ConfigFn[*Team]) (*Team, error) {
-	for _, key := range []gosync.ConfigKey{Organisation} {
-		if _, ok := config[key]; !ok {
-			return nil, fmt.Errorf("team.init -> %w(%s)", gosync.ErrMissingConfig, key)
-		}
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("team.init -> %w", err)
 	}
 
 	adapter := &Team{
-		organisation: config[Organisation],
+		organisation: parsed.String(Organisation),
 		cache:        make(map[string]string),
 	}
 
-	if _, ok := config[Token]; ok {
-		client, err := tfe.NewClient(&tfe.Config{Token: config[Token]})
+	if parsed.Has(Token) {
+		client, err := tfe.NewClient(&tfe.Config{
+			Token:      parsed.String(Token),
+			HTTPClient: &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
+		})
 		if err != nil {
 			return nil, fmt.Errorf("team.init.newclient -> %w", err)
 		}
@@ -164,13 +253,11 @@ func Init(_ context.Context, config map[gosync.ConfigKey]string, configFns ...go
 	}
 
 	if adapter.Logger == nil {
-		logger := log.New(
-			os.Stderr, "[go-sync/terraformcloud/team] ", log.LstdFlags|log.Lshortfile|log.Lmsgprefix,
-		)
-
-		WithLogger(logger)(adapter)
+		WithSlogLogger(slog.Default())(adapter)
 	}
 
+	parsed.LogUnknown(adapter.Logger)
+
 	if adapter.teams == nil {
 		return nil, fmt.Errorf("team.init -> %w(%s)", gosync.ErrMissingConfig, Token)
 	}
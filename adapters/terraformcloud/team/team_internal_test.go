@@ -1,8 +1,11 @@
 package team
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"log"
+	"log/slog"
 	"os"
 	"testing"
 
@@ -11,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adaptertest"
 )
 
 func TestTeam_Get(t *testing.T) {
@@ -23,7 +27,7 @@ func TestTeam_Get(t *testing.T) {
 	adapter := &Team{
 		organisation: "test",
 		teams:        iTeamsClient,
-		Logger:       log.New(os.Stdout, "", log.LstdFlags),
+		Logger:       slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	iTeamsClient.EXPECT().List(ctx, "test", &tfe.TeamListOptions{
@@ -64,7 +68,7 @@ func TestTeam_Add(t *testing.T) {
 	adapter := &Team{
 		organisation: "test",
 		teams:        iTeamsClient,
-		Logger:       log.New(os.Stdout, "", log.LstdFlags),
+		Logger:       slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	foo := "foo"
@@ -87,7 +91,7 @@ func TestTeam_Remove(t *testing.T) {
 		organisation: "test",
 		teams:        iTeamsClient,
 		cache:        map[string]string{"foo": "foo-id"},
-		Logger:       log.New(os.Stdout, "", log.LstdFlags),
+		Logger:       slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	iTeamsClient.EXPECT().Delete(ctx, "foo-id").Return(nil)
@@ -97,6 +101,100 @@ func TestTeam_Remove(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestTeam_Remove_Error(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	testErr := errors.New("foo") //nolint:goerr113
+
+	iTeamsClient := newMockITeams(t)
+
+	adapter := &Team{
+		organisation: "test",
+		teams:        iTeamsClient,
+		cache:        map[string]string{"foo": "foo-id"},
+		Logger:       slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	iTeamsClient.EXPECT().Delete(ctx, "foo-id").Return(testErr)
+
+	err := adapter.Remove(ctx, []string{"foo"})
+
+	var syncErr *gosync.Error
+
+	require.ErrorIs(t, err, testErr)
+	require.ErrorAs(t, err, &syncErr)
+	assert.Equal(t, gosync.PhaseRemove, syncErr.Phase)
+	assert.Equal(t, []string{"foo"}, syncErr.Things)
+}
+
+func TestTeam_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	iTeamsClient := newMockITeams(t)
+
+	adapter := &Team{
+		organisation: "test",
+		teams:        iTeamsClient,
+		cache:        map[string]string{"bar": "bar-id"},
+		Logger:       slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	foo := "foo"
+
+	iTeamsClient.EXPECT().Create(ctx, "test", tfe.TeamCreateOptions{Name: &foo}).Return(
+		nil,
+		errors.New("invalid attribute\n\nName has already been taken"), //nolint:goerr113
+	)
+	iTeamsClient.EXPECT().Delete(ctx, "bar-id").Return(tfe.ErrResourceNotFound)
+
+	adaptertest.Idempotent(ctx, t, adapter, []string{"foo"}, []string{"bar", "baz"})
+}
+
+func TestTeam_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	tests := map[string]struct {
+		err  error
+		want error
+	}{
+		"Success":      {},
+		"Not found":    {err: tfe.ErrResourceNotFound, want: gosync.ErrNotFound},
+		"Unauthorised": {err: tfe.ErrUnauthorized, want: gosync.ErrMissingPermission},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mockTeams := newMockITeams(t)
+			mockTeams.EXPECT().List(ctx, "org", &tfe.TeamListOptions{
+				ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 1},
+			}).Return(&tfe.TeamList{}, test.err)
+
+			adapter := &Team{organisation: "org", teams: mockTeams}
+
+			err := adapter.Validate(ctx)
+
+			if test.want == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var syncErr *gosync.Error
+
+			require.ErrorIs(t, err, test.want)
+			require.ErrorAs(t, err, &syncErr)
+			assert.Equal(t, gosync.PhaseValidate, syncErr.Phase)
+		})
+	}
+}
+
 func TestInit(t *testing.T) {
 	t.Parallel()
 
@@ -132,7 +230,9 @@ func TestInit(t *testing.T) {
 	t.Run("with logger", func(t *testing.T) {
 		t.Parallel()
 
-		logger := log.New(os.Stderr, "custom logger", log.LstdFlags)
+		var buf bytes.Buffer
+
+		logger := log.New(&buf, "custom logger ", 0)
 
 		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 			Token:        "token",
@@ -140,7 +240,27 @@ func TestInit(t *testing.T) {
 		}, WithLogger(logger))
 
 		require.NoError(t, err)
-		assert.Equal(t, logger, adapter.Logger)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "custom logger INFO test")
+	})
+
+	t.Run("with slog logger", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
+			Token:        "token",
+			Organisation: "org",
+		}, WithSlogLogger(logger))
+
+		require.NoError(t, err)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "msg=test")
 	})
 
 	t.Run("with client", func(t *testing.T) {
@@ -157,3 +277,16 @@ func TestInit(t *testing.T) {
 		assert.Equal(t, client.Teams, adapter.teams)
 	})
 }
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("terraformcloud/team")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("terraformcloud/team")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
@@ -3,6 +3,7 @@ package team_test
 import (
 	"context"
 	"log"
+	"log/slog"
 	"os"
 
 	"github.com/hashicorp/go-tfe"
@@ -58,3 +59,19 @@ func ExampleWithLogger() {
 
 	gosync.New(adapter)
 }
+
+func ExampleWithSlogLogger() {
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	adapter, err := team.Init(ctx, map[gosync.ConfigKey]string{
+		team.Token:        "my-org-token",
+		team.Organisation: "ovotech",
+	}, team.WithSlogLogger(logger))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gosync.New(adapter)
+}
@@ -17,9 +17,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
+	"net/http"
 
 	"github.com/hashicorp/go-tfe"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	gosync "github.com/ovotech/go-sync"
 )
@@ -35,9 +37,25 @@ const Team gosync.ConfigKey = "team"
 
 var (
 	_ gosync.Adapter       = &User{} // Ensure [User.User] fully satisfies the [gosync.Adapter] interface.
+	_ gosync.Describer     = &User{} // Ensure [User.User] fully satisfies the [gosync.Describer] interface.
+	_ gosync.Capable       = &User{} // Ensure [User.User] fully satisfies the [gosync.Capable] interface.
+	_ gosync.Validator     = &User{} // Ensure [User.User] fully satisfies the [gosync.Validator] interface.
 	_ gosync.InitFn[*User] = Init    // Ensure [user.Init] fully satisfies the [gosync.InitFn] type.
 )
 
+// Schema describes the configuration that [user.Init] accepts.
+var Schema = gosync.ConfigSchema{ //nolint:gochecknoglobals
+	{Key: Token, Secret: true, Description: "Terraform Cloud API token, unless a client is passed with WithClient."},
+	{Key: Organisation, Required: true, Description: "Name of the Terraform Cloud organisation."},
+	{Key: Team, Required: true, Description: "Name of the team whose members are synchronised."},
+}
+
+// Register the adapter, so that it can be initialised by name with [gosync.Lookup].
+func init() { //nolint:gochecknoinits
+	gosync.Register("terraformcloud/user", Init)
+	gosync.RegisterSchema("terraformcloud/user", Schema)
+}
+
 // ErrTeamNotFound is returned if the team cannot be found in the Terraform Cloud organisation.
 var ErrTeamNotFound = errors.New("team_not_found")
 
@@ -67,12 +85,12 @@ type User struct {
 	teams                   iTeams
 	teamMembers             iTeamMembers
 	organizationMemberships iOrganizationMemberships
-	Logger                  *log.Logger
+	Logger                  *slog.Logger
 }
 
 // getTeamID queries the Terraform Cloud API to convert a friendly team name into a team ID.
 func (u *User) getTeamID(ctx context.Context) (string, error) {
-	u.Logger.Printf("Querying Terraform Cloud organisation %s for team ID of %s", u.organisation, u.team)
+	u.Logger.Debug("Querying Terraform Cloud organisation for team ID")
 
 	teams, err := u.teams.List(ctx, u.organisation, &tfe.TeamListOptions{Names: []string{u.team}})
 	if err != nil {
@@ -83,7 +101,7 @@ func (u *User) getTeamID(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("terraformcloud.user.get(%s, %s) -> %w", u.organisation, u.team, ErrTeamNotFound)
 	}
 
-	u.Logger.Println("Successfully queried team ID")
+	u.Logger.Debug("Successfully queried team ID")
 
 	return teams.Items[0].ID, nil
 }
@@ -93,7 +111,7 @@ func (u *User) getOrgIDsFromEmails(ctx context.Context, emails []string) ([]stri
 	pageNumber := 1
 	ids := make([]string, 0, len(emails))
 
-	u.Logger.Printf("Fetching IDs from Terraform Cloud organisation %s", u.organisation)
+	u.Logger.Debug("Fetching IDs from Terraform Cloud organisation")
 
 	for {
 		users, err := u.organizationMemberships.List(ctx, u.organisation, &tfe.OrganizationMembershipListOptions{
@@ -104,9 +122,11 @@ func (u *User) getOrgIDsFromEmails(ctx context.Context, emails []string) ([]stri
 			return nil, fmt.Errorf("organizationmembership.list(%s, %s) -> %w", u.organisation, u.team, err)
 		}
 
-		u.Logger.Printf("Fetching page %v in %v", users.CurrentPage, users.TotalPages)
+		u.Logger.Debug("Fetching page", slog.Int("page", users.CurrentPage), slog.Int("pages", users.TotalPages))
 
 		for _, user := range users.Items {
+			u.Logger.Debug("Found user", slog.String(gosync.LogKeyThing, user.Email), slog.String("id", user.ID))
+
 			ids = append(ids, user.ID)
 		}
 
@@ -117,25 +137,50 @@ func (u *User) getOrgIDsFromEmails(ctx context.Context, emails []string) ([]stri
 		}
 	}
 
-	u.Logger.Println("Finished fetching users")
+	u.Logger.Debug("Finished fetching users")
 
 	return ids, nil
 }
 
+/*
+removeMembers removes Organisational Memberships from a team. Terraform Cloud rejects the whole request if any of them
+aren't members of the team, so each membership is then removed on its own and non-members are skipped.
+*/
+func (u *User) removeMembers(ctx context.Context, teamID string, ids []string) error {
+	err := u.teamMembers.Remove(ctx, teamID, tfe.TeamMemberRemoveOptions{OrganizationMembershipIDs: ids})
+	if !errors.Is(err, tfe.ErrResourceNotFound) {
+		return err //nolint:wrapcheck
+	}
+
+	if len(ids) == 1 {
+		u.Logger.Debug("User isn't a member, skipping", slog.String("id", ids[0]))
+
+		return nil
+	}
+
+	for _, id := range ids {
+		if err := u.removeMembers(ctx, teamID, []string{id}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Get users in a Terraform Cloud team.
 func (u *User) Get(ctx context.Context) ([]string, error) {
-	u.Logger.Printf("Fetching users in Terraform Cloud team %s", u.team)
+	u.Logger.Info("Fetching users in Terraform Cloud team")
 
 	team, err := u.teams.List(ctx, u.organisation, &tfe.TeamListOptions{
 		Include: []tfe.TeamIncludeOpt{tfe.TeamOrganizationMemberships},
 		Names:   []string{u.team},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("terraformcloud.user.get(%s).list(%s) -> %w", u.organisation, u.team, err)
+		return nil, gosync.NewError(u, gosync.PhaseGet, nil, fmt.Errorf("list -> %w", err))
 	}
 
 	if len(team.Items) != 1 {
-		return nil, fmt.Errorf("terraformcloud.user.get(%s).list(%s) -> %w", u.organisation, u.team, ErrTeamNotFound)
+		return nil, gosync.NewError(u, gosync.PhaseGet, nil, fmt.Errorf("list -> %w", ErrTeamNotFound))
 	}
 
 	emails := make([]string, 0, len(team.Items[0].OrganizationMemberships))
@@ -144,65 +189,75 @@ func (u *User) Get(ctx context.Context) ([]string, error) {
 		emails = append(emails, organisationMembership.Email)
 	}
 
-	u.Logger.Println("Fetched teams successfully")
+	u.Logger.Info("Fetched users successfully", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	return emails, nil
 }
 
 // Add users to a Terraform Cloud team.
 func (u *User) Add(ctx context.Context, emails []string) error {
-	u.Logger.Printf("Adding %s to Terraform Cloud team %s", emails, u.team)
+	u.Logger.Info("Adding users to Terraform Cloud team", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	ids, err := u.getOrgIDsFromEmails(ctx, emails)
 	if err != nil {
-		return fmt.Errorf(
-			"terraformcloud.user.add(%s, %s).getorgidsfromemails(%s) -> %w",
-			u.organisation, u.team, emails, err,
-		)
+		return gosync.NewError(u, gosync.PhaseAdd, emails, fmt.Errorf("getorgidsfromemails -> %w", err))
 	}
 
 	teamID, err := u.getTeamID(ctx)
 	if err != nil {
-		return fmt.Errorf("terraformcloud.user.add(%s, %s).getteamid -> %w", u.organisation, u.team, err)
+		return gosync.NewError(u, gosync.PhaseAdd, emails, fmt.Errorf("getteamid -> %w", err))
 	}
 
 	if len(ids) > 0 {
 		err = u.teamMembers.Add(ctx, teamID, tfe.TeamMemberAddOptions{OrganizationMembershipIDs: ids})
 		if err != nil {
-			return fmt.Errorf("terraformcloud.user.add(%s, %s).add(%s) -> %w", u.organisation, u.team, emails, err)
+			return gosync.NewError(u, gosync.PhaseAdd, emails, fmt.Errorf("add -> %w", err))
 		}
 	}
 
-	u.Logger.Println("Finished adding users successfully")
+	u.Logger.Info("Finished adding users successfully")
 
 	return nil
 }
 
 // Remove users from a Terraform Cloud team.
 func (u *User) Remove(ctx context.Context, emails []string) error {
-	u.Logger.Printf("Removing %s from Terraform Cloud team %s", emails, u.team)
+	u.Logger.Info("Removing users from Terraform Cloud team", slog.Int(gosync.LogKeyCount, len(emails)))
 
 	ids, err := u.getOrgIDsFromEmails(ctx, emails)
 	if err != nil {
-		return fmt.Errorf(
-			"terraformcloud.user.remove(%s, %s).getorgidsfromemails(%s) -> %w",
-			u.organisation, u.team, emails, err,
-		)
+		return gosync.NewError(u, gosync.PhaseRemove, emails, fmt.Errorf("getorgidsfromemails -> %w", err))
 	}
 
 	teamID, err := u.getTeamID(ctx)
 	if err != nil {
-		return fmt.Errorf("terraformcloud.user.remove(%s, %s).getteamid -> %w", u.organisation, u.team, err)
+		return gosync.NewError(u, gosync.PhaseRemove, emails, fmt.Errorf("getteamid -> %w", err))
 	}
 
 	if len(ids) > 0 {
-		err = u.teamMembers.Remove(ctx, teamID, tfe.TeamMemberRemoveOptions{OrganizationMembershipIDs: ids})
+		err = u.removeMembers(ctx, teamID, ids)
 		if err != nil {
-			return fmt.Errorf("terraformcloud.user.remove(%s, %s).add(%s) -> %w", u.organisation, u.team, emails, err)
+			return gosync.NewError(u, gosync.PhaseRemove, emails, fmt.Errorf("remove -> %w", err))
 		}
 	}
 
-	u.Logger.Println("Finished removing users successfully")
+	u.Logger.Info("Finished removing users successfully")
+
+	return nil
+}
+
+// Validate checks that the Terraform Cloud team exists, and that the token is allowed to read it.
+func (u *User) Validate(ctx context.Context) error {
+	_, err := u.getTeamID(ctx)
+
+	switch {
+	case errors.Is(err, ErrTeamNotFound), errors.Is(err, tfe.ErrResourceNotFound):
+		return gosync.NewError(u, gosync.PhaseValidate, nil, fmt.Errorf("getteamid -> %w", gosync.ErrNotFound))
+	case errors.Is(err, tfe.ErrUnauthorized):
+		return gosync.NewError(u, gosync.PhaseValidate, nil, fmt.Errorf("getteamid -> %w", gosync.ErrMissingPermission))
+	case err != nil:
+		return gosync.NewError(u, gosync.PhaseValidate, nil, fmt.Errorf("getteamid -> %w", err))
+	}
 
 	return nil
 }
@@ -216,8 +271,33 @@ func WithClient(client *tfe.Client) gosync.ConfigFn[*User] {
 	}
 }
 
-// WithLogger passes a custom logger to the adapter.
+// Kind of adapter.
+func (u *User) Kind() string {
+	return "terraformcloud/user"
+}
+
+// Target returns the Terraform Cloud team as `organisation/team`.
+func (u *User) Target() string {
+	return u.organisation + "/" + u.team
+}
+
+// Capabilities of the adapter.
+func (u *User) Capabilities() gosync.Capabilities {
+	return gosync.Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+	}
+}
+
+// WithLogger passes a custom logger to the adapter, converted with [gosync.NewLogLogger].
 func WithLogger(logger *log.Logger) gosync.ConfigFn[*User] {
+	return func(u *User) {
+		u.Logger = gosync.NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom structured logger to the adapter.
+func WithSlogLogger(logger *slog.Logger) gosync.ConfigFn[*User] {
 	return func(u *User) {
 		u.Logger = logger
 	}
@@ -231,19 +311,21 @@ Required config:
   - [user.Organisation]
 */
 func Init(_ context.Context, config map[gosync.ConfigKey]string, configFns ...gosync.ConfigFn[*User]) (*User, error) {
-	for _, key := range []gosync.ConfigKey{Organisation, Team} {
-		if _, ok := config[key]; !ok {
-			return nil, fmt.Errorf("user.init -> %w(%s)", gosync.ErrMissingConfig, key)
-		}
+	parsed, err := Schema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("user.init -> %w", err)
 	}
 
 	adapter := &User{
-		organisation: config[Organisation],
-		team:         config[Team],
+		organisation: parsed.String(Organisation),
+		team:         parsed.String(Team),
 	}
 
-	if _, ok := config[Token]; ok {
-		client, err := tfe.NewClient(&tfe.Config{Token: config[Token]})
+	if parsed.Has(Token) {
+		client, err := tfe.NewClient(&tfe.Config{
+			Token:      parsed.String(Token),
+			HTTPClient: &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
+		})
 		if err != nil {
 			return nil, fmt.Errorf("user.init.newclient -> %w", err)
 		}
@@ -256,13 +338,11 @@ func Init(_ context.Context, config map[gosync.ConfigKey]string, configFns ...go
 	}
 
 	if adapter.Logger == nil {
-		logger := log.New(
-			os.Stderr, "[go-sync/terraformcloud/user] ", log.LstdFlags|log.Lshortfile|log.Lmsgprefix,
-		)
-
-		WithLogger(logger)(adapter)
+		WithSlogLogger(slog.Default())(adapter)
 	}
 
+	parsed.LogUnknown(adapter.Logger)
+
 	if adapter.teamMembers == nil {
 		return nil, fmt.Errorf("user.init -> %w(%s)", gosync.ErrMissingConfig, Token)
 	}
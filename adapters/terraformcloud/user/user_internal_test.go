@@ -1,16 +1,21 @@
 package user
 
 import (
+	"bytes"
 	"context"
 	"log"
+	"log/slog"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adaptertest"
 )
 
 func TestUser_Get(t *testing.T) {
@@ -23,7 +28,7 @@ func TestUser_Get(t *testing.T) {
 		organisation: "org",
 		team:         "team",
 		teams:        mockTeams,
-		Logger:       log.New(os.Stdout, "", log.LstdFlags),
+		Logger:       slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	mockTeams.EXPECT().List(ctx, "org", &tfe.TeamListOptions{
@@ -63,7 +68,7 @@ func TestUser_Add(t *testing.T) {
 		teams:                   mockTeams,
 		organizationMemberships: mockOrgMembership,
 		teamMembers:             mockTeamMembers,
-		Logger:                  log.New(os.Stdout, "", log.LstdFlags),
+		Logger:                  slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	// Mock a first page of responses from the API.
@@ -126,7 +131,7 @@ func TestUser_Remove(t *testing.T) {
 		teams:                   mockTeams,
 		organizationMemberships: mockOrgMembership,
 		teamMembers:             mockTeamMembers,
-		Logger:                  log.New(os.Stdout, "", log.LstdFlags),
+		Logger:                  slog.New(slog.NewTextHandler(os.Stdout, nil)),
 	}
 
 	mockOrgMembership.EXPECT().List(ctx, "org", &tfe.OrganizationMembershipListOptions{
@@ -159,6 +164,111 @@ func TestUser_Remove(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUser_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	mockTeams := newMockITeams(t)
+	mockOrgMembership := newMockIOrganizationMemberships(t)
+	mockTeamMembers := newMockITeamMembers(t)
+
+	adapter := &User{
+		organisation:            "org",
+		team:                    "team",
+		teams:                   mockTeams,
+		organizationMemberships: mockOrgMembership,
+		teamMembers:             mockTeamMembers,
+		Logger:                  slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	}
+
+	mockOrgMembership.EXPECT().List(ctx, "org", mock.Anything).RunAndReturn(
+		func(
+			_ context.Context,
+			_ string,
+			opts *tfe.OrganizationMembershipListOptions,
+		) (*tfe.OrganizationMembershipList, error) {
+			items := make([]*tfe.OrganizationMembership, 0, len(opts.Emails))
+			for _, email := range opts.Emails {
+				items = append(items, &tfe.OrganizationMembership{Email: email, ID: strings.Split(email, "@")[0]})
+			}
+
+			return &tfe.OrganizationMembershipList{
+				Pagination: &tfe.Pagination{CurrentPage: 1, TotalPages: 1},
+				Items:      items,
+			}, nil
+		},
+	)
+
+	mockTeams.EXPECT().List(ctx, "org", &tfe.TeamListOptions{
+		Names: []string{"team"},
+	}).Return(&tfe.TeamList{
+		Items: []*tfe.Team{{ID: "team-id"}},
+	}, nil)
+
+	mockTeamMembers.EXPECT().Add(ctx, "team-id", tfe.TeamMemberAddOptions{
+		OrganizationMembershipIDs: []string{"foo"},
+	}).Return(nil)
+	mockTeamMembers.EXPECT().Remove(ctx, "team-id", tfe.TeamMemberRemoveOptions{
+		OrganizationMembershipIDs: []string{"bar", "baz"},
+	}).Return(tfe.ErrResourceNotFound)
+	mockTeamMembers.EXPECT().Remove(ctx, "team-id", tfe.TeamMemberRemoveOptions{
+		OrganizationMembershipIDs: []string{"bar"},
+	}).Return(nil)
+	mockTeamMembers.EXPECT().Remove(ctx, "team-id", tfe.TeamMemberRemoveOptions{
+		OrganizationMembershipIDs: []string{"baz"},
+	}).Return(tfe.ErrResourceNotFound)
+
+	adaptertest.Idempotent(ctx, t, adapter, []string{"foo@email"}, []string{"bar@email", "baz@email"})
+}
+
+func TestUser_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	tests := map[string]struct {
+		items []*tfe.Team
+		err   error
+		want  error
+	}{
+		"Success":        {items: []*tfe.Team{{ID: "team-id"}}},
+		"Not found":      {err: tfe.ErrResourceNotFound, want: gosync.ErrNotFound},
+		"Unauthorised":   {err: tfe.ErrUnauthorized, want: gosync.ErrMissingPermission},
+		"Team not found": {want: gosync.ErrNotFound},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mockTeams := newMockITeams(t)
+			mockTeams.EXPECT().List(ctx, "org", &tfe.TeamListOptions{Names: []string{"team"}}).
+				Return(&tfe.TeamList{Items: test.items}, test.err)
+
+			adapter := &User{
+				organisation: "org",
+				team:         "team",
+				teams:        mockTeams,
+				Logger:       slog.New(slog.NewTextHandler(os.Stdout, nil)),
+			}
+
+			err := adapter.Validate(ctx)
+
+			if test.want == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var syncErr *gosync.Error
+
+			require.ErrorIs(t, err, test.want)
+			require.ErrorAs(t, err, &syncErr)
+			assert.Equal(t, gosync.PhaseValidate, syncErr.Phase)
+		})
+	}
+}
+
 func TestInit(t *testing.T) {
 	t.Parallel()
 
@@ -216,7 +326,9 @@ func TestInit(t *testing.T) {
 	t.Run("with logger", func(t *testing.T) {
 		t.Parallel()
 
-		logger := log.New(os.Stderr, "custom logger", log.LstdFlags)
+		var buf bytes.Buffer
+
+		logger := log.New(&buf, "custom logger ", 0)
 
 		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
 			Token:        "token",
@@ -225,7 +337,28 @@ func TestInit(t *testing.T) {
 		}, WithLogger(logger))
 
 		require.NoError(t, err)
-		assert.Equal(t, logger, adapter.Logger)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "custom logger INFO test")
+	})
+
+	t.Run("with slog logger", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+		adapter, err := Init(ctx, map[gosync.ConfigKey]string{
+			Token:        "token",
+			Organisation: "org",
+			Team:         "team",
+		}, WithSlogLogger(logger))
+
+		require.NoError(t, err)
+
+		adapter.Logger.Info("test")
+		assert.Contains(t, buf.String(), "msg=test")
 	})
 
 	t.Run("with client", func(t *testing.T) {
@@ -245,3 +378,16 @@ func TestInit(t *testing.T) {
 		assert.Equal(t, client.OrganizationMemberships, adapter.organizationMemberships)
 	})
 }
+
+func TestInit_Registered(t *testing.T) {
+	t.Parallel()
+
+	_, err := gosync.Lookup("terraformcloud/user")
+
+	require.NoError(t, err)
+
+	schema, err := gosync.LookupSchema("terraformcloud/user")
+
+	require.NoError(t, err)
+	assert.Equal(t, Schema, schema)
+}
@@ -3,6 +3,7 @@ package user_test
 import (
 	"context"
 	"log"
+	"log/slog"
 	"os"
 
 	"github.com/hashicorp/go-tfe"
@@ -61,3 +62,20 @@ func ExampleWithLogger() {
 
 	gosync.New(adapter)
 }
+
+func ExampleWithSlogLogger() {
+	ctx := context.Background()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	adapter, err := user.Init(ctx, map[gosync.ConfigKey]string{
+		user.Token:        "my-org-token",
+		user.Organisation: "ovotech",
+		user.Team:         "my-team",
+	}, user.WithSlogLogger(logger))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gosync.New(adapter)
+}
@@ -0,0 +1,47 @@
+/*
+Package adaptertest provides a shared test suite for [gosync.Adapter] implementations.
+
+Call the suite from an adapter's own tests, with its client mocked to respond in the same way as the third party
+service:
+
+	func TestGroup_Idempotent(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.TODO()
+		adapter := ... // Mock the client to reject adding foo@email and removing bar@email.
+
+		adaptertest.Idempotent(ctx, t, adapter, []string{"foo@email"}, []string{"bar@email"})
+	}
+*/
+package adaptertest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+/*
+Idempotent tests that an adapter treats adding things that already exist, and removing things that don't, as
+success. Things can change in a destination between Get and Add or Remove, so neither should fail a sync.
+
+Existing things are passed to Add, and absent things to Remove. Either can be empty to skip that half of the suite.
+*/
+func Idempotent(ctx context.Context, t *testing.T, adapter gosync.Adapter, existing []string, absent []string) {
+	t.Helper()
+
+	if len(existing) > 0 {
+		t.Run("Add existing", func(t *testing.T) {
+			require.NoError(t, adapter.Add(ctx, existing), "adding things that already exist should succeed")
+		})
+	}
+
+	if len(absent) > 0 {
+		t.Run("Remove absent", func(t *testing.T) {
+			require.NoError(t, adapter.Remove(ctx, absent), "removing things that don't exist should succeed")
+		})
+	}
+}
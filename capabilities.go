@@ -0,0 +1,137 @@
+package gosync
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Capabilities describe what an adapter can do, so that Sync can check a sync is valid before making any changes.
+type Capabilities struct {
+	SupportsAdd    bool // SupportsAdd is true if things can be added to the adapter.
+	SupportsRemove bool // SupportsRemove is true if things can be removed from the adapter.
+	RequiresGet    bool // RequiresGet is true if Get must be called before Add or Remove, e.g. to populate a cache.
+	ReplacesList   bool // ReplacesList is true if Add and Remove overwrite the whole list of things in the service.
+	MaxBatchSize   int  // MaxBatchSize is the most things that can be passed to Add or Remove at once. 0 is unlimited.
+}
+
+// ReadOnly is true if the adapter can only be used as a source.
+func (c Capabilities) ReadOnly() bool {
+	return !c.SupportsAdd && !c.SupportsRemove
+}
+
+// Supports returns true if the adapter supports the phase.
+func (c Capabilities) Supports(phase Phase) bool {
+	switch phase {
+	case PhaseAdd:
+		return c.SupportsAdd
+	case PhaseRemove:
+		return c.SupportsRemove
+	default:
+		return true
+	}
+}
+
+/*
+Capable is an optional interface for adapters to declare their Capabilities.
+
+If an adapter doesn't implement Capable, it is assumed to support Add and Remove with no other requirements.
+*/
+type Capable interface {
+	Capabilities() Capabilities
+}
+
+// CapabilitiesOf returns the Capabilities that an adapter declares.
+func CapabilitiesOf(adapter Adapter) Capabilities {
+	if capable, ok := adapter.(Capable); ok {
+		return capable.Capabilities()
+	}
+
+	return Capabilities{SupportsAdd: true, SupportsRemove: true}
+}
+
+// phases returns the phases that an OperatingMode changes a destination with.
+func (m OperatingMode) phases() []Phase {
+	switch m {
+	case AddOnly:
+		return []Phase{PhaseAdd}
+	case RemoveOnly:
+		return []Phase{PhaseRemove}
+	case RemoveAdd:
+		return []Phase{PhaseRemove, PhaseAdd}
+	case AddRemove:
+		return []Phase{PhaseAdd, PhaseRemove}
+	default:
+		return nil
+	}
+}
+
+/*
+sameAdapter returns true if both adapters are pointers to the same instance. Other kinds of adapter aren't compared, as
+comparing structs panics if they hold an uncomparable value in an interface field.
+*/
+func sameAdapter(a any, b any) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || reflect.TypeOf(a) == nil || reflect.TypeOf(a).Kind() != reflect.Pointer {
+		return false
+	}
+
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+/*
+preflight checks that the destination adapter can be synchronised with the source in the current OperatingMode, before
+any adapter is called.
+
+Sync always fetches a destination before changing it, and calls Get rather than Stream on destinations that
+RequiresGet. Destinations that ReplacesList are checked by checkBatches once their changes are known.
+*/
+func (s *Sync) preflight(adapter Adapter) error {
+	if sameAdapter(s.source, adapter) {
+		return fmt.Errorf("%w: source and destination are the same adapter", ErrInvalidConfig)
+	}
+
+	capabilities := CapabilitiesOf(adapter)
+
+	for _, phase := range s.OperatingMode.phases() {
+		if capabilities.Supports(phase) {
+			continue
+		}
+
+		if capabilities.ReadOnly() {
+			return NewError(adapter, phase, nil, ErrReadOnly)
+		}
+
+		return NewError(adapter, phase, nil, fmt.Errorf("%w(%s)", ErrUnsupported, s.OperatingMode))
+	}
+
+	return nil
+}
+
+/*
+checkBatches returns ErrUnsupported if an operation's changes to a destination that ReplacesList would be split into
+more than one batch, as each batch would overwrite the list written by the one before it.
+*/
+func checkBatches(adapter Adapter, phase Phase, things []string) error {
+	capabilities := CapabilitiesOf(adapter)
+
+	if !capabilities.ReplacesList || capabilities.MaxBatchSize <= 0 || len(things) <= capabilities.MaxBatchSize {
+		return nil
+	}
+
+	return NewError(adapter, phase, things, fmt.Errorf("%w(%d things replace the list in batches of %d)",
+		ErrUnsupported, len(things), capabilities.MaxBatchSize))
+}
+
+// batch splits things into batches no larger than size. A size of 0 or less returns a single batch.
+func batch(things []string, size int) [][]string {
+	if size <= 0 || len(things) <= size {
+		return [][]string{things}
+	}
+
+	batches := make([][]string, 0, (len(things)+size-1)/size)
+
+	for start := 0; start < len(things); start += size {
+		batches = append(batches, things[start:min(start+size, len(things))])
+	}
+
+	return batches
+}
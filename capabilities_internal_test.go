@@ -0,0 +1,189 @@
+package gosync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type capableAdapter struct {
+	*MockAdapter
+	capabilities Capabilities
+}
+
+func (c *capableAdapter) Capabilities() Capabilities {
+	return c.capabilities
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	t.Parallel()
+
+	capabilities := CapabilitiesOf(NewMockAdapter(t))
+
+	assert.Equal(t, Capabilities{SupportsAdd: true, SupportsRemove: true}, capabilities)
+	assert.False(t, capabilities.ReadOnly())
+
+	capabilities = CapabilitiesOf(&capableAdapter{NewMockAdapter(t), Capabilities{MaxBatchSize: 10}})
+
+	assert.Equal(t, Capabilities{MaxBatchSize: 10}, capabilities)
+	assert.True(t, capabilities.ReadOnly())
+	assert.True(t, capabilities.Supports(PhaseGet))
+	assert.False(t, capabilities.Supports(PhaseAdd))
+	assert.False(t, capabilities.Supports(PhaseRemove))
+}
+
+// valueAdapter is an adapter that's passed by value, and holds an uncomparable value in an interface field.
+type valueAdapter struct {
+	Adapter
+	things any
+}
+
+func Test_sameAdapter(t *testing.T) {
+	t.Parallel()
+
+	adapter := NewMockAdapter(t)
+
+	assert.True(t, sameAdapter(adapter, adapter))
+	assert.False(t, sameAdapter(adapter, NewMockAdapter(t)))
+	assert.False(t, sameAdapter(adapter, nil))
+	assert.False(t, sameAdapter(nil, nil))
+
+	value := valueAdapter{adapter, []string{"foo"}}
+
+	assert.NotPanics(t, func() {
+		assert.False(t, sameAdapter(value, value))
+	})
+}
+
+func Test_batch(t *testing.T) {
+	t.Parallel()
+
+	things := []string{"foo", "bar", "baz", "qux", "quux"}
+
+	assert.Equal(t, [][]string{things}, batch(things, 0))
+	assert.Equal(t, [][]string{things}, batch(things, 5))
+	assert.Equal(t, [][]string{{"foo", "bar"}, {"baz", "qux"}, {"quux"}}, batch(things, 2))
+}
+
+func TestSync_Preflight(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Read only destination", func(t *testing.T) {
+		t.Parallel()
+
+		// The source has no expectations, so the test fails if Sync calls it before the preflight check.
+		source := NewMockAdapter(t)
+		destination := &capableAdapter{NewMockAdapter(t), Capabilities{}}
+
+		err := New(source).SyncWith(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, ErrReadOnly)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, PhaseRemove, syncErr.Phase)
+	})
+
+	t.Run("Unsupported operation", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		destination := &capableAdapter{NewMockAdapter(t), Capabilities{SupportsAdd: true}}
+
+		err := New(source).SyncWith(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, ErrUnsupported)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, PhaseRemove, syncErr.Phase)
+	})
+
+	t.Run("Supported operating mode", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		destination := &capableAdapter{NewMockAdapter(t), Capabilities{SupportsAdd: true}}
+		destination.EXPECT().Get(ctx).Return([]string{"bar"}, nil)
+		destination.EXPECT().Add(ctx, []string{"foo"}).Return(nil)
+
+		err := New(source, func(s *Sync) {
+			s.OperatingMode = AddOnly
+		}).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Same source and destination", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := NewMockAdapter(t)
+
+		err := New(adapter).SyncWith(ctx, adapter)
+
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestSync_MaxBatchSize(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	source := NewMockAdapter(t)
+	source.EXPECT().Get(ctx).Return([]string{"foo", "bar", "baz"}, nil)
+
+	destination := &capableAdapter{NewMockAdapter(t), Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+		MaxBatchSize:   2,
+	}}
+	destination.EXPECT().Get(ctx).Return([]string{}, nil)
+
+	var batches [][]string
+
+	destination.EXPECT().Add(ctx, mock.Anything).RunAndReturn(func(_ context.Context, things []string) error {
+		batches = append(batches, things)
+
+		return nil
+	}).Twice()
+
+	err := New(source).SyncWith(ctx, destination)
+
+	require.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.ElementsMatch(t, []string{"foo", "bar", "baz"}, append(batches[0], batches[1]...))
+}
+
+func TestSync_ReplacesList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	source := NewMockAdapter(t)
+	source.EXPECT().Get(ctx).Return([]string{"foo", "bar", "baz"}, nil)
+
+	// Each batch would replace the list written by the one before it, so nothing is added.
+	destination := &capableAdapter{NewMockAdapter(t), Capabilities{
+		SupportsAdd:    true,
+		SupportsRemove: true,
+		ReplacesList:   true,
+		MaxBatchSize:   2,
+	}}
+	destination.EXPECT().Get(ctx).Return([]string{}, nil)
+
+	err := New(source).SyncWith(ctx, destination, WithOperatingMode(AddOnly))
+
+	var syncErr *Error
+
+	require.ErrorIs(t, err, ErrUnsupported)
+	require.ErrorAs(t, err, &syncErr)
+	assert.Equal(t, PhaseAdd, syncErr.Phase)
+}
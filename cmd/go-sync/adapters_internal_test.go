@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// exampleConfig returns a value for every key in a schema, using its default or first allowed value where it has one.
+func exampleConfig(schema gosync.ConfigSchema) map[gosync.ConfigKey]string {
+	config := make(map[gosync.ConfigKey]string, len(schema))
+
+	for _, field := range schema {
+		switch {
+		case field.Default != "":
+			config[field.Key] = field.Default
+		case len(field.Allowed) > 0:
+			config[field.Key] = field.Allowed[0]
+		case field.Type == gosync.ConfigBool:
+			config[field.Key] = "true"
+		case field.Type == gosync.ConfigInt:
+			config[field.Key] = "1"
+		case field.Type == gosync.ConfigDuration:
+			config[field.Key] = "1h"
+		default:
+			config[field.Key] = "example"
+		}
+	}
+
+	return config
+}
+
+/*
+assertConfigAccepted checks that an adapter didn't reject its config. Some adapters connect to their service or load
+credentials in their InitFn, which can fail in tests for other reasons.
+*/
+func assertConfigAccepted(t *testing.T, err error) {
+	t.Helper()
+
+	assert.NotErrorIs(t, err, gosync.ErrMissingConfig)
+	assert.NotErrorIs(t, err, gosync.ErrInvalidConfig)
+}
+
+func TestRegistered(t *testing.T) {
+	t.Parallel()
+
+	// Every Adapter registered by the CLI, and the in-memory adapter used by its tests.
+	adapters := []string{
+		"azuread/groupmembership",
+		"azuread/user",
+		"file/grants",
+		"github/team",
+		"google/group",
+		"opsgenie/oncall",
+		"opsgenie/schedule",
+		"slack/conversation",
+		"slack/usergroup",
+		"terraformcloud/membership",
+		"terraformcloud/team",
+		"terraformcloud/user",
+		"test/memory",
+	}
+
+	assert.ElementsMatch(t, adapters, gosync.Registered())
+
+	for _, name := range adapters {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			schema, err := gosync.LookupSchema(name)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+			defer cancel()
+
+			config := exampleConfig(schema)
+			require.NoError(t, schema.Validate(config))
+
+			initFn, err := gosync.Lookup(name)
+			require.NoError(t, err)
+
+			_, err = initFn(ctx, config)
+			assertConfigAccepted(t, err)
+		})
+	}
+}
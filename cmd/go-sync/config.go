@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// jobFile is a YAML file of jobs.
+type jobFile struct {
+	Jobs []jobConfig `yaml:"jobs"`
+}
+
+// adapterConfig is an adapter that's initialised by name, with the config keys that it accepts.
+type adapterConfig struct {
+	Adapter string                      `yaml:"adapter"`
+	Config  map[gosync.ConfigKey]string `yaml:"config"`
+}
+
+// jobConfig is a job in a YAML file, which is built into a [gosync.Job].
+type jobConfig struct {
+	Name           string               `yaml:"name"`
+	Source         adapterConfig        `yaml:"source"`
+	Destinations   []adapterConfig      `yaml:"destinations"`
+	OperatingMode  gosync.OperatingMode `yaml:"operating_mode"`  // Default is gosync.RemoveAdd.
+	DryRun         bool                 `yaml:"dry_run"`         // Default is false.
+	MaximumChanges *int                 `yaml:"maximum_changes"` // Default is gosync.NoChangeLimit.
+	CaseSensitive  *bool                `yaml:"case_sensitive"`  // Default is true.
+}
+
+// operatingModes are the values accepted by a job's operating_mode.
+var operatingModes = []gosync.OperatingMode{ //nolint:gochecknoglobals
+	gosync.AddOnly,
+	gosync.RemoveOnly,
+	gosync.RemoveAdd,
+	gosync.AddRemove,
+}
+
+// loadFile reads and validates a YAML file of jobs. Unknown fields are rejected, as they're usually a typo.
+func loadFile(path string) (jobFile, error) {
+	var file jobFile
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return file, fmt.Errorf("load(%s) -> %w", path, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	decoder.KnownFields(true)
+
+	if err = decoder.Decode(&file); err != nil {
+		return file, fmt.Errorf("load(%s) -> %w(%w)", path, gosync.ErrInvalidConfig, err)
+	}
+
+	if err = file.validate(); err != nil {
+		return file, fmt.Errorf("load(%s) -> %w", path, err)
+	}
+
+	return file, nil
+}
+
+// validate checks every job in the file, and returns all of their problems joined together.
+func (f jobFile) validate() error {
+	if len(f.Jobs) == 0 {
+		return fmt.Errorf("jobs -> %w", gosync.ErrMissingConfig)
+	}
+
+	errs := make([]error, 0)
+	names := make(map[string]bool, len(f.Jobs))
+
+	for _, job := range f.Jobs {
+		if job.Name == "" {
+			errs = append(errs, fmt.Errorf("job name -> %w", gosync.ErrMissingConfig))
+
+			continue
+		}
+
+		if names[job.Name] {
+			errs = append(errs, fmt.Errorf("job(%s) -> %w(duplicate name)", job.Name, gosync.ErrInvalidConfig))
+		}
+
+		names[job.Name] = true
+
+		if err := job.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("job(%s).%w", job.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate checks a job's options, and the config of each of its adapters against their schemas.
+func (j jobConfig) validate() error {
+	errs := make([]error, 0)
+
+	if j.OperatingMode != "" && !slices.Contains(operatingModes, j.OperatingMode) {
+		errs = append(errs, fmt.Errorf("operating_mode -> %w(%s)", gosync.ErrInvalidConfig, j.OperatingMode))
+	}
+
+	if j.MaximumChanges != nil && *j.MaximumChanges < 0 {
+		errs = append(errs, fmt.Errorf("maximum_changes -> %w(%d)", gosync.ErrInvalidConfig, *j.MaximumChanges))
+	}
+
+	if err := j.Source.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("source.%w", err))
+	}
+
+	if len(j.Destinations) == 0 {
+		errs = append(errs, fmt.Errorf("destinations -> %w", gosync.ErrMissingConfig))
+	}
+
+	for index, destination := range j.Destinations {
+		if err := destination.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("destinations[%d].%w", index, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate checks that the adapter is registered, and that its config matches its schema, if it has one.
+func (a adapterConfig) validate() error {
+	if a.Adapter == "" {
+		return fmt.Errorf("adapter -> %w", gosync.ErrMissingConfig)
+	}
+
+	if _, err := gosync.Lookup(a.Adapter); err != nil {
+		return fmt.Errorf("adapter -> %w", err)
+	}
+
+	schema, err := gosync.LookupSchema(a.Adapter)
+	if err != nil {
+		return fmt.Errorf("adapter -> %w", err)
+	}
+
+	if err = schema.Validate(a.Config); err != nil {
+		return fmt.Errorf("%s -> %w", a.Adapter, err)
+	}
+
+	return nil
+}
+
+// init initialises the adapter with its config.
+func (a adapterConfig) init(ctx context.Context) (gosync.Adapter, error) { //nolint:ireturn
+	initFn, err := gosync.Lookup(a.Adapter)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", a.Adapter, err)
+	}
+
+	adapter, err := initFn(ctx, a.Config)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", a.Adapter, err)
+	}
+
+	return adapter, nil
+}
+
+// selectJobs returns the jobs whose names match any of the selectors, in the order they're in the file. Every job is
+// returned if there aren't any selectors, and selectors that don't match a job return an error wrapping ErrNotFound.
+func (f jobFile) selectJobs(selectors []string) ([]jobConfig, error) {
+	if len(selectors) == 0 {
+		return f.Jobs, nil
+	}
+
+	selected := make([]jobConfig, 0, len(f.Jobs))
+	matched := make(map[string]bool, len(selectors))
+
+	for _, job := range f.Jobs {
+		include := false
+
+		for _, selector := range selectors {
+			ok, err := gosync.MatchSelector(selector, job.Name)
+			if err != nil {
+				return nil, fmt.Errorf("select(%s) -> %w", selector, err)
+			}
+
+			if ok {
+				matched[selector] = true
+				include = true
+			}
+		}
+
+		if include {
+			selected = append(selected, job)
+		}
+	}
+
+	for _, selector := range selectors {
+		if !matched[selector] {
+			return nil, fmt.Errorf("select(%s) -> %w", selector, gosync.ErrNotFound)
+		}
+	}
+
+	return selected, nil
+}
+
+// build initialises the job's adapters, and creates a [gosync.Job] with its options.
+func (j jobConfig) build(ctx context.Context, options ...func(*gosync.Sync)) (gosync.Job, error) {
+	source, err := j.Source.init(ctx)
+	if err != nil {
+		return gosync.Job{}, fmt.Errorf("job(%s).source.%w", j.Name, err)
+	}
+
+	destinations := make([]gosync.Adapter, 0, len(j.Destinations))
+
+	for index, config := range j.Destinations {
+		destination, err := config.init(ctx)
+		if err != nil {
+			return gosync.Job{}, fmt.Errorf("job(%s).destinations[%d].%w", j.Name, index, err)
+		}
+
+		destinations = append(destinations, destination)
+	}
+
+	return gosync.Job{
+		Name:         j.Name,
+		Source:       source,
+		Destinations: destinations,
+		Options:      append([]func(*gosync.Sync){j.apply}, options...),
+	}, nil
+}
+
+// apply sets the job's options on a Sync service.
+func (j jobConfig) apply(syncService *gosync.Sync) {
+	if j.OperatingMode != "" {
+		syncService.OperatingMode = j.OperatingMode
+	}
+
+	syncService.DryRun = j.DryRun
+
+	if j.MaximumChanges != nil {
+		syncService.MaximumChanges = *j.MaximumChanges
+	}
+
+	if j.CaseSensitive != nil {
+		syncService.CaseSensitive = *j.CaseSensitive
+	}
+}
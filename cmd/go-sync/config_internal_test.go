@@ -0,0 +1,198 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// writeFile writes a job file to a temporary directory, and returns its path.
+func writeFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "go-sync.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Parallel()
+
+	path := writeFile(t, `
+jobs:
+  - name: platform
+    source:
+      adapter: test/memory
+      config:
+        store: source
+    destinations:
+      - adapter: test/memory
+        config:
+          store: destination
+          fail: true
+    operating_mode: Add
+    dry_run: true
+    maximum_changes: 5
+    case_sensitive: false
+`)
+
+	file, err := loadFile(path)
+	require.NoError(t, err)
+
+	maximumChanges, caseSensitive := 5, false
+
+	assert.Equal(t, jobFile{Jobs: []jobConfig{{
+		Name:   "platform",
+		Source: adapterConfig{Adapter: "test/memory", Config: map[gosync.ConfigKey]string{storeName: "source"}},
+		Destinations: []adapterConfig{{
+			Adapter: "test/memory",
+			Config:  map[gosync.ConfigKey]string{storeName: "destination", storeFail: "true"},
+		}},
+		OperatingMode:  gosync.AddOnly,
+		DryRun:         true,
+		MaximumChanges: &maximumChanges,
+		CaseSensitive:  &caseSensitive,
+	}}}, file)
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		contents string
+		want     error
+		contains string
+	}{
+		"No jobs": {
+			contents: `jobs: []`,
+			want:     gosync.ErrMissingConfig,
+			contains: "jobs -> missing configuration",
+		},
+		"Unknown field": {
+			contents: `jobs: [{name: foo, sourec: {adapter: test/memory}}]`,
+			want:     gosync.ErrInvalidConfig,
+			contains: "field sourec not found",
+		},
+		"Missing name": {
+			contents: `jobs: [{source: {adapter: test/memory, config: {store: a}}}]`,
+			want:     gosync.ErrMissingConfig,
+			contains: "job name -> missing configuration",
+		},
+		"Duplicate name": {
+			contents: `
+jobs:
+  - name: foo
+    source: {adapter: test/memory, config: {store: a}}
+    destinations: [{adapter: test/memory, config: {store: b}}]
+  - name: foo
+    source: {adapter: test/memory, config: {store: a}}
+    destinations: [{adapter: test/memory, config: {store: b}}]
+`,
+			want:     gosync.ErrInvalidConfig,
+			contains: "job(foo) -> invalid configuration(duplicate name)",
+		},
+		"Missing destinations": {
+			contents: `jobs: [{name: foo, source: {adapter: test/memory, config: {store: a}}}]`,
+			want:     gosync.ErrMissingConfig,
+			contains: "job(foo).destinations -> missing configuration",
+		},
+		"Unregistered adapter": {
+			contents: `
+jobs:
+  - name: foo
+    source: {adapter: test/unknown}
+    destinations: [{adapter: test/memory, config: {store: b}}]
+`,
+			want:     gosync.ErrNotRegistered,
+			contains: "job(foo).source.adapter -> registry.lookup(test/unknown)",
+		},
+		"Missing config key": {
+			contents: `
+jobs:
+  - name: foo
+    source: {adapter: test/memory}
+    destinations: [{adapter: test/memory, config: {store: b}}]
+`,
+			want:     gosync.ErrMissingConfig,
+			contains: "job(foo).source.test/memory -> missing configuration(store)",
+		},
+		"Unknown config key": {
+			contents: `
+jobs:
+  - name: foo
+    source: {adapter: test/memory, config: {store: a}}
+    destinations: [{adapter: test/memory, config: {stor: b}}]
+`,
+			want:     gosync.ErrInvalidConfig,
+			contains: "invalid configuration(stor): unknown key",
+		},
+		"Invalid operating mode": {
+			contents: `
+jobs:
+  - name: foo
+    source: {adapter: test/memory, config: {store: a}}
+    destinations: [{adapter: test/memory, config: {store: b}}]
+    operating_mode: Sideways
+`,
+			want:     gosync.ErrInvalidConfig,
+			contains: "job(foo).operating_mode -> invalid configuration(Sideways)",
+		},
+		"Negative maximum changes": {
+			contents: `
+jobs:
+  - name: foo
+    source: {adapter: test/memory, config: {store: a}}
+    destinations: [{adapter: test/memory, config: {store: b}}]
+    maximum_changes: -1
+`,
+			want:     gosync.ErrInvalidConfig,
+			contains: "job(foo).maximum_changes -> invalid configuration(-1)",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := loadFile(writeFile(t, test.contents))
+
+			require.ErrorIs(t, err, test.want)
+			require.ErrorContains(t, err, test.contains)
+		})
+	}
+
+	t.Run("Missing file", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := loadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestJobFile_SelectJobs(t *testing.T) {
+	t.Parallel()
+
+	file := jobFile{Jobs: []jobConfig{{Name: "platform-a"}, {Name: "billing"}, {Name: "platform-b"}}}
+
+	jobs, err := file.selectJobs(nil)
+	require.NoError(t, err)
+	assert.Equal(t, file.Jobs, jobs)
+
+	jobs, err = file.selectJobs([]string{"platform-*"})
+	require.NoError(t, err)
+	assert.Equal(t, []jobConfig{{Name: "platform-a"}, {Name: "platform-b"}}, jobs)
+
+	// Jobs are kept in the order they're in the file, and are only selected once.
+	jobs, err = file.selectJobs([]string{"platform-b", "billing", "platform-*"})
+	require.NoError(t, err)
+	assert.Equal(t, []jobConfig{{Name: "platform-a"}, {Name: "billing"}, {Name: "platform-b"}}, jobs)
+
+	_, err = file.selectJobs([]string{"billing", "payments"})
+	require.ErrorIs(t, err, gosync.ErrNotFound)
+	require.ErrorContains(t, err, "select(payments)")
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"time"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// adapterReference matches a reference to an adapter in a job file, e.g. `platform.source` or
+// `platform.destinations[0]`.
+var adapterReference = regexp.MustCompile(`^(.+)\.(?:source|destinations\[(\d+)])$`) //nolint:gochecknoglobals
+
+// lookupAdapter returns the config of the adapter that a reference points to.
+func (f jobFile) lookupAdapter(reference string) (adapterConfig, error) {
+	match := adapterReference.FindStringSubmatch(reference)
+	if match == nil {
+		return adapterConfig{}, fmt.Errorf(
+			"adapter(%s) -> %w: expected <job>.source or <job>.destinations[<index>]", reference, gosync.ErrInvalidConfig,
+		)
+	}
+
+	for _, job := range f.Jobs {
+		if job.Name != match[1] {
+			continue
+		}
+
+		if match[2] == "" {
+			return job.Source, nil
+		}
+
+		index, err := strconv.Atoi(match[2])
+		if err != nil || index >= len(job.Destinations) {
+			return adapterConfig{}, fmt.Errorf("adapter(%s) -> %w", reference, gosync.ErrNotFound)
+		}
+
+		return job.Destinations[index], nil
+	}
+
+	return adapterConfig{}, fmt.Errorf("adapter(%s) -> %w", reference, gosync.ErrNotFound)
+}
+
+// diffAdapters shows the things that differ between any two adapters in a job file, without changing either.
+func diffAdapters(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := newFlagSet("diff", "[flags] <from> <to>",
+		"Show the things that are missing from, or unexpected in, one adapter compared with another. Adapters are\n"+
+			"referred to as <job>.source or <job>.destinations[<index>].",
+		stderr)
+
+	path := flags.String("config", "go-sync.yaml", "`path` to the YAML job file")
+	format := flags.String("format", string(gosync.ReportTable), "`format` of the output: table, json or markdown")
+	caseSensitive := flags.Bool("case-sensitive", true, "compare things case sensitively")
+
+	if code, ok := parseFlags(flags, args, format, stderr); !ok {
+		return code
+	}
+
+	if flags.NArg() != 2 { //nolint:gomnd,mnd
+		flags.Usage()
+
+		return exitUsage
+	}
+
+	file, err := loadFile(*path)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitUsage
+	}
+
+	adapters := make([]gosync.Adapter, 0, flags.NArg())
+
+	for _, reference := range flags.Args() {
+		config, err := file.lookupAdapter(reference)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+
+			return exitUsage
+		}
+
+		adapter, err := config.init(ctx)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s.%s\n", reference, err)
+
+			return exitFailure
+		}
+
+		adapters = append(adapters, adapter)
+	}
+
+	syncService := gosync.New(adapters[0], gosync.WithCaseSensitive(*caseSensitive))
+
+	destination, err := syncService.Check(ctx, adapters[1])
+	if err != nil {
+		slog.Default().Error("Failed to diff adapters", slog.Any("error", err))
+
+		destination.Error = err.Error()
+	}
+
+	drift := gosync.Drift{CheckedAt: time.Now().UTC(), Destinations: []gosync.DestinationDrift{destination}}
+
+	if err = drift.Render(stdout, gosync.ReportFormat(*format)); err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitFailure
+	}
+
+	switch {
+	case destination.Error != "":
+		return exitFailure
+	case destination.Drifted():
+		return exitDiffered
+	default:
+		return exitOK
+	}
+}
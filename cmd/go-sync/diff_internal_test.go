@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+func TestJobFile_LookupAdapter(t *testing.T) {
+	t.Parallel()
+
+	source := adapterConfig{Adapter: "test/memory", Config: map[gosync.ConfigKey]string{storeName: "a"}}
+	destination := adapterConfig{Adapter: "test/memory", Config: map[gosync.ConfigKey]string{storeName: "b"}}
+	file := jobFile{Jobs: []jobConfig{{Name: "platform.a", Source: source, Destinations: []adapterConfig{destination}}}}
+
+	config, err := file.lookupAdapter("platform.a.source")
+	require.NoError(t, err)
+	assert.Equal(t, source, config)
+
+	config, err = file.lookupAdapter("platform.a.destinations[0]")
+	require.NoError(t, err)
+	assert.Equal(t, destination, config)
+
+	for reference, want := range map[string]error{
+		"platform.a":                 gosync.ErrInvalidConfig,
+		"platform.a.destinations":    gosync.ErrInvalidConfig,
+		"platform.a.destinations[1]": gosync.ErrNotFound,
+		"billing.source":             gosync.ErrNotFound,
+	} {
+		_, err = file.lookupAdapter(reference)
+		require.ErrorIs(t, err, want, reference)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Drift", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo", "bar")
+		destination := newStore(t, "destination", "bar", "baz")
+		path := writeFile(t, "jobs:"+jobYAML("foo", source, destination, ""))
+
+		var stdout, stderr bytes.Buffer
+
+		code := run(ctx, []string{"diff", "-config", path, "-format", "json", "foo.source", "foo.destinations[0]"},
+			&stdout, &stderr)
+
+		assert.Equal(t, exitDiffered, code, stderr.String())
+		assert.Equal(t, []string{"bar", "baz"}, storeThings(destination))
+
+		var drift gosync.Drift
+
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &drift))
+		require.Len(t, drift.Destinations, 1)
+		assert.Equal(t, []string{"foo"}, drift.Destinations[0].Missing)
+		assert.Equal(t, []string{"baz"}, drift.Destinations[0].Unexpected)
+	})
+
+	t.Run("Any two adapters", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo")
+		first := newStore(t, "first", "FOO")
+		second := newStore(t, "second", "foo")
+		path := writeFile(t, "jobs:"+jobYAML("a", source, first, "")+jobYAML("b", source, second, ""))
+
+		args := []string{"diff", "-config", path, "a.destinations[0]", "b.destinations[0]"}
+
+		assert.Equal(t, exitDiffered, run(ctx, args, &bytes.Buffer{}, &bytes.Buffer{}))
+
+		args = []string{"diff", "-config", path, "-case-sensitive=false", "a.destinations[0]", "b.destinations[0]"}
+
+		assert.Equal(t, exitOK, run(ctx, args, &bytes.Buffer{}, &bytes.Buffer{}))
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		t.Parallel()
+
+		destination := newStore(t, "destination")
+		path := writeFile(t, "jobs:"+jobYAML("foo", "missing", destination, "")+`
+  - name: bar
+    source: {adapter: test/memory, config: {store: missing, fail: true}}
+    destinations: [{adapter: test/memory, config: {store: missing}}]
+`)
+
+		var stdout bytes.Buffer
+
+		code := run(ctx, []string{"diff", "-config", path, "bar.source", "foo.destinations[0]"}, &stdout, &bytes.Buffer{})
+
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stdout.String(), "error:")
+	})
+
+	t.Run("Usage", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFile(t, "jobs:"+jobYAML("foo", "source", "destination", ""))
+
+		for name, args := range map[string][]string{
+			"Missing adapter": {"diff", "-config", path, "foo.source"},
+			"Unknown adapter": {"diff", "-config", path, "foo.source", "bar.source"},
+			"Invalid adapter": {"diff", "-config", path, "foo.source", "foo"},
+			"Unknown format":  {"diff", "-config", path, "-format", "yaml", "foo.source", "foo.destinations[0]"},
+		} {
+			var stderr bytes.Buffer
+
+			assert.Equal(t, exitUsage, run(ctx, args, &bytes.Buffer{}, &stderr), name)
+			assert.NotEmpty(t, stderr.String(), name)
+		}
+	})
+}
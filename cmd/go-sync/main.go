@@ -0,0 +1,101 @@
+/*
+Go Sync synchronises adapters from a YAML job file, without writing any Go.
+
+	go-sync run [-config go-sync.yaml] [-dry-run] [-format table|json|markdown] [job...]
+	go-sync plan [-config go-sync.yaml] [-out go-sync.plan.json] [-format table|json|markdown] [job...]
+	go-sync apply [-config go-sync.yaml] [-format table|json|markdown] <plan>
+	go-sync diff [-config go-sync.yaml] [-case-sensitive=false] [-format table|json|markdown] <from> <to>
+
+Each job in the file has a source and destinations, which are adapters initialised by name with the config keys that
+they accept, and the options of the Sync service:
+
+	jobs:
+	  - name: platform
+	    source:
+	      adapter: github/team
+	      config:
+	        github_org: ovotech
+	        team_slug: platform
+	        discovery_mechanism: saml
+	    destinations:
+	      - adapter: slack/conversation
+	        config:
+	          name: platform
+	    operating_mode: AddRemove
+	    dry_run: true
+	    maximum_changes: 5
+	    case_sensitive: false
+
+Every job is run, unless some are selected by name. Names can contain wildcards, such as `platform-*`. Once every job
+has run, the changes to each destination are written to stdout.
+
+Plan computes the changes of each job in dry run mode, and writes them to a JSON plan file. Apply makes exactly the
+changes in a plan, after checking that the job file is unchanged and that every destination would still have the same
+changes. If any destination has drifted, nothing is changed. Each operation can change no more things than were
+planned for it, and plans for jobs with dry_run set are refused.
+
+Diff compares any two adapters in the job file, without changing either of them. Adapters are referred to by their
+job, as `<job>.source` or `<job>.destinations[<index>]`. Things in the first adapter that aren't in the second are
+missing, and things in the second that aren't in the first are unexpected.
+
+Secrets, such as `slack_api_key`, don't need to be written into the job file. Config values can refer to environment
+variables as `${SLACK_API_KEY}`, to files as `file:///run/secrets/slack`, and to secrets in the JSON file named by the
+GO_SYNC_SECRETS_FILE environment variable as `local://slack`. Secret values are redacted from errors.
+
+The exit code tells you how the run went:
+
+	0	Things were changed, would have been in dry run mode, or the adapters didn't differ.
+	1	A job failed.
+	2	The command line or job file was invalid.
+	3	A safeguard, such as the maximum number of changes, stopped a job.
+	4	There was nothing to change.
+	5	A plan wasn't applied, because destinations had drifted since it was made.
+	6	The adapters differed.
+*/
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	gosync "github.com/ovotech/go-sync"
+
+	// Register every adapter, so that jobs can refer to them by name. Jobs only synchronise Adapters, so MultiAdapters
+	// such as slack/usergroups aren't registered.
+	_ "github.com/ovotech/go-sync/adapters/azuread/groupmembership"
+	_ "github.com/ovotech/go-sync/adapters/azuread/user"
+	_ "github.com/ovotech/go-sync/adapters/file/grants"
+	_ "github.com/ovotech/go-sync/adapters/github/team"
+	_ "github.com/ovotech/go-sync/adapters/google/group"
+	_ "github.com/ovotech/go-sync/adapters/opsgenie/oncall"
+	_ "github.com/ovotech/go-sync/adapters/opsgenie/schedule"
+	_ "github.com/ovotech/go-sync/adapters/slack/conversation"
+	_ "github.com/ovotech/go-sync/adapters/slack/usergroup"
+	_ "github.com/ovotech/go-sync/adapters/terraformcloud/membership"
+	_ "github.com/ovotech/go-sync/adapters/terraformcloud/team"
+	_ "github.com/ovotech/go-sync/adapters/terraformcloud/user"
+)
+
+const (
+	secretsFileEnv = "GO_SYNC_SECRETS_FILE" // secretsFileEnv names a JSON file of secrets.
+	localSecrets   = "local"                // localSecrets is the scheme of references to secrets in the file.
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+
+	// Logs are written to stderr, so that reports written to stdout can be piped to other tools.
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+
+	if path := os.Getenv(secretsFileEnv); path != "" {
+		gosync.RegisterSecretProvider(localSecrets, gosync.FileSecretProvider(path))
+	}
+
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+
+	stop()
+	os.Exit(code)
+}
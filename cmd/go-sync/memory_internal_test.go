@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// storeName is the name of an in-memory store of things, which test/memory adapters read and write.
+const storeName gosync.ConfigKey = "store"
+
+// storeFail makes a test/memory adapter fail to get its things.
+const storeFail gosync.ConfigKey = "fail"
+
+// errMemory is returned by test/memory adapters that are configured to fail.
+var errMemory = errors.New("memory adapter failed")
+
+//nolint:gochecknoglobals
+var (
+	storesMu sync.Mutex
+	stores   = make(map[string]map[string]bool)
+
+	memorySchema = gosync.ConfigSchema{
+		{Key: storeName, Required: true},
+		{Key: storeFail, Type: gosync.ConfigBool},
+	}
+)
+
+// Register an in-memory adapter, so that job files in tests can refer to it.
+func init() { //nolint:gochecknoinits
+	gosync.Register("test/memory", initMemory)
+	gosync.RegisterSchema("test/memory", memorySchema)
+}
+
+// newStore creates a store of things for a test, which test/memory adapters refer to by the test's name.
+func newStore(t *testing.T, suffix string, things ...string) string {
+	t.Helper()
+
+	name := t.Name() + "/" + suffix
+
+	storesMu.Lock()
+	defer storesMu.Unlock()
+
+	stores[name] = make(map[string]bool, len(things))
+	for _, thing := range things {
+		stores[name][thing] = true
+	}
+
+	return name
+}
+
+// storeThings returns the things in a store, sorted.
+func storeThings(name string) []string {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+
+	things := make([]string, 0, len(stores[name]))
+	for thing := range stores[name] {
+		things = append(things, thing)
+	}
+
+	slices.Sort(things)
+
+	return things
+}
+
+// memory is an adapter that reads and writes an in-memory store.
+type memory struct {
+	store string
+	fail  bool
+}
+
+func initMemory(_ context.Context, config map[gosync.ConfigKey]string, _ ...gosync.ConfigFn[*memory]) (*memory, error) {
+	parsed, err := memorySchema.Parse(config)
+	if err != nil {
+		return nil, fmt.Errorf("memory.init -> %w", err)
+	}
+
+	return &memory{store: parsed.String(storeName), fail: parsed.Bool(storeFail)}, nil
+}
+
+func (m *memory) Get(_ context.Context) ([]string, error) {
+	if m.fail {
+		return nil, errMemory
+	}
+
+	return storeThings(m.store), nil
+}
+
+func (m *memory) Add(_ context.Context, things []string) error {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+
+	for _, thing := range things {
+		stores[m.store][thing] = true
+	}
+
+	return nil
+}
+
+func (m *memory) Remove(_ context.Context, things []string) error {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+
+	for _, thing := range things {
+		delete(stores[m.store], thing)
+	}
+
+	return nil
+}
+
+func (m *memory) Kind() string {
+	return "test/memory"
+}
+
+func (m *memory) Target() string {
+	return m.store
+}
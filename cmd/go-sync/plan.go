@@ -0,0 +1,440 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// planVersion is the version of the plan file format, which is increased when it changes incompatibly.
+const planVersion = 1
+
+var (
+	// errDrifted is returned when a destination's changes no longer match the plan.
+	errDrifted = errors.New("drifted since the plan was made")
+	// errInvalidPlan is returned when a plan can't be applied, because it's for a different job file or has errors.
+	errInvalidPlan = errors.New("invalid plan")
+)
+
+// plan is the file written by `go-sync plan`, which `go-sync apply` makes the changes of.
+type plan struct {
+	Version      int                  `json:"version"`
+	CreatedAt    time.Time            `json:"createdAt"`
+	Config       string               `json:"config"`   // Config is the path to the job file.
+	Checksum     string               `json:"checksum"` // Checksum of the job file, which mustn't change before apply.
+	Destinations []plannedDestination `json:"destinations"`
+}
+
+// plannedDestination is the changes that will be made to one of a job's destinations.
+type plannedDestination struct {
+	Job   string `json:"job"`
+	Index int    `json:"index"` // Index of the destination in the job.
+	gosync.DestinationReport
+}
+
+// report returns the planned changes as a report, so that they can be rendered like the changes of a run.
+func (p plan) report() *gosync.Report {
+	report := &gosync.Report{Destinations: make([]gosync.DestinationReport, 0, len(p.Destinations))}
+
+	for _, destination := range p.Destinations {
+		report.Destinations = append(report.Destinations, destination.DestinationReport)
+	}
+
+	return report
+}
+
+// planJobs computes the changes to the destinations of every selected job in a job file, and writes them to a plan.
+func planJobs(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := newFlagSet("plan", "[flags] [job...]",
+		"Compute the changes of every job in a YAML job file, or the selected jobs, and write them to a plan file.",
+		stderr)
+
+	path := flags.String("config", "go-sync.yaml", "`path` to the YAML job file")
+	out := flags.String("out", "go-sync.plan.json", "`path` to write the plan to")
+	format := flags.String("format", string(gosync.ReportTable), "`format` of the output: table, json or markdown")
+
+	if code, ok := parseFlags(flags, args, format, stderr); !ok {
+		return code
+	}
+
+	jobs, ok := loadJobs(*path, flags.Args(), stderr)
+	if !ok {
+		return exitUsage
+	}
+
+	checksum, err := fileChecksum(*path)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitUsage
+	}
+
+	result := plan{Version: planVersion, CreatedAt: time.Now().UTC(), Config: *path, Checksum: checksum}
+	code := exitNoChanges
+
+	for _, job := range jobs {
+		destinations, jobCode := planJob(ctx, job)
+		result.Destinations = append(result.Destinations, destinations...)
+
+		if severity[jobCode] > severity[code] {
+			code = jobCode
+		}
+	}
+
+	if err = writePlan(*out, result); err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitFailure
+	}
+
+	if err = result.report().Render(stdout, gosync.ReportFormat(*format)); err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitFailure
+	}
+
+	return code
+}
+
+// planJob computes the changes to each of a job's destinations in dry run mode, and returns its exit code.
+// Destinations that fail are still planned with their error, so that the plan can't be applied.
+func planJob(ctx context.Context, config jobConfig) ([]plannedDestination, int) {
+	logger := slog.Default().With(slog.String(gosync.LogKeyJob, config.Name))
+	planned := make([]plannedDestination, 0, len(config.Destinations))
+
+	job, err := config.build(ctx, gosync.WithSlogLogger(logger))
+	if err != nil {
+		logger.Error("Job failed", slog.Any("error", err))
+
+		for index, destination := range config.Destinations {
+			planned = append(planned, plannedDestination{
+				Job:               config.Name,
+				Index:             index,
+				DestinationReport: gosync.DestinationReport{Adapter: destination.Adapter, Error: err.Error()},
+			})
+		}
+
+		return planned, exitFailure
+	}
+
+	syncService := gosync.New(job.Source, job.Options...)
+	code := exitNoChanges
+
+	for index, destination := range job.Destinations {
+		report, destinationCode := computeChanges(ctx, syncService, config.Name, destination)
+		planned = append(planned, plannedDestination{Job: config.Name, Index: index, DestinationReport: report})
+
+		if severity[destinationCode] > severity[code] {
+			code = destinationCode
+		}
+	}
+
+	return planned, code
+}
+
+// computeChanges runs a sync with a destination in dry run mode, and returns the changes it would make, with their
+// things sorted so that they can be compared.
+func computeChanges(
+	ctx context.Context,
+	syncService *gosync.Sync,
+	job string,
+	destination gosync.Adapter,
+) (gosync.DestinationReport, int) {
+	report := &gosync.Report{}
+	err := syncService.SyncWith(ctx, destination, gosync.WithDryRun(true), gosync.WithReport(report))
+
+	var changes gosync.DestinationReport
+	if len(report.Destinations) > 0 {
+		changes = report.Destinations[0]
+	}
+
+	changes.Changes = slices.Clone(changes.Changes)
+	for index := range changes.Changes {
+		changes.Changes[index].Things = slices.Clone(changes.Changes[index].Things)
+		slices.Sort(changes.Changes[index].Things)
+	}
+
+	return changes, exitCode(gosync.Summarise(job, report, err), err)
+}
+
+// sameChanges returns true if both destinations have the same changes, ignoring the safeguards that stopped them.
+func sameChanges(a gosync.DestinationReport, b gosync.DestinationReport) bool {
+	return slices.EqualFunc(a.Changes, b.Changes, func(a gosync.Change, b gosync.Change) bool {
+		return a.Operation == b.Operation && slices.Equal(a.Things, b.Things)
+	})
+}
+
+// applyPlan makes exactly the changes in a plan file, after checking that every destination would still have the
+// same changes. Nothing is changed if any destination has drifted since the plan was made.
+func applyPlan(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := newFlagSet("apply", "[flags] <plan>",
+		"Make the changes in a plan file, unless any destination has drifted since the plan was made.",
+		stderr)
+
+	path := flags.String("config", "", "`path` to the YAML job file, if it has moved since the plan was made")
+	format := flags.String("format", string(gosync.ReportTable), "`format` of the report: table, json or markdown")
+
+	if code, ok := parseFlags(flags, args, format, stderr); !ok {
+		return code
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+
+		return exitUsage
+	}
+
+	planned, err := readPlan(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitUsage
+	}
+
+	if *path == "" {
+		*path = planned.Config
+	}
+
+	staged, err := stagePlan(ctx, *path, planned)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return stageExitCode(err)
+	}
+
+	report := &gosync.Report{}
+	code := exitNoChanges
+
+	for _, change := range staged {
+		if changeCode := change.apply(ctx, report); severity[changeCode] > severity[code] {
+			code = changeCode
+		}
+	}
+
+	if err = report.Render(stdout, gosync.ReportFormat(*format)); err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitFailure
+	}
+
+	return code
+}
+
+// stagedChange is a planned destination, which has been checked against its current changes and is ready to apply.
+type stagedChange struct {
+	planned     plannedDestination
+	syncService *gosync.Sync
+	destination gosync.Adapter
+}
+
+// stagePlan checks that a plan was made from the job file, and that none of its destinations have drifted.
+func stagePlan(ctx context.Context, path string, planned plan) ([]stagedChange, error) {
+	checksum, err := fileChecksum(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if checksum != planned.Checksum {
+		return nil, fmt.Errorf("plan -> %w: %s has changed since the plan was made", errInvalidPlan, path)
+	}
+
+	file, err := loadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("plan -> %w", err)
+	}
+
+	jobs := make(map[string]jobConfig, len(file.Jobs))
+	for _, job := range file.Jobs {
+		jobs[job.Name] = job
+	}
+
+	built := make(map[string]gosync.Job)
+	services := make(map[string]*gosync.Sync)
+	staged := make([]stagedChange, 0, len(planned.Destinations))
+	errs := make([]error, 0)
+
+	for _, destination := range planned.Destinations {
+		name := fmt.Sprintf("plan.job(%s).destinations[%d]", destination.Job, destination.Index)
+
+		if destination.Error != "" {
+			return nil, fmt.Errorf("%s -> %w: %s", name, errInvalidPlan, destination.Error)
+		}
+
+		job, ok := built[destination.Job]
+		if !ok {
+			config, found := jobs[destination.Job]
+			if !found {
+				return nil, fmt.Errorf("%s -> %w", name, gosync.ErrNotFound)
+			}
+
+			// Jobs in dry run mode are never changed, so their plans can't be applied.
+			if config.DryRun {
+				return nil, fmt.Errorf("%s -> %w: job is configured with dry_run", name, errInvalidPlan)
+			}
+
+			logger := slog.Default().With(slog.String(gosync.LogKeyJob, config.Name))
+
+			if job, err = config.build(ctx, gosync.WithSlogLogger(logger)); err != nil {
+				return nil, fmt.Errorf("plan.%w", err)
+			}
+
+			built[destination.Job] = job
+			services[destination.Job] = gosync.New(job.Source, job.Options...)
+		}
+
+		if destination.Index < 0 || destination.Index >= len(job.Destinations) {
+			return nil, fmt.Errorf("%s -> %w", name, gosync.ErrNotFound)
+		}
+
+		adapter := job.Destinations[destination.Index]
+
+		if kind, target := gosync.Describe(adapter); kind != destination.Adapter || target != destination.Target {
+			return nil, fmt.Errorf("%s -> %w: destination is %s(%s)", name, errInvalidPlan, kind, target)
+		}
+
+		current, _ := computeChanges(ctx, services[destination.Job], destination.Job, adapter)
+
+		switch {
+		case current.Error != "":
+			errs = append(errs, fmt.Errorf("%s -> %w", name, errors.New(current.Error))) //nolint:err113
+		case !sameChanges(current, destination.DestinationReport):
+			errs = append(errs, fmt.Errorf("%s(%s) -> %w", destination.Adapter, destination.Target, errDrifted))
+		}
+
+		staged = append(staged, stagedChange{
+			planned:     destination,
+			syncService: services[destination.Job],
+			destination: adapter,
+		})
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("plan -> %w", err)
+	}
+
+	return staged, nil
+}
+
+/*
+apply makes the planned changes to the destination, and adds them to the report. Each operation is made separately,
+limited to the number of things planned for it, so that a destination which drifts while the plan is applied can't be
+changed any further.
+*/
+func (c stagedChange) apply(ctx context.Context, report *gosync.Report) int {
+	logger := slog.Default().With(slog.String(gosync.LogKeyJob, c.planned.Job))
+	applied := gosync.DestinationReport{
+		Adapter: c.planned.Adapter,
+		Target:  c.planned.Target,
+		Mode:    c.planned.Mode,
+		Changes: make([]gosync.Change, 0, len(c.planned.Changes)),
+	}
+
+	var err error
+
+	for _, change := range c.planned.Changes {
+		operationReport := &gosync.Report{}
+		err = c.syncService.SyncWith(ctx, c.destination,
+			gosync.WithOperatingMode(operationModes[change.Operation]),
+			gosync.WithMaximumChanges(len(change.Things)),
+			gosync.WithReport(operationReport),
+		)
+
+		for _, destination := range operationReport.Destinations {
+			applied.Changes = append(applied.Changes, destination.Changes...)
+			applied.Error = destination.Error
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	report.Destinations = append(report.Destinations, applied)
+	summary := gosync.Summarise(c.planned.Job, &gosync.Report{Destinations: []gosync.DestinationReport{applied}}, err)
+
+	code := exitCode(summary, err)
+	if code == exitFailure || code == exitSafeguard {
+		logger.Error("Failed to apply plan", slog.Any("error", err))
+	}
+
+	return code
+}
+
+// operationModes are the operating modes that make a single operation of a plan.
+var operationModes = map[gosync.Phase]gosync.OperatingMode{ //nolint:gochecknoglobals
+	gosync.PhaseAdd:    gosync.AddOnly,
+	gosync.PhaseRemove: gosync.RemoveOnly,
+}
+
+// stageExitCode returns the exit code for a plan that couldn't be staged. Drift has its own exit code, so that it can
+// be told apart from plans that are invalid and destinations that fail to be checked.
+func stageExitCode(err error) int {
+	switch {
+	case errors.Is(err, errDrifted):
+		return exitDrifted
+	case errors.Is(err, errInvalidPlan),
+		errors.Is(err, os.ErrNotExist),
+		errors.Is(err, gosync.ErrNotFound),
+		errors.Is(err, gosync.ErrInvalidConfig),
+		errors.Is(err, gosync.ErrMissingConfig):
+		return exitUsage
+	default:
+		return exitFailure
+	}
+}
+
+// fileChecksum returns the SHA-256 checksum of a file.
+func fileChecksum(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("checksum(%s) -> %w", path, err)
+	}
+
+	sum := sha256.Sum256(contents)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// writePlan writes a plan to a JSON file.
+func writePlan(path string, planned plan) error {
+	contents, err := json.MarshalIndent(planned, "", "  ")
+	if err != nil {
+		return fmt.Errorf("plan.write(%s) -> %w", path, err)
+	}
+
+	if err = os.WriteFile(path, append(contents, '\n'), 0o600); err != nil { //nolint:gomnd,mnd
+		return fmt.Errorf("plan.write(%s) -> %w", path, err)
+	}
+
+	return nil
+}
+
+// readPlan reads a plan from a JSON file, and checks that it's a version that can be applied.
+func readPlan(path string) (plan, error) {
+	var planned plan
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return planned, fmt.Errorf("plan.read(%s) -> %w", path, err)
+	}
+
+	if err = json.Unmarshal(contents, &planned); err != nil {
+		return planned, fmt.Errorf("plan.read(%s) -> %w(%w)", path, errInvalidPlan, err)
+	}
+
+	if planned.Version != planVersion {
+		return planned, fmt.Errorf("plan.read(%s) -> %w(version %d)", path, errInvalidPlan, planned.Version)
+	}
+
+	return planned, nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+func TestPlanAndApply(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Apply", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo", "bar")
+		destination := newStore(t, "destination", "bar", "baz")
+		path := writeFile(t, "jobs:"+jobYAML("foo", source, destination, ""))
+		out := filepath.Join(t.TempDir(), "plan.json")
+
+		var stdout, stderr bytes.Buffer
+
+		code := run(ctx, []string{"plan", "-config", path, "-out", out}, &stdout, &stderr)
+
+		// Planning doesn't change anything.
+		assert.Equal(t, exitOK, code, stderr.String())
+		assert.Equal(t, []string{"bar", "baz"}, storeThings(destination))
+		assert.Contains(t, stdout.String(), destination)
+
+		planned, err := readPlan(out)
+		require.NoError(t, err)
+		require.Len(t, planned.Destinations, 1)
+		assert.Equal(t, "foo", planned.Destinations[0].Job)
+		assert.Equal(t, []gosync.Change{
+			{Operation: gosync.PhaseRemove, Count: 1, Things: []string{"baz"}},
+			{Operation: gosync.PhaseAdd, Count: 1, Things: []string{"foo"}},
+		}, planned.Destinations[0].Changes)
+
+		stdout.Reset()
+
+		code = run(ctx, []string{"apply", "-format", "json", out}, &stdout, &stderr)
+
+		assert.Equal(t, exitOK, code, stderr.String())
+		assert.Equal(t, []string{"bar", "foo"}, storeThings(destination))
+		assert.True(t, json.Valid(stdout.Bytes()))
+	})
+
+	t.Run("Drifted", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo")
+		destination := newStore(t, "destination")
+		path := writeFile(t, "jobs:"+jobYAML("foo", source, destination, ""))
+		out := filepath.Join(t.TempDir(), "plan.json")
+
+		require.Equal(t, exitOK, run(ctx, []string{"plan", "-config", path, "-out", out}, &bytes.Buffer{}, &bytes.Buffer{}))
+		require.NoError(t, (&memory{store: destination}).Add(ctx, []string{"bar"}))
+
+		var stderr bytes.Buffer
+
+		code := run(ctx, []string{"apply", out}, &bytes.Buffer{}, &stderr)
+
+		assert.Equal(t, exitDrifted, code)
+		assert.Contains(t, stderr.String(), "drifted since the plan was made")
+		assert.Equal(t, []string{"bar"}, storeThings(destination))
+	})
+
+	t.Run("Changed job file", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo")
+		destination := newStore(t, "destination")
+		path := writeFile(t, "jobs:"+jobYAML("foo", source, destination, ""))
+		out := filepath.Join(t.TempDir(), "plan.json")
+
+		require.Equal(t, exitOK, run(ctx, []string{"plan", "-config", path, "-out", out}, &bytes.Buffer{}, &bytes.Buffer{}))
+		require.NoError(t, os.WriteFile(path, []byte("jobs:"+jobYAML("foo", source, destination, `
+    operating_mode: Remove
+`)), 0o600))
+
+		var stderr bytes.Buffer
+
+		code := run(ctx, []string{"apply", out}, &bytes.Buffer{}, &stderr)
+
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr.String(), "has changed since the plan was made")
+		assert.Empty(t, storeThings(destination))
+	})
+
+	t.Run("Failed plan", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo")
+		destination := newStore(t, "destination")
+		path := writeFile(t, fmt.Sprintf(`
+jobs:
+  - name: failing
+    source: {adapter: test/memory, config: {store: %q, fail: true}}
+    destinations: [{adapter: test/memory, config: {store: %q}}]
+`, source, destination))
+		out := filepath.Join(t.TempDir(), "plan.json")
+
+		code := run(ctx, []string{"plan", "-config", path, "-out", out}, &bytes.Buffer{}, &bytes.Buffer{})
+		assert.Equal(t, exitFailure, code)
+
+		// Plans with errors are still written, but can't be applied.
+		var stderr bytes.Buffer
+
+		code = run(ctx, []string{"apply", out}, &bytes.Buffer{}, &stderr)
+
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr.String(), "invalid plan")
+	})
+
+	t.Run("Dry run job", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo")
+		destination := newStore(t, "destination")
+		path := writeFile(t, "jobs:"+jobYAML("foo", source, destination, `
+    dry_run: true
+`))
+		out := filepath.Join(t.TempDir(), "plan.json")
+
+		require.Equal(t, exitOK, run(ctx, []string{"plan", "-config", path, "-out", out}, &bytes.Buffer{}, &bytes.Buffer{}))
+
+		var stderr bytes.Buffer
+
+		code := run(ctx, []string{"apply", out}, &bytes.Buffer{}, &stderr)
+
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr.String(), "dry_run")
+		assert.Empty(t, storeThings(destination))
+	})
+
+	t.Run("Usage", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFile(t, "jobs:"+jobYAML("foo", "source", "destination", ""))
+		invalid := filepath.Join(t.TempDir(), "invalid.json")
+		require.NoError(t, os.WriteFile(invalid, []byte(`{"version": 99}`), 0o600))
+
+		for name, args := range map[string][]string{
+			"Plan unknown format": {"plan", "-config", path, "-format", "yaml"},
+			"Plan unknown job":    {"plan", "-config", path, "bar"},
+			"Apply missing plan":  {"apply"},
+			"Apply missing file":  {"apply", filepath.Join(t.TempDir(), "missing.json")},
+			"Apply version":       {"apply", invalid},
+		} {
+			var stderr bytes.Buffer
+
+			assert.Equal(t, exitUsage, run(ctx, args, &bytes.Buffer{}, &stderr), name)
+			assert.NotEmpty(t, stderr.String(), name)
+		}
+	})
+}
+
+func TestStagedChange_Apply(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	source := newStore(t, "source", "foo", "bar")
+	destination := newStore(t, "destination", "baz")
+	adapter := &memory{store: destination}
+
+	// The plan removed two things and added one, but the destination now needs one removed and two added.
+	change := stagedChange{
+		planned: plannedDestination{Job: "foo", DestinationReport: gosync.DestinationReport{
+			Adapter: "test/memory",
+			Target:  destination,
+			Mode:    gosync.RemoveAdd,
+			Changes: []gosync.Change{
+				{Operation: gosync.PhaseRemove, Count: 2, Things: []string{"baz", "qux"}},
+				{Operation: gosync.PhaseAdd, Count: 1, Things: []string{"foo"}},
+			},
+		}},
+		syncService: gosync.New(&memory{store: source}),
+		destination: adapter,
+	}
+
+	report := &gosync.Report{}
+
+	assert.Equal(t, exitSafeguard, change.apply(ctx, report))
+	assert.Equal(t, []string{}, storeThings(destination))
+	require.Len(t, report.Destinations, 1)
+	require.Len(t, report.Destinations[0].Changes, 2)
+	assert.NotEmpty(t, report.Destinations[0].Changes[1].Safeguard)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// Exit codes, which distinguish failures, safeguards and runs without any changes. See the package docs.
+const (
+	exitOK        = 0 // Things were changed, would have been in dry run mode, or adapters didn't differ.
+	exitFailure   = 1 // A job failed.
+	exitUsage     = 2 // The command line or job file was invalid.
+	exitSafeguard = 3 // A safeguard stopped a job.
+	exitNoChanges = 4 // There was nothing to change.
+	exitDrifted   = 5 // A plan wasn't applied, because destinations had drifted since it was made.
+	exitDiffered  = 6 // The adapters that were diffed differed.
+)
+
+// severity ranks the exit code of each job, so that the most severe is returned when many jobs are run.
+var severity = map[int]int{exitNoChanges: 0, exitOK: 1, exitSafeguard: 2, exitFailure: 3} //nolint:gochecknoglobals
+
+// reportFormats are the formats that the report can be written in.
+var reportFormats = []gosync.ReportFormat{ //nolint:gochecknoglobals
+	gosync.ReportTable,
+	gosync.ReportJSON,
+	gosync.ReportMarkdown,
+}
+
+// usage describes the commands.
+const usage = `Usage: go-sync <command> [flags]
+
+Commands:
+  run    Run the jobs in a YAML job file.
+  plan   Write the changes of the jobs in a YAML job file to a plan file.
+  apply  Make the changes in a plan file, unless destinations have drifted.
+  diff   Show the difference between two adapters in a YAML job file.
+
+Run "go-sync <command> -h" for the flags of a command.
+`
+
+// run parses the command line, runs the command and returns its exit code.
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "run":
+		return runJobs(ctx, args[1:], stdout, stderr)
+	case "plan":
+		return planJobs(ctx, args[1:], stdout, stderr)
+	case "apply":
+		return applyPlan(ctx, args[1:], stdout, stderr)
+	case "diff":
+		return diffAdapters(ctx, args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "Unknown command %q.\n\n%s", args[0], usage)
+
+		return exitUsage
+	}
+}
+
+// newFlagSet returns the flags of a command, with usage that describes its arguments.
+func newFlagSet(name string, arguments string, description string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: go-sync %s %s\n\n%s\n\n", name, arguments, description)
+		flags.PrintDefaults()
+	}
+
+	return flags
+}
+
+// parseFlags parses a command's flags and checks its output format. If the command shouldn't continue, it returns
+// false with the exit code.
+func parseFlags(flags *flag.FlagSet, args []string, format *string, stderr io.Writer) (int, bool) {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, false
+		}
+
+		return exitUsage, false
+	}
+
+	if !slices.Contains(reportFormats, gosync.ReportFormat(*format)) {
+		fmt.Fprintf(stderr, "Unknown report format %q.\n", *format)
+
+		return exitUsage, false
+	}
+
+	return exitOK, true
+}
+
+// loadJobs loads a job file and selects jobs from it, writing any problems to stderr.
+func loadJobs(path string, selectors []string, stderr io.Writer) ([]jobConfig, bool) {
+	file, err := loadFile(path)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return nil, false
+	}
+
+	jobs, err := file.selectJobs(selectors)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return nil, false
+	}
+
+	return jobs, true
+}
+
+// runJobs runs the selected jobs in a job file once each, and writes a report of their changes.
+func runJobs(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := newFlagSet("run", "[flags] [job...]", "Run every job in a YAML job file, or the selected jobs.", stderr)
+
+	path := flags.String("config", "go-sync.yaml", "`path` to the YAML job file")
+	dryRun := flags.Bool("dry-run", false, "compute the changes of every job, without making them")
+	format := flags.String("format", string(gosync.ReportTable), "`format` of the report: table, json or markdown")
+
+	if code, ok := parseFlags(flags, args, format, stderr); !ok {
+		return code
+	}
+
+	jobs, ok := loadJobs(*path, flags.Args(), stderr)
+	if !ok {
+		return exitUsage
+	}
+
+	options := make([]func(*gosync.Sync), 0, 1)
+	if *dryRun {
+		options = append(options, func(s *gosync.Sync) {
+			s.DryRun = true
+		})
+	}
+
+	report := &gosync.Report{}
+	code := exitNoChanges
+
+	for _, job := range jobs {
+		if jobCode := runJob(ctx, job, report, options...); severity[jobCode] > severity[code] {
+			code = jobCode
+		}
+	}
+
+	if err := report.Render(stdout, gosync.ReportFormat(*format)); err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitFailure
+	}
+
+	return code
+}
+
+// runJob builds a job and runs it once, adding its changes to the report, and returns its exit code.
+func runJob(ctx context.Context, config jobConfig, report *gosync.Report, options ...func(*gosync.Sync)) int {
+	logger := slog.Default().With(slog.String(gosync.LogKeyJob, config.Name))
+	jobReport := &gosync.Report{}
+
+	job, err := config.build(ctx, append(slices.Clone(options), gosync.WithReport(jobReport))...)
+	if err != nil {
+		logger.Error("Job failed", slog.Any("error", err))
+
+		return exitFailure
+	}
+
+	err = gosync.NewRunner([]gosync.Job{job}).RunOnce(ctx)
+
+	report.Destinations = append(report.Destinations, jobReport.Destinations...)
+
+	code := exitCode(gosync.Summarise(job.Name, jobReport, err), err)
+
+	switch code {
+	case exitFailure, exitSafeguard:
+		logger.Error("Job failed", slog.Any("error", err))
+	case exitNoChanges:
+		logger.Info("Job finished without any changes")
+	default:
+		logger.Info("Job finished successfully")
+	}
+
+	return code
+}
+
+// exitCode returns the exit code for the outcome of a job.
+func exitCode(summary gosync.Summary, err error) int {
+	if errors.Is(err, gosync.ErrTooManyChanges) {
+		return exitSafeguard
+	}
+
+	if err != nil {
+		return exitFailure
+	}
+
+	if summary.Changed() {
+		return exitOK
+	}
+
+	return exitNoChanges
+}
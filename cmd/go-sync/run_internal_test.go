@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jobYAML returns a job that synchronises one test/memory store with another.
+func jobYAML(name string, source string, destination string, options string) string {
+	return fmt.Sprintf(`
+  - name: %s
+    source: {adapter: test/memory, config: {store: %q}}
+    destinations: [{adapter: test/memory, config: {store: %q}}]
+%s`, name, source, destination, options)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Changes", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo", "bar")
+		destination := newStore(t, "destination", "bar", "baz")
+		path := writeFile(t, "jobs:"+jobYAML("foo", source, destination, ""))
+
+		var stdout, stderr bytes.Buffer
+
+		code := run(ctx, []string{"run", "-config", path, "-format", "json"}, &stdout, &stderr)
+
+		assert.Equal(t, exitOK, code, stderr.String())
+		assert.Equal(t, []string{"bar", "foo"}, storeThings(destination))
+
+		var report struct {
+			Destinations []struct {
+				Target  string `json:"target"`
+				Changes []struct {
+					Operation string   `json:"operation"`
+					Things    []string `json:"things"`
+				} `json:"changes"`
+			} `json:"destinations"`
+		}
+
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+		require.Len(t, report.Destinations, 1)
+		assert.Equal(t, destination, report.Destinations[0].Target)
+		assert.Len(t, report.Destinations[0].Changes, 2)
+	})
+
+	t.Run("No changes", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo")
+		destination := newStore(t, "destination", "foo")
+		path := writeFile(t, "jobs:"+jobYAML("foo", source, destination, ""))
+
+		code := run(ctx, []string{"run", "-config", path}, &bytes.Buffer{}, &bytes.Buffer{})
+
+		assert.Equal(t, exitNoChanges, code)
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo")
+		destination := newStore(t, "destination", "bar")
+		path := writeFile(t, "jobs:"+jobYAML("foo", source, destination, ""))
+
+		var stdout bytes.Buffer
+
+		code := run(ctx, []string{"run", "-config", path, "-dry-run"}, &stdout, &bytes.Buffer{})
+
+		// Changes that would be made count as changes.
+		assert.Equal(t, exitOK, code)
+		assert.Equal(t, []string{"bar"}, storeThings(destination))
+		assert.Contains(t, stdout.String(), destination)
+	})
+
+	t.Run("Job options", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "FOO")
+		destination := newStore(t, "destination", "foo", "bar")
+		path := writeFile(t, "jobs:"+jobYAML("foo", source, destination, `
+    operating_mode: Add
+    case_sensitive: false
+`))
+
+		code := run(ctx, []string{"run", "-config", path}, &bytes.Buffer{}, &bytes.Buffer{})
+
+		assert.Equal(t, exitNoChanges, code)
+		assert.Equal(t, []string{"bar", "foo"}, storeThings(destination))
+	})
+
+	t.Run("Secret references", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo")
+		destination := newStore(t, "destination")
+		reference := filepath.Join(t.TempDir(), "store")
+		require.NoError(t, os.WriteFile(reference, []byte(destination+"\n"), 0o600))
+
+		path := writeFile(t, "jobs:"+jobYAML("foo", source, "file://"+reference, ""))
+
+		var stderr bytes.Buffer
+
+		code := run(ctx, []string{"run", "-config", path}, &bytes.Buffer{}, &stderr)
+
+		assert.Equal(t, exitOK, code, stderr.String())
+		assert.Equal(t, []string{"foo"}, storeThings(destination))
+	})
+
+	t.Run("Safeguard", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo", "bar", "baz")
+		destination := newStore(t, "destination")
+		path := writeFile(t, "jobs:"+jobYAML("foo", source, destination, `
+    maximum_changes: 2
+`))
+
+		code := run(ctx, []string{"run", "-config", path}, &bytes.Buffer{}, &bytes.Buffer{})
+
+		assert.Equal(t, exitSafeguard, code)
+		assert.Empty(t, storeThings(destination))
+	})
+
+	t.Run("Selected jobs", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo")
+		selected := newStore(t, "selected")
+		skipped := newStore(t, "skipped")
+		path := writeFile(t, "jobs:"+
+			jobYAML("platform-a", source, selected, "")+
+			jobYAML("billing", source, skipped, ""))
+
+		code := run(ctx, []string{"run", "-config", path, "platform-*"}, &bytes.Buffer{}, &bytes.Buffer{})
+
+		assert.Equal(t, exitOK, code)
+		assert.Equal(t, []string{"foo"}, storeThings(selected))
+		assert.Empty(t, storeThings(skipped))
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		t.Parallel()
+
+		source := newStore(t, "source", "foo")
+		destination := newStore(t, "destination")
+		path := writeFile(t, fmt.Sprintf(`
+jobs:
+  - name: failing
+    source: {adapter: test/memory, config: {store: %q, fail: true}}
+    destinations: [{adapter: test/memory, config: {store: %q}}]
+`, source, destination)+jobYAML("working", source, destination, ""))
+
+		code := run(ctx, []string{"run", "-config", path}, &bytes.Buffer{}, &bytes.Buffer{})
+
+		// The failure is the most severe outcome, but later jobs still run.
+		assert.Equal(t, exitFailure, code)
+		assert.Equal(t, []string{"foo"}, storeThings(destination))
+	})
+
+	t.Run("Usage", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFile(t, "jobs:"+jobYAML("foo", "source", "destination", ""))
+
+		for name, args := range map[string][]string{
+			"No command":      {},
+			"Unknown command": {"foo"},
+			"Unknown flag":    {"run", "-foo"},
+			"Unknown format":  {"run", "-config", path, "-format", "yaml"},
+			"Missing file":    {"run", "-config", filepath.Join(t.TempDir(), "missing.yaml")},
+			"Unknown job":     {"run", "-config", path, "bar"},
+		} {
+			var stderr bytes.Buffer
+
+			assert.Equal(t, exitUsage, run(ctx, args, &bytes.Buffer{}, &stderr), name)
+			assert.NotEmpty(t, stderr.String(), name)
+		}
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout bytes.Buffer
+
+		assert.Equal(t, exitOK, run(ctx, []string{"help"}, &stdout, &bytes.Buffer{}))
+		assert.Contains(t, stdout.String(), "Usage: go-sync <command>")
+	})
+}
@@ -0,0 +1,119 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+)
+
+/*
+Discoverer is an optional interface for adapter packages that can find their own targets, such as every GitHub team in
+an organisation. Discover returns an initialised adapter for each target whose name matches the selector, keyed by
+the target's name.
+
+Selectors are glob patterns, as matched by [MatchSelector]. For example, `platform-*` matches `platform-foo` but not
+`data-platform`.
+*/
+type Discoverer interface {
+	Discover(ctx context.Context, selector string) (map[string]Adapter, error)
+}
+
+// MatchSelector reports whether the name of a target matches a selector, using the pattern syntax of [path.Match].
+func MatchSelector(selector string, name string) (bool, error) {
+	matched, err := path.Match(selector, name)
+	if err != nil {
+		return false, fmt.Errorf("%w(selector %s): %w", ErrInvalidConfig, selector, err)
+	}
+
+	return matched, nil
+}
+
+// SourceFn returns the source adapter for a discovered destination, given the destination's name.
+type SourceFn func(ctx context.Context, name string) (Adapter, error)
+
+// StaticSource returns a SourceFn that synchronises every discovered destination with the same source.
+func StaticSource(source Adapter) SourceFn {
+	return func(context.Context, string) (Adapter, error) {
+		return source, nil
+	}
+}
+
+// Discovery describes the destinations to find with a Discoverer, and where their sources come from.
+type Discovery struct {
+	Discoverer Discoverer // Discoverer finds the destinations.
+	Selector   string     // Selector that the names of destinations must match, e.g. `platform-*`.
+	Source     SourceFn   // Source derives the source adapter for each destination.
+}
+
+/*
+FanOut synchronises every destination found by a Discovery with its own source, creating Sync services with the given
+options. Destinations are discovered each time FanOut is called, so new targets are picked up without any changes.
+
+	err := gosync.FanOut(ctx, gosync.Discovery{
+		Discoverer: discoverer,
+		Selector:   "platform-*",
+		Source: func(ctx context.Context, name string) (gosync.Adapter, error) {
+			return groupmembership.Init(ctx, map[gosync.ConfigKey]string{groupmembership.GroupName: name})
+		},
+	})
+
+If a destination fails to sync, the remaining destinations are still synchronised, and every error is returned.
+Destinations that share a source instance share a Sync service, so the source is only fetched once.
+*/
+func FanOut(ctx context.Context, discovery Discovery, optsFn ...func(*Sync)) error {
+	return fanOut(ctx, discovery, optsFn, func(syncService *Sync, _ string, destination Adapter) error {
+		return syncService.SyncWith(ctx, destination)
+	})
+}
+
+// fanOut calls fn with a Sync service for every destination found by a Discovery, and joins the errors it returns.
+func fanOut(
+	ctx context.Context,
+	discovery Discovery,
+	optsFn []func(*Sync),
+	fn func(syncService *Sync, name string, destination Adapter) error,
+) error {
+	if discovery.Discoverer == nil || discovery.Source == nil {
+		return fmt.Errorf("fanout -> %w(discoverer, source)", ErrMissingConfig)
+	}
+
+	destinations, err := discovery.Discoverer.Discover(ctx, discovery.Selector)
+	if err != nil {
+		return fmt.Errorf("fanout.discover(%s) -> %w", discovery.Selector, err)
+	}
+
+	syncs := make(map[Adapter]*Sync)
+	errs := make([]error, 0)
+
+	for _, name := range sortedKeys(destinations) {
+		source, err := discovery.Source(ctx, name)
+		if err == nil && source == nil {
+			err = ErrMissingConfig
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fanout.source(%s) -> %w", name, err))
+
+			continue
+		}
+
+		// Only comparable sources can be shared, as they're used as map keys.
+		syncService := New(source, optsFn...)
+
+		if reflect.TypeOf(source).Comparable() {
+			if existing, ok := syncs[source]; ok {
+				syncService = existing
+			} else {
+				syncs[source] = syncService
+			}
+		}
+
+		if err = fn(syncService, name, destinations[name]); err != nil {
+			errs = append(errs, fmt.Errorf("fanout.syncwith(%s) -> %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
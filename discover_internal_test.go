@@ -0,0 +1,214 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticDiscoverer is a Discoverer with a fixed set of destinations, which are filtered by the selector.
+type staticDiscoverer struct {
+	destinations map[string]Adapter
+	err          error
+}
+
+func (s *staticDiscoverer) Discover(_ context.Context, selector string) (map[string]Adapter, error) {
+	out := make(map[string]Adapter)
+
+	for name, adapter := range s.destinations {
+		matched, err := MatchSelector(selector, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			out[name] = adapter
+		}
+	}
+
+	return out, s.err
+}
+
+func TestMatchSelector(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		selector string
+		name     string
+		matched  bool
+		want     error
+	}{
+		"Prefix":    {selector: "platform-*", name: "platform-foo", matched: true},
+		"No match":  {selector: "platform-*", name: "data-platform", matched: false},
+		"Exact":     {selector: "oncall", name: "oncall", matched: true},
+		"Character": {selector: "team-?", name: "team-a", matched: true},
+		"Invalid":   {selector: "team-[", name: "team-a", want: ErrInvalidConfig},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			matched, err := MatchSelector(test.selector, test.name)
+
+			require.ErrorIs(t, err, test.want)
+			assert.Equal(t, test.matched, matched)
+		})
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Static source", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Once().Return([]string{"foo"}, nil)
+
+		foo := NewMockAdapter(t)
+		foo.EXPECT().Get(ctx).Return([]string{}, nil)
+		foo.EXPECT().Add(ctx, []string{"foo"}).Return(nil)
+
+		bar := NewMockAdapter(t)
+		bar.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		discoverer := &staticDiscoverer{destinations: map[string]Adapter{
+			"platform-foo":  foo,
+			"platform-bar":  bar,
+			"data-platform": NewMockAdapter(t),
+		}}
+
+		err := FanOut(ctx, Discovery{Discoverer: discoverer, Selector: "platform-*", Source: StaticSource(source)})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Derived sources", func(t *testing.T) {
+		t.Parallel()
+
+		newItem := func(key string) Item {
+			return Item{ID: key}
+		}
+
+		sources := map[string]*memoryAdapter[Item]{
+			"foo": {things: []Item{{ID: "alice"}}},
+			"bar": {things: []Item{{ID: "bob"}}},
+		}
+		destinations := map[string]*memoryAdapter[Item]{"foo": {}, "bar": {}}
+
+		discoverer := &staticDiscoverer{destinations: map[string]Adapter{
+			"foo": Untyped[Item](destinations["foo"], newItem),
+			"bar": Untyped[Item](destinations["bar"], newItem),
+		}}
+
+		err := FanOut(ctx, Discovery{
+			Discoverer: discoverer,
+			Selector:   "*",
+			Source: func(_ context.Context, name string) (Adapter, error) {
+				return Untyped[Item](sources[name], newItem), nil
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Item{{ID: "alice"}}, destinations["foo"].added)
+		assert.Equal(t, []Item{{ID: "bob"}}, destinations["bar"].added)
+	})
+
+	t.Run("Continues after errors", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Once().Return([]string{"foo"}, nil)
+
+		foo := NewMockAdapter(t)
+		foo.EXPECT().Get(ctx).Return(nil, testErr)
+
+		bar := NewMockAdapter(t)
+		bar.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		discoverer := &staticDiscoverer{destinations: map[string]Adapter{"foo": foo, "bar": bar, "baz": nil}}
+
+		err := FanOut(ctx, Discovery{
+			Discoverer: discoverer,
+			Selector:   "*",
+			Source: func(_ context.Context, name string) (Adapter, error) {
+				if name == "baz" {
+					return nil, ErrNotFound
+				}
+
+				return source, nil
+			},
+		})
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, testErr)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, PhaseGet, syncErr.Phase)
+	})
+
+	t.Run("Discover error", func(t *testing.T) {
+		t.Parallel()
+
+		err := FanOut(ctx, Discovery{
+			Discoverer: &staticDiscoverer{destinations: map[string]Adapter{"team-a": NewMockAdapter(t)}},
+			Selector:   "team-[",
+			Source:     StaticSource(NewMockAdapter(t)),
+		})
+
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+
+	t.Run("Missing config", func(t *testing.T) {
+		t.Parallel()
+
+		err := FanOut(ctx, Discovery{Selector: "*"})
+
+		require.ErrorIs(t, err, ErrMissingConfig)
+	})
+}
+
+func TestRunner_RunOnce_Discovery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	source := NewMockAdapter(t)
+	source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+	destination := NewMockAdapter(t)
+	destination.EXPECT().Get(ctx).Once().Return([]string{"foo"}, nil)
+
+	discovered := NewMockAdapter(t)
+	discovered.EXPECT().Get(ctx).Once().Return([]string{}, nil)
+	discovered.EXPECT().Add(ctx, []string{"foo"}).Once().Return(nil)
+
+	runner := NewRunner([]Job{{
+		Name:         "foo",
+		Source:       source,
+		Destinations: []Adapter{destination},
+		Discovery: &Discovery{
+			Discoverer: &staticDiscoverer{destinations: map[string]Adapter{"platform-foo": discovered}},
+			Selector:   "platform-*",
+		},
+	}})
+
+	require.NoError(t, runner.RunOnce(ctx))
+
+	// A job's source can only be omitted if discovered destinations derive their own.
+	err := NewRunner([]Job{{
+		Name:      "bar",
+		Discovery: &Discovery{Discoverer: &staticDiscoverer{}, Selector: "*"},
+	}}).RunOnce(ctx)
+
+	require.ErrorIs(t, err, ErrMissingConfig)
+}
@@ -0,0 +1,356 @@
+package gosync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ErrDrift is returned by a drift check when any destination doesn't match its source.
+var ErrDrift = errors.New("drift detected")
+
+// DestinationDrift is the difference between a destination and its source, found by a drift check.
+type DestinationDrift struct {
+	Job        string     `json:"job,omitempty"`
+	Adapter    string     `json:"adapter"`
+	Target     string     `json:"target"`
+	Missing    []string   `json:"missing"`         // Missing things are in the source, but not the destination.
+	Unexpected []string   `json:"unexpected"`      // Unexpected things are in the destination, but not the source.
+	Since      *time.Time `json:"since,omitempty"` // Since drift was first seen, if the check has a DriftState.
+	Error      string     `json:"error,omitempty"` // Error that stopped the destination from being checked.
+}
+
+// Drifted returns true if the destination doesn't match its source.
+func (d DestinationDrift) Drifted() bool {
+	return len(d.Missing) > 0 || len(d.Unexpected) > 0
+}
+
+// name of the destination, e.g. `github/team(my-team)`.
+func (d DestinationDrift) name() string {
+	return fmt.Sprintf("%s(%s)", d.Adapter, d.Target)
+}
+
+// stateKey identifies the destination in a DriftState, e.g. `platform:github/team(my-team)`, as many jobs can
+// synchronise the same destination from different sources.
+func (d DestinationDrift) stateKey() string {
+	return d.Job + ":" + d.name()
+}
+
+// Drift is the result of a drift check across many destinations.
+type Drift struct {
+	CheckedAt    time.Time          `json:"checkedAt"`
+	Destinations []DestinationDrift `json:"destinations"`
+}
+
+// Drifted returns the number of destinations that don't match their source.
+func (d Drift) Drifted() int {
+	count := 0
+
+	for _, destination := range d.Destinations {
+		if destination.Drifted() {
+			count++
+		}
+	}
+
+	return count
+}
+
+/*
+Check compares the destination with the source, and returns the things that differ without calling Add or Remove.
+Unlike DryRun, the Sync's OperatingMode and MaximumChanges are ignored, so every difference is reported.
+CaseSensitive is still used to compare things, and the source is only fetched once.
+*/
+func (s *Sync) Check(ctx context.Context, adapter Adapter) (DestinationDrift, error) {
+	kind, target := Describe(adapter)
+	drift := DestinationDrift{Adapter: kind, Target: target, Missing: make([]string, 0), Unexpected: make([]string, 0)}
+
+	if sameAdapter(s.source, adapter) {
+		return drift, fmt.Errorf("sync.check -> %w: source and destination are the same adapter", ErrInvalidConfig)
+	}
+
+	if err := s.generateCache(ctx); err != nil {
+		return drift, fmt.Errorf("sync.check.generateCache -> %w", err)
+	}
+
+	things, err := s.fetch(ctx, adapter)
+	if err != nil {
+		return drift, fmt.Errorf("sync.check.get -> %w", wrapError(adapter, PhaseGet, nil, err))
+	}
+
+	drift.Missing = append(drift.Missing, s.getThingsToAdd(things)...)
+	drift.Unexpected = append(drift.Unexpected, s.getThingsToRemove(things)...)
+
+	slices.Sort(drift.Missing)
+	slices.Sort(drift.Unexpected)
+
+	return drift, nil
+}
+
+/*
+DriftState stores when drift was first seen in each destination, so that a drift check can report how long it has
+existed. Destinations are keyed by their job and name, e.g. `platform:github/team(my-team)`.
+*/
+type DriftState interface {
+	Load(ctx context.Context) (map[string]time.Time, error)
+	Save(ctx context.Context, since map[string]time.Time) error
+}
+
+// FileDriftState returns a DriftState that is stored as JSON in a local file. The file is created if it doesn't exist.
+func FileDriftState(path string) DriftState { //nolint:ireturn
+	return &fileDriftState{path: path}
+}
+
+// fileDriftState is a DriftState stored in a JSON file.
+type fileDriftState struct {
+	path string
+}
+
+// Load the state from the file, which is empty if the file doesn't exist.
+func (f *fileDriftState) Load(_ context.Context) (map[string]time.Time, error) {
+	since := make(map[string]time.Time)
+
+	data, err := os.ReadFile(f.path)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return since, nil
+	case err != nil:
+		return nil, fmt.Errorf("driftstate.load -> %w", err)
+	}
+
+	if err = json.Unmarshal(data, &since); err != nil {
+		return nil, fmt.Errorf("driftstate.load -> %w", err)
+	}
+
+	return since, nil
+}
+
+// Save the state to the file.
+func (f *fileDriftState) Save(_ context.Context, since map[string]time.Time) error {
+	data, err := json.MarshalIndent(since, "", "  ")
+	if err != nil {
+		return fmt.Errorf("driftstate.save -> %w", err)
+	}
+
+	if err = os.WriteFile(f.path, data, 0o600); err != nil { //nolint:gomnd,mnd
+		return fmt.Errorf("driftstate.save -> %w", err)
+	}
+
+	return nil
+}
+
+/*
+Check fetches the source and destinations of every job, including waves and discovered destinations, and reports drift
+without changing anything. It's intended for scheduled compliance jobs, which can fail if any destination has drifted.
+
+Check returns an error wrapping ErrDrift if any destination has drifted, joined with errors for destinations that
+couldn't be checked. If the Runner has a DriftState, each drifted destination reports when its drift was first seen.
+*/
+func (r *Runner) Check(ctx context.Context) (Drift, error) {
+	drift := Drift{CheckedAt: time.Now(), Destinations: make([]DestinationDrift, 0)}
+
+	if err := r.validate(); err != nil {
+		return drift, fmt.Errorf("runner.check -> %w", err)
+	}
+
+	errs := make([]error, 0)
+
+	for _, job := range r.jobs {
+		check := func(syncService *Sync, destination Adapter) error {
+			result, err := syncService.Check(ctx, destination)
+			result.Job = job.Name
+
+			if err != nil {
+				result.Error = err.Error()
+			}
+
+			drift.Destinations = append(drift.Destinations, result)
+
+			return err
+		}
+
+		syncService := New(job.Source, job.Options...)
+
+		destinations := slices.Clone(job.Destinations)
+		for _, wave := range job.Waves {
+			destinations = append(destinations, wave.Destinations...)
+		}
+
+		for _, destination := range destinations {
+			if err := check(syncService, destination); err != nil {
+				errs = append(errs, fmt.Errorf("runner.check(%s) -> %w", job.Name, err))
+			}
+		}
+
+		if job.Discovery == nil {
+			continue
+		}
+
+		discovery := *job.Discovery
+		if discovery.Source == nil {
+			discovery.Source = StaticSource(job.Source)
+		}
+
+		err := fanOut(ctx, discovery, job.Options, func(syncService *Sync, _ string, destination Adapter) error {
+			return check(syncService, destination)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("runner.check(%s) -> %w", job.Name, err))
+		}
+	}
+
+	if err := r.trackDrift(ctx, &drift); err != nil {
+		errs = append(errs, fmt.Errorf("runner.check -> %w", err))
+	}
+
+	for _, destination := range drift.Destinations {
+		if destination.Drifted() {
+			r.Logger.Warn("Destination has drifted from its source",
+				slog.String(LogKeyJob, destination.Job),
+				slog.String(LogKeyAdapter, destination.Adapter),
+				slog.String(LogKeyTarget, destination.Target),
+				slog.Any("missing", destination.Missing),
+				slog.Any("unexpected", destination.Unexpected),
+			)
+		}
+	}
+
+	if count := drift.Drifted(); count > 0 {
+		errs = append(errs, fmt.Errorf("runner.check -> %w(%d destinations)", ErrDrift, count))
+	}
+
+	return drift, errors.Join(errs...)
+}
+
+/*
+trackDrift sets when drift was first seen in each drifted destination, using the Runner's DriftState. Destinations
+that no longer drift are removed from the state, and destinations that couldn't be checked keep their previous state.
+*/
+func (r *Runner) trackDrift(ctx context.Context, drift *Drift) error {
+	if r.DriftState == nil {
+		return nil
+	}
+
+	previous, err := r.DriftState.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("trackdrift -> %w", err)
+	}
+
+	since := make(map[string]time.Time)
+
+	for i, destination := range drift.Destinations {
+		key := destination.stateKey()
+		first, seen := previous[key]
+
+		switch {
+		case destination.Error != "":
+			if seen {
+				since[key] = first
+			}
+		case destination.Drifted():
+			if !seen {
+				first = drift.CheckedAt
+			}
+
+			since[key] = first
+			drift.Destinations[i].Since = &first
+		}
+	}
+
+	if err = r.DriftState.Save(ctx, since); err != nil {
+		return fmt.Errorf("trackdrift -> %w", err)
+	}
+
+	return nil
+}
+
+// Render writes the drift to w in the given format.
+func (d Drift) Render(w io.Writer, format ReportFormat) error {
+	var err error
+
+	switch format {
+	case ReportJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		err = encoder.Encode(d)
+	case ReportMarkdown:
+		err = d.renderMarkdown(w)
+	case ReportTable:
+		err = d.renderTable(w)
+	default:
+		return fmt.Errorf("drift.render -> %w(format %s)", ErrInvalidConfig, format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("drift.render(%s) -> %w", format, err)
+	}
+
+	return nil
+}
+
+// status summarises a destination's drift, and how long it has existed.
+func (d DestinationDrift) status(checkedAt time.Time) string {
+	switch {
+	case d.Error != "":
+		return "error: " + d.Error
+	case !d.Drifted():
+		return "in sync"
+	case d.Since != nil:
+		return "drifted for " + checkedAt.Sub(*d.Since).Truncate(time.Second).String()
+	default:
+		return "drifted"
+	}
+}
+
+// renderMarkdown writes the drift as a Markdown table.
+func (d Drift) renderMarkdown(w io.Writer) error {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "## Go Sync drift\n\n%d of %d destinations have drifted.\n\n", d.Drifted(),
+		len(d.Destinations))
+
+	if len(d.Destinations) > 0 {
+		builder.WriteString("| Job | Destination | Missing | Unexpected | Status |\n| --- | --- | --- | --- | --- |\n")
+	}
+
+	code := func(things []string) string {
+		if len(things) == 0 {
+			return ""
+		}
+
+		return "`" + strings.Join(things, "`, `") + "`"
+	}
+
+	for _, destination := range d.Destinations {
+		fmt.Fprintf(&builder, "| %s | `%s` | %s | %s | %s |\n", destination.Job, destination.name(),
+			code(destination.Missing), code(destination.Unexpected),
+			markdownEscape(destination.status(d.CheckedAt)))
+	}
+
+	_, err := io.WriteString(w, builder.String())
+
+	return err //nolint:wrapcheck
+}
+
+// renderTable writes the drift as a plain text table.
+func (d Drift) renderTable(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint:gomnd,mnd
+
+	fmt.Fprintln(table, "JOB\tDESTINATION\tMISSING\tUNEXPECTED\tSTATUS")
+
+	for _, destination := range d.Destinations {
+		fmt.Fprintf(table, "%s\t%s\t%d\t%d\t%s\n", destination.Job, destination.name(),
+			len(destination.Missing), len(destination.Unexpected), destination.status(d.CheckedAt))
+	}
+
+	return table.Flush() //nolint:wrapcheck
+}
@@ -0,0 +1,275 @@
+package gosync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync_Check(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Reports drift without changing anything", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Once().Return([]string{"foo", "bar"}, nil)
+
+		// Add and Remove aren't expected, so the mock fails the test if they're called.
+		destination := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "team"}
+		destination.EXPECT().Get(ctx).Return([]string{"bar", "baz", "qux"}, nil)
+
+		// The operating mode doesn't limit what's reported.
+		syncService := New(source, func(s *Sync) {
+			s.OperatingMode = AddOnly
+		})
+
+		drift, err := syncService.Check(ctx, destination)
+
+		require.NoError(t, err)
+		assert.Equal(t, DestinationDrift{
+			Adapter:    "test/named",
+			Target:     "team",
+			Missing:    []string{"foo"},
+			Unexpected: []string{"baz", "qux"},
+		}, drift)
+		assert.True(t, drift.Drifted())
+	})
+
+	t.Run("Case insensitive", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"Foo"}, nil)
+
+		destination := NewMockAdapter(t)
+		destination.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		drift, err := New(source, func(s *Sync) {
+			s.CaseSensitive = false
+		}).Check(ctx, destination)
+
+		require.NoError(t, err)
+		assert.False(t, drift.Drifted())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		destination := NewMockAdapter(t)
+		destination.EXPECT().Get(ctx).Return(nil, testErr)
+
+		_, err := New(source).Check(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, testErr)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, PhaseGet, syncErr.Phase)
+	})
+}
+
+func TestRunner_Check(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	newRunner := func(t *testing.T, state DriftState, broken bool) *Runner {
+		t.Helper()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		inSync := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "in-sync"}
+		inSync.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		drifted := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "drifted"}
+		if broken {
+			drifted.EXPECT().Get(ctx).Return(nil, errors.New("foo")) //nolint:goerr113
+		} else {
+			drifted.EXPECT().Get(ctx).Return([]string{"bar"}, nil)
+		}
+
+		discovered := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "discovered"}
+		discovered.EXPECT().Get(ctx).Return([]string{}, nil)
+
+		return NewRunner([]Job{
+			{Name: "foo", Source: source, Destinations: []Adapter{inSync}},
+			{Name: "bar", Source: source, Destinations: []Adapter{drifted}, Discovery: &Discovery{
+				Discoverer: &staticDiscoverer{destinations: map[string]Adapter{"discovered": discovered}},
+				Selector:   "*",
+			}},
+		}, func(r *Runner) {
+			r.DriftState = state
+		})
+	}
+
+	t.Run("Reports drift", func(t *testing.T) {
+		t.Parallel()
+
+		drift, err := newRunner(t, nil, false).Check(ctx)
+
+		require.ErrorIs(t, err, ErrDrift)
+		require.Len(t, drift.Destinations, 3)
+		assert.Equal(t, 2, drift.Drifted())
+		assert.Equal(t, "foo", drift.Destinations[0].Job)
+		assert.False(t, drift.Destinations[0].Drifted())
+		assert.Equal(t, []string{"foo"}, drift.Destinations[1].Missing)
+		assert.Equal(t, []string{"bar"}, drift.Destinations[1].Unexpected)
+		assert.Equal(t, "discovered", drift.Destinations[2].Target)
+		assert.Nil(t, drift.Destinations[1].Since)
+	})
+
+	t.Run("Tracks how long drift has existed", func(t *testing.T) {
+		t.Parallel()
+
+		state := FileDriftState(filepath.Join(t.TempDir(), "drift.json"))
+		firstSeen := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		require.NoError(t, state.Save(ctx, map[string]time.Time{
+			"bar:test/named(drifted)": firstSeen,
+			"foo:test/named(in-sync)": firstSeen,
+		}))
+
+		drift, err := newRunner(t, state, false).Check(ctx)
+
+		require.ErrorIs(t, err, ErrDrift)
+		assert.True(t, firstSeen.Equal(*drift.Destinations[1].Since))
+		assert.Equal(t, drift.CheckedAt, *drift.Destinations[2].Since)
+
+		since, err := state.Load(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, since, 2)
+		assert.True(t, firstSeen.Equal(since["bar:test/named(drifted)"]))
+		assert.True(t, drift.CheckedAt.Equal(since["bar:test/named(discovered)"]))
+	})
+
+	t.Run("Destinations that can't be checked keep their state", func(t *testing.T) {
+		t.Parallel()
+
+		state := FileDriftState(filepath.Join(t.TempDir(), "drift.json"))
+		firstSeen := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		require.NoError(t, state.Save(ctx, map[string]time.Time{"bar:test/named(drifted)": firstSeen}))
+
+		drift, err := newRunner(t, state, true).Check(ctx)
+
+		require.ErrorIs(t, err, ErrDrift)
+		assert.Contains(t, drift.Destinations[1].Error, "foo")
+
+		since, err := state.Load(ctx)
+
+		require.NoError(t, err)
+		assert.True(t, firstSeen.Equal(since["bar:test/named(drifted)"]))
+	})
+
+	t.Run("Tracks a destination separately for each job", func(t *testing.T) {
+		t.Parallel()
+
+		state := FileDriftState(filepath.Join(t.TempDir(), "drift.json"))
+		firstSeen := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		require.NoError(t, state.Save(ctx, map[string]time.Time{"foo:test/named(shared)": firstSeen}))
+
+		inSync := NewMockAdapter(t)
+		inSync.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		drifted := NewMockAdapter(t)
+		drifted.EXPECT().Get(ctx).Return([]string{"bar"}, nil)
+
+		shared := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "shared"}
+		shared.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		drift, err := NewRunner([]Job{
+			{Name: "foo", Source: inSync, Destinations: []Adapter{shared}},
+			{Name: "bar", Source: drifted, Destinations: []Adapter{shared}},
+		}, func(r *Runner) {
+			r.DriftState = state
+		}).Check(ctx)
+
+		require.ErrorIs(t, err, ErrDrift)
+		assert.Nil(t, drift.Destinations[0].Since)
+		assert.Equal(t, drift.CheckedAt, *drift.Destinations[1].Since)
+
+		since, err := state.Load(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, since, 1)
+		assert.True(t, drift.CheckedAt.Equal(since["bar:test/named(shared)"]))
+	})
+}
+
+func TestDrift_Render(t *testing.T) {
+	t.Parallel()
+
+	checkedAt := time.Date(2024, 6, 14, 12, 0, 0, 0, time.UTC)
+	since := checkedAt.Add(-26 * time.Hour)
+	drift := Drift{
+		CheckedAt: checkedAt,
+		Destinations: []DestinationDrift{
+			{Job: "foo", Adapter: "github/team", Target: "platform", Missing: []string{"alice"},
+				Unexpected: []string{"bob", "carol"}, Since: &since},
+			{Job: "foo", Adapter: "slack/usergroup", Target: "S123", Missing: []string{}, Unexpected: []string{}},
+		},
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, drift.Render(&buf, ReportJSON))
+
+		var decoded Drift
+
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, drift.Destinations[0].Unexpected, decoded.Destinations[0].Unexpected)
+		assert.True(t, since.Equal(*decoded.Destinations[0].Since))
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, drift.Render(&buf, ReportMarkdown))
+		assert.Contains(t, buf.String(), "1 of 2 destinations have drifted.")
+		assert.Contains(t, buf.String(),
+			"| foo | `github/team(platform)` | `alice` | `bob`, `carol` | drifted for 26h0m0s |\n")
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, drift.Render(&buf, ReportTable))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+		require.Len(t, lines, 3)
+		assert.Equal(t, []string{"foo", "slack/usergroup(S123)", "0", "0", "in", "sync"}, strings.Fields(lines[2]))
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, drift.Render(&bytes.Buffer{}, "xml"), ErrInvalidConfig)
+	})
+}
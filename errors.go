@@ -1,6 +1,9 @@
 package gosync
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // ErrNotImplemented is for brand-new adapters that are still being worked on.
 //
@@ -13,11 +16,106 @@ var ErrCacheEmpty = errors.New("cache is empty, run Get first")
 // ErrReadOnly is returned for adapters that cannot Add/Remove, but have been set as a destination.
 var ErrReadOnly = errors.New("cannot perform action, adapter is readonly")
 
+// ErrUnsupported is returned when an adapter doesn't support an operation that Sync needs to perform.
+var ErrUnsupported = errors.New("operation is not supported by adapter")
+
+// ErrMissingPermission is returned by Validate when an adapter's credentials don't grant a required permission.
+var ErrMissingPermission = errors.New("missing permission")
+
+// ErrNotFound is returned by Validate when the target of an adapter doesn't exist, or can't be accessed.
+var ErrNotFound = errors.New("not found")
+
 // ErrMissingConfig is returned when an InitFn is missing a required configuration.
 var ErrMissingConfig = errors.New("missing configuration")
 
 // ErrInvalidConfig is returned when an InitFn is passed an invalid configuration.
 var ErrInvalidConfig = errors.New("invalid configuration")
 
+// ErrNotRegistered is returned when an adapter is looked up by a name that hasn't been registered.
+var ErrNotRegistered = errors.New("adapter is not registered")
+
 // ErrTooManyChanges is returned when a change limit has been set, and the number of changes exceeds it.
 var ErrTooManyChanges = errors.New("too many changes")
+
+// Phase is the operation on an adapter that an Error occurred in.
+type Phase string
+
+const (
+	PhaseGet      Phase = "get"      // PhaseGet is a call to an adapter's Get method.
+	PhaseAdd      Phase = "add"      // PhaseAdd is a call to an adapter's Add method.
+	PhaseRemove   Phase = "remove"   // PhaseRemove is a call to an adapter's Remove method.
+	PhaseUpdate   Phase = "update"   // PhaseUpdate is a call to an adapter's Update method.
+	PhaseValidate Phase = "validate" // PhaseValidate is a call to an adapter's Validate method.
+	PhaseCreate   Phase = "create"   // PhaseCreate is a call to a GroupManager's CreateGroups method.
+	PhaseDelete   Phase = "delete"   // PhaseDelete is a call to a GroupManager's DeleteGroups method.
+)
+
+/*
+Error is returned by Sync and adapters when an operation fails, so callers can react to failures without parsing error
+strings. Use [errors.As] to retrieve it from an error chain, and [errors.Is] to check its cause against sentinel
+errors such as ErrCacheEmpty and ErrReadOnly.
+
+	var syncErr *gosync.Error
+	if errors.As(err, &syncErr) && syncErr.Phase == gosync.PhaseRemove {
+		log.Printf("couldn't remove %v from %s", syncErr.Things, syncErr.Target)
+	}
+*/
+type Error struct {
+	Phase  Phase    // Phase is the operation that failed.
+	Kind   string   // Kind of adapter that the operation failed on, as returned by Describe.
+	Target string   // Target of the adapter that the operation failed on, as returned by Describe.
+	Things []string // Things affected by the failed operation, if any.
+	Err    error    // Err is the underlying cause.
+}
+
+// NewError creates an Error for a failed operation on an adapter.
+func NewError(adapter Adapter, phase Phase, things []string, err error) *Error {
+	kind, target := Describe(adapter)
+
+	return &Error{Phase: phase, Kind: kind, Target: target, Things: things, Err: err}
+}
+
+/*
+NewGroupError creates an Error for a failed operation on a group in a MultiAdapter, which is the Error's target. Leave
+the group empty for operations on the whole MultiAdapter, such as Get or CreateGroups.
+*/
+func NewGroupError(adapter MultiAdapter, group string, phase Phase, things []string, err error) *Error {
+	return NewError(&multiGroup{adapter: adapter, group: group}, phase, things, err)
+}
+
+// Error formats the error as `kind(target).phase(things) -> cause`.
+func (e *Error) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString(e.Kind)
+
+	if e.Target != "" {
+		builder.WriteString("(" + e.Target + ")")
+	}
+
+	builder.WriteString("." + string(e.Phase))
+
+	if len(e.Things) > 0 {
+		builder.WriteString("(" + strings.Join(e.Things, ", ") + ")")
+	}
+
+	builder.WriteString(" -> ")
+	builder.WriteString(e.Err.Error())
+
+	return builder.String()
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// wrapError wraps an error from an adapter in an Error, unless the adapter has already returned one.
+func wrapError(adapter Adapter, phase Phase, things []string, err error) error {
+	var syncErr *Error
+	if errors.As(err, &syncErr) {
+		return err
+	}
+
+	return NewError(adapter, phase, things, err)
+}
@@ -0,0 +1,133 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	adapter := &describedAdapter{NewMockAdapter(t)}
+
+	err := NewError(adapter, PhaseRemove, []string{"foo", "bar"}, fmt.Errorf("kick -> %w", ErrReadOnly))
+
+	assert.Equal(t, "test/adapter(foo).remove(foo, bar) -> kick -> cannot perform action, adapter is readonly", err.Error())
+	require.ErrorIs(t, err, ErrReadOnly)
+
+	err = NewError(NewMockAdapter(t), PhaseGet, nil, ErrCacheEmpty)
+
+	assert.Equal(t, "*gosync.MockAdapter.get -> cache is empty, run Get first", err.Error())
+	require.ErrorIs(t, err, ErrCacheEmpty)
+}
+
+func TestNewGroupError(t *testing.T) {
+	t.Parallel()
+
+	adapter := newMemoryGroups(nil)
+
+	err := NewGroupError(adapter, "platform", PhaseAdd, []string{"foo"}, ErrNotFound)
+
+	assert.Equal(t, "test/groups(platform).add(foo) -> not found", err.Error())
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = NewGroupError(adapter, "", PhaseCreate, []string{"platform"}, ErrUnsupported)
+
+	assert.Equal(t, "test/groups.create(platform) -> "+ErrUnsupported.Error(), err.Error())
+}
+
+func TestSync_Error(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	testErr := errors.New("foo") //nolint:goerr113
+
+	t.Run("Source get", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return(nil, testErr)
+
+		err := New(source).SyncWith(ctx, NewMockAdapter(t))
+
+		var syncErr *Error
+
+		require.ErrorAs(t, err, &syncErr)
+		require.ErrorIs(t, err, testErr)
+		assert.Equal(t, PhaseGet, syncErr.Phase)
+		assert.Equal(t, "*gosync.MockAdapter", syncErr.Kind)
+	})
+
+	t.Run("Destination add", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		destination := &describedAdapter{NewMockAdapter(t)}
+		destination.EXPECT().Get(ctx).Return([]string{}, nil)
+		destination.EXPECT().Add(ctx, []string{"foo"}).Return(testErr)
+
+		err := New(source).SyncWith(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorAs(t, err, &syncErr)
+		require.ErrorIs(t, err, testErr)
+		assert.Equal(t, &Error{
+			Phase:  PhaseAdd,
+			Kind:   "test/adapter",
+			Target: "foo",
+			Things: []string{"foo"},
+			Err:    testErr,
+		}, syncErr)
+	})
+
+	t.Run("Adapter error is preserved", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{}, nil)
+
+		destination := &describedAdapter{NewMockAdapter(t)}
+		adapterErr := NewError(destination, PhaseRemove, []string{"bar"}, ErrCacheEmpty)
+
+		destination.EXPECT().Get(ctx).Return([]string{"foo", "bar"}, nil)
+		destination.EXPECT().Remove(ctx, []string{"foo", "bar"}).Maybe().Return(adapterErr)
+		destination.EXPECT().Remove(ctx, []string{"bar", "foo"}).Maybe().Return(adapterErr)
+
+		err := New(source).SyncWith(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorAs(t, err, &syncErr)
+		require.ErrorIs(t, err, ErrCacheEmpty)
+		assert.Same(t, adapterErr, syncErr)
+	})
+
+	t.Run("Too many changes", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"foo", "bar"}, nil)
+
+		destination := &describedAdapter{NewMockAdapter(t)}
+		destination.EXPECT().Get(ctx).Return([]string{}, nil)
+
+		err := New(source, func(s *Sync) {
+			s.MaximumChanges = 1
+		}).SyncWith(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorAs(t, err, &syncErr)
+		require.ErrorIs(t, err, ErrTooManyChanges)
+		assert.Equal(t, PhaseAdd, syncErr.Phase)
+		assert.ElementsMatch(t, []string{"foo", "bar"}, syncErr.Things)
+	})
+}
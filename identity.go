@@ -0,0 +1,320 @@
+package gosync
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+/*
+IdentitySource is an interface for directories that know which things belong to the same identity, such as a person's
+primary email address, their aliases, and their address on an old domain after a rebrand.
+
+Identities returns sets of aliases, where each set belongs to one identity and its first alias is the primary address.
+Sets that share an alias are treated as the same identity.
+*/
+type IdentitySource interface {
+	Identities(ctx context.Context) ([][]string, error)
+}
+
+// StaticIdentities is an IdentitySource of fixed sets of aliases, with the primary address first in each set.
+type StaticIdentities [][]string
+
+// Identities returns the sets of aliases.
+func (s StaticIdentities) Identities(_ context.Context) ([][]string, error) {
+	return s, nil
+}
+
+// CombineIdentities returns an IdentitySource that loads the aliases from every source, in order.
+func CombineIdentities(sources ...IdentitySource) IdentitySource { //nolint:ireturn
+	return combinedIdentities(sources)
+}
+
+// combinedIdentities is an IdentitySource that loads aliases from many sources.
+type combinedIdentities []IdentitySource
+
+// Identities returns the sets of aliases from every source.
+func (c combinedIdentities) Identities(ctx context.Context) ([][]string, error) {
+	out := make([][]string, 0)
+
+	for index, source := range c {
+		sets, err := source.Identities(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("identities(%d) -> %w", index, err)
+		}
+
+		out = append(out, sets...)
+	}
+
+	return out, nil
+}
+
+/*
+WithIdentities matches things in the source and destinations that are aliases of the same identity, so that a person
+isn't removed and added again because each service knows them by a different address:
+
+	syncService := gosync.New(source, gosync.WithIdentities(gosync.StaticIdentities{
+		{"jane@example.com", "jane.doe@example.com", "jane@old-example.com"},
+	}))
+
+The aliases are loaded once for each Sync service, the first time it's used. Things that aren't in any set of aliases
+are compared as they are.
+*/
+func WithIdentities(source IdentitySource) func(*Sync) {
+	return func(s *Sync) {
+		s.Identities = source
+	}
+}
+
+/*
+WithPreferredAlias sets which of an identity's aliases is added to a destination, such as an address on the domain that
+the destination uses. If prefer returns an empty string, or the thing isn't in any set of aliases, the source's thing is
+added. It can be passed to New, or to a single SyncWith call.
+
+	err := syncService.SyncWith(ctx, slackChannel, gosync.WithPreferredAlias(gosync.PreferDomain("example.com")))
+*/
+func WithPreferredAlias(prefer func(aliases []string) string) CallOption {
+	return func(s *Sync) {
+		s.PreferAlias = prefer
+	}
+}
+
+// PreferPrimary prefers the primary address of an identity, which is the first of its aliases.
+func PreferPrimary(aliases []string) string {
+	if len(aliases) == 0 {
+		return ""
+	}
+
+	return aliases[0]
+}
+
+// PreferDomain prefers the first alias of an identity on one of the domains, in the order they're given.
+func PreferDomain(domains ...string) func(aliases []string) string {
+	return func(aliases []string) string {
+		for _, domain := range domains {
+			for _, alias := range aliases {
+				if strings.HasSuffix(strings.ToLower(alias), "@"+strings.ToLower(domain)) {
+					return alias
+				}
+			}
+		}
+
+		return ""
+	}
+}
+
+// identityIndex finds the identity that an alias belongs to.
+type identityIndex struct {
+	identities [][]string     // identities are merged sets of aliases, with the primary address first.
+	exact      map[string]int // exact maps each alias to its identity.
+	folded     map[string]int // folded maps each lowercased alias to its identity.
+}
+
+// newIdentityIndex merges sets of aliases that share an alias into identities, and indexes them.
+func newIdentityIndex(sets [][]string) *identityIndex {
+	parent := make([]int, len(sets))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	root := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+
+		return i
+	}
+
+	owner := make(map[string]int)
+
+	for i, set := range sets {
+		for _, alias := range set {
+			if j, ok := owner[alias]; ok {
+				// The earlier set stays the root, so that its primary address stays first.
+				a, b := root(j), root(i)
+				parent[max(a, b)] = min(a, b)
+			} else {
+				owner[alias] = i
+			}
+		}
+	}
+
+	index := &identityIndex{exact: make(map[string]int), folded: make(map[string]int)}
+	identities := make(map[int]int)
+
+	for i, set := range sets {
+		id, ok := identities[root(i)]
+		if !ok {
+			id = len(index.identities)
+			identities[root(i)] = id
+			index.identities = append(index.identities, make([]string, 0, len(set)))
+		}
+
+		for _, alias := range set {
+			if _, seen := index.exact[alias]; seen {
+				continue
+			}
+
+			index.exact[alias] = id
+			index.identities[id] = append(index.identities[id], alias)
+
+			if _, seen := index.folded[strings.ToLower(alias)]; !seen {
+				index.folded[strings.ToLower(alias)] = id
+			}
+		}
+	}
+
+	return index
+}
+
+// lookup returns the identity that a thing belongs to, or false if it isn't an alias of any identity.
+func (i *identityIndex) lookup(thing string, caseSensitive bool) (int, bool) {
+	if caseSensitive {
+		id, ok := i.exact[thing]
+
+		return id, ok
+	}
+
+	id, ok := i.folded[strings.ToLower(thing)]
+
+	return id, ok
+}
+
+// loadIdentities loads the aliases from the Sync's IdentitySource, if it has one and they haven't been loaded yet.
+func (s *Sync) loadIdentities(ctx context.Context) error {
+	if s.Identities == nil || s.identities != nil {
+		return nil
+	}
+
+	sets, err := s.Identities.Identities(ctx)
+	if err != nil {
+		return fmt.Errorf("identities -> %w", err)
+	}
+
+	s.identities = newIdentityIndex(sets)
+
+	s.Logger.Info("Loaded identities", slog.Int(LogKeyCount, len(s.identities.identities)))
+
+	return nil
+}
+
+// resolve returns the identities of things, keyed by identity. Things that aren't aliases of any identity are skipped.
+func (s *Sync) resolve(things map[string]bool) map[int]bool {
+	out := make(map[int]bool)
+
+	for thing := range things {
+		if id, ok := s.identities.lookup(thing, s.CaseSensitive); ok {
+			out[id] = true
+		}
+	}
+
+	return out
+}
+
+// getIdentitiesToAdd determines things in the source whose identities aren't in the destination service.
+func (s *Sync) getIdentitiesToAdd(things map[string]bool) []string {
+	present := s.resolve(things)
+	out := make([]string, 0)
+
+	// Things are sorted, so that the same alias is added when the source has many aliases of one identity.
+	sorted := mapKeys(s.cache)
+	slices.Sort(sorted)
+
+	for _, thing := range sorted {
+		if things[thing] {
+			continue
+		}
+
+		id, ok := s.identities.lookup(thing, s.CaseSensitive)
+		if !ok {
+			out = append(out, thing)
+
+			continue
+		}
+
+		if present[id] {
+			continue
+		}
+
+		present[id] = true
+
+		if s.PreferAlias != nil {
+			if preferred := s.PreferAlias(s.identities.identities[id]); preferred != "" {
+				thing = preferred
+			}
+		}
+
+		out = append(out, thing)
+	}
+
+	return out
+}
+
+// getIdentitiesToRemove determines things in the destination service whose identities aren't in the source.
+func (s *Sync) getIdentitiesToRemove(things map[string]bool) []string {
+	wanted := s.resolve(s.cache)
+
+	var out []string
+
+	for thing := range things {
+		if s.cache[thing] {
+			continue
+		}
+
+		if id, ok := s.identities.lookup(thing, s.CaseSensitive); ok && wanted[id] {
+			continue
+		}
+
+		out = append(out, thing)
+	}
+
+	return out
+}
+
+/*
+getIdentitiesToUpdate records the source's thing that each thing in the destination service matches in sources, either
+because they're the same thing, or because they're aliases of the same identity.
+*/
+func (s *Sync) getIdentitiesToUpdate(things map[string]bool, sources map[string]string) {
+	// Things are sorted, so that the same alias is used when the source has many aliases of one identity.
+	sorted := mapKeys(s.cache)
+	slices.Sort(sorted)
+
+	aliases := make(map[int]string)
+
+	for _, thing := range sorted {
+		if id, ok := s.identities.lookup(thing, s.CaseSensitive); ok {
+			if _, found := aliases[id]; !found {
+				aliases[id] = thing
+			}
+		}
+	}
+
+	for thing := range things {
+		if s.cache[thing] {
+			sources[thing] = thing
+
+			continue
+		}
+
+		if id, ok := s.identities.lookup(thing, s.CaseSensitive); ok {
+			if source, found := aliases[id]; found {
+				sources[thing] = source
+			}
+		}
+	}
+}
+
+// mapKeys returns the keys of a map of things.
+func mapKeys(things map[string]bool) []string {
+	out := make([]string, 0, len(things))
+
+	for thing := range things {
+		out = append(out, thing)
+	}
+
+	return out
+}
@@ -0,0 +1,168 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIdentityIndex(t *testing.T) {
+	t.Parallel()
+
+	index := newIdentityIndex([][]string{
+		{"jane@example.com", "jane.doe@example.com"},
+		{"bob@example.com"},
+		{"jane@old-example.com", "jane.doe@example.com"},
+		{"Bob@Example.com", "bob@old-example.com"},
+	})
+
+	// Sets that share an alias are merged, with the earliest primary address first.
+	assert.Equal(t, [][]string{
+		{"jane@example.com", "jane.doe@example.com", "jane@old-example.com"},
+		{"bob@example.com"},
+		{"Bob@Example.com", "bob@old-example.com"},
+	}, index.identities)
+
+	id, ok := index.lookup("jane@old-example.com", true)
+	assert.True(t, ok)
+	assert.Equal(t, 0, id)
+
+	_, ok = index.lookup("JANE@old-example.com", true)
+	assert.False(t, ok)
+
+	// Lowercased aliases belong to the first identity that has them.
+	id, ok = index.lookup("BOB@example.com", false)
+	assert.True(t, ok)
+	assert.Equal(t, 1, id)
+}
+
+func TestPreferDomain(t *testing.T) {
+	t.Parallel()
+
+	aliases := []string{"jane@example.com", "Jane@Old-Example.com"}
+
+	assert.Equal(t, "Jane@Old-Example.com", PreferDomain("old-example.com", "example.com")(aliases))
+	assert.Equal(t, "jane@example.com", PreferDomain("example.com")(aliases))
+	assert.Equal(t, "", PreferDomain("other.com")(aliases))
+	assert.Equal(t, "jane@example.com", PreferPrimary(aliases))
+	assert.Equal(t, "", PreferPrimary(nil))
+}
+
+func TestSync_Identities(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	identities := StaticIdentities{
+		{"jane@example.com", "jane.doe@example.com", "jane@old-example.com"},
+		{"bob@example.com", "bob@old-example.com"},
+	}
+
+	t.Run("Matches aliases of the same identity", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"jane@example.com", "bob@example.com", "alice@example.com"}, nil)
+
+		destination := NewMockAdapter(t)
+		destination.EXPECT().Get(ctx).Return([]string{"jane@old-example.com", "eve@example.com"}, nil)
+		destination.EXPECT().Remove(ctx, []string{"eve@example.com"}).Return(nil)
+		destination.EXPECT().Add(ctx, []string{"alice@example.com", "bob@example.com"}).Return(nil)
+
+		err := New(source, WithIdentities(identities)).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Adds the preferred alias", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"jane@example.com", "jane.doe@example.com", "alice@example.com"}, nil)
+
+		// Jane is only added once, although the source has two of her aliases.
+		destination := NewMockAdapter(t)
+		destination.EXPECT().Get(ctx).Return([]string{}, nil)
+		destination.EXPECT().Add(ctx, []string{"alice@example.com", "jane@old-example.com"}).Return(nil)
+
+		err := New(source, WithIdentities(identities)).
+			SyncWith(ctx, destination, WithPreferredAlias(PreferDomain("old-example.com")))
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Case insensitive", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"Jane@Example.com"}, nil)
+
+		destination := NewMockAdapter(t)
+		destination.EXPECT().Get(ctx).Return([]string{"JANE.DOE@example.com"}, nil)
+
+		err := New(source, WithIdentities(identities), func(s *Sync) {
+			s.CaseSensitive = false
+		}).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Identities are loaded once", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Once().Return([]string{"jane@example.com"}, nil)
+
+		destination := NewMockAdapter(t)
+		destination.EXPECT().Get(ctx).Return([]string{"jane@old-example.com"}, nil)
+
+		loader := &countingIdentities{IdentitySource: identities}
+		syncService := New(source, WithIdentities(loader))
+
+		require.NoError(t, syncService.SyncWith(ctx, destination, WithDryRun(true)))
+		require.NoError(t, syncService.SyncWith(ctx, destination))
+
+		drift, err := syncService.Check(ctx, destination)
+
+		require.NoError(t, err)
+		assert.False(t, drift.Drifted())
+		assert.Equal(t, 1, loader.calls)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"jane@example.com"}, nil)
+
+		// The destination isn't fetched if the identities can't be loaded.
+		destination := NewMockAdapter(t)
+
+		err := New(source, WithIdentities(CombineIdentities(identities, &countingIdentities{err: testErr}))).
+			SyncWith(ctx, destination)
+
+		require.ErrorIs(t, err, testErr)
+	})
+}
+
+// countingIdentities is an IdentitySource that counts how many times it has been loaded.
+type countingIdentities struct {
+	IdentitySource
+	calls int
+	err   error
+}
+
+func (c *countingIdentities) Identities(ctx context.Context) ([][]string, error) {
+	c.calls++
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return c.IdentitySource.Identities(ctx) //nolint:wrapcheck
+}
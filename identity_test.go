@@ -0,0 +1,42 @@
+package gosync_test
+
+import (
+	"context"
+	"log"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/github/team"
+	"github.com/ovotech/go-sync/adapters/slack/conversation"
+)
+
+func ExampleWithIdentities() {
+	ctx := context.Background()
+
+	source, err := team.Init(ctx, map[gosync.ConfigKey]string{
+		team.GitHubToken: "some-token",
+		team.GitHubOrg:   "my-org",
+		team.TeamSlug:    "my-team",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	channel, err := conversation.Init(ctx, map[gosync.ConfigKey]string{
+		conversation.SlackAPIKey: "some-key",
+		conversation.Name:        "public-channel",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// Identities can also be loaded from a directory, such as Azure AD users or the Google Workspace directory.
+	syncService := gosync.New(source, gosync.WithIdentities(gosync.StaticIdentities{
+		{"jane@example.com", "jane.doe@example.com", "jane@old-example.com"},
+	}))
+
+	// Slack profiles use the new domain, so add people by their address on it.
+	err = syncService.SyncWith(ctx, channel, gosync.WithPreferredAlias(gosync.PreferDomain("example.com")))
+	if err != nil {
+		log.Panic(err)
+	}
+}
@@ -0,0 +1,175 @@
+package gosync
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// Attribute keys used in structured logs by Sync and adapters.
+const (
+	LogKeyAdapter   = "adapter"   // LogKeyAdapter is the kind of adapter, e.g. `slack/usergroup`.
+	LogKeyTarget    = "target"    // LogKeyTarget identifies what the adapter is synchronising, e.g. a usergroup ID.
+	LogKeyOperation = "operation" // LogKeyOperation is the operation being performed, e.g. `add`.
+	LogKeyCount     = "count"     // LogKeyCount is the number of things affected by an operation.
+	LogKeyThing     = "thing"     // LogKeyThing is a single thing, used in debug logs for each thing.
+	LogKeyThings    = "things"    // LogKeyThings is a list of things.
+	LogKeyMode      = "mode"      // LogKeyMode is the OperatingMode of Sync.
+	LogKeyJob       = "job"       // LogKeyJob is the name of a Runner's job.
+)
+
+/*
+Describer is an optional interface for adapters to describe themselves in logs and other output.
+
+If an adapter doesn't implement Describer, its Go type is used as the kind, and the target is empty.
+*/
+type Describer interface {
+	Kind() string   // Kind of adapter, e.g. `slack/usergroup`.
+	Target() string // Target that the adapter is synchronising, e.g. a usergroup ID.
+}
+
+// Describe returns the kind and target of an adapter.
+func Describe(adapter Adapter) (string, string) {
+	if describer, ok := adapter.(Describer); ok {
+		return describer.Kind(), describer.Target()
+	}
+
+	return fmt.Sprintf("%T", adapter), ""
+}
+
+/*
+LogAttrs returns the log attributes that identify an adapter. Sync adds them to its logs for each destination, so
+adapters don't add them to their own loggers.
+*/
+func LogAttrs(adapter Adapter) []any {
+	kind, target := Describe(adapter)
+
+	return []any{slog.String(LogKeyAdapter, kind), slog.String(LogKeyTarget, target)}
+}
+
+// logHandler is a [slog.Handler] that writes records to a [log.Logger].
+type logHandler struct {
+	logger *log.Logger
+	level  slog.Leveler
+	attrs  []slog.Attr
+	group  string
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *logHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle formats a record as `LEVEL message key=value`, and writes it to the logger.
+func (h *logHandler) Handle(_ context.Context, record slog.Record) error {
+	var builder strings.Builder
+
+	builder.WriteString(record.Level.String())
+	builder.WriteString(" ")
+	builder.WriteString(record.Message)
+
+	for _, attr := range h.attrs {
+		writeLogAttr(&builder, "", attr)
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		writeLogAttr(&builder, h.group, attr)
+
+		return true
+	})
+
+	return h.logger.Output(0, builder.String()) //nolint:wrapcheck
+}
+
+// writeLogAttr writes an attribute as ` key=value`, expanding groups into dotted keys.
+func writeLogAttr(builder *strings.Builder, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	key := attr.Key
+	if prefix != "" {
+		key = prefix + "." + key
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, groupAttr := range attr.Value.Group() {
+			writeLogAttr(builder, key, groupAttr)
+		}
+
+		return
+	}
+
+	builder.WriteString(" ")
+	builder.WriteString(key)
+	builder.WriteString("=")
+
+	value := attr.Value.String()
+	if value == "" || strings.ContainsAny(value, " \"=") {
+		value = strconv.Quote(value)
+	}
+
+	builder.WriteString(value)
+}
+
+// WithAttrs returns a new handler with additional attributes.
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler { //nolint:ireturn
+	out := *h
+	out.attrs = make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	out.attrs = append(out.attrs, h.attrs...)
+
+	for _, attr := range attrs {
+		if h.group != "" {
+			attr.Key = h.group + "." + attr.Key
+		}
+
+		out.attrs = append(out.attrs, attr)
+	}
+
+	return &out
+}
+
+// WithGroup returns a new handler that qualifies subsequent attributes with a group name.
+func (h *logHandler) WithGroup(name string) slog.Handler { //nolint:ireturn
+	if name == "" {
+		return h
+	}
+
+	out := *h
+
+	if h.group != "" {
+		out.group = h.group + "." + name
+	} else {
+		out.group = name
+	}
+
+	return &out
+}
+
+/*
+NewLogLogger converts a [log.Logger] into a [slog.Logger], to support code written before Go Sync used structured logs.
+
+Records are written to the logger as `LEVEL message key=value`. Debug records are discarded.
+*/
+func NewLogLogger(logger *log.Logger) *slog.Logger {
+	return slog.New(&logHandler{logger: logger, level: slog.LevelInfo})
+}
+
+// WithLogger passes a custom [log.Logger] to Sync, converted with [NewLogLogger].
+func WithLogger(logger *log.Logger) func(*Sync) {
+	return func(s *Sync) {
+		s.Logger = NewLogLogger(logger)
+	}
+}
+
+// WithSlogLogger passes a custom [slog.Logger] to Sync.
+func WithSlogLogger(logger *slog.Logger) func(*Sync) {
+	return func(s *Sync) {
+		s.Logger = logger
+	}
+}
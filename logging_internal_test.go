@@ -0,0 +1,98 @@
+package gosync
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type describedAdapter struct {
+	*MockAdapter
+}
+
+func (d *describedAdapter) Kind() string {
+	return "test/adapter"
+}
+
+func (d *describedAdapter) Target() string {
+	return "foo"
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	kind, target := Describe(NewMockAdapter(t))
+	assert.Equal(t, "*gosync.MockAdapter", kind)
+	assert.Empty(t, target)
+
+	kind, target = Describe(&describedAdapter{NewMockAdapter(t)})
+	assert.Equal(t, "test/adapter", kind)
+	assert.Equal(t, "foo", target)
+}
+
+func TestNewLogLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := NewLogLogger(log.New(&buf, "[prefix] ", 0))
+
+	logger.Debug("hidden")
+	logger.Info("Hello world", slog.Int(LogKeyCount, 2), slog.String(LogKeyThing, "foo bar"))
+	logger.With(slog.String(LogKeyAdapter, "test")).WithGroup("source").Warn("Grouped", slog.String("key", "value"))
+	logger.Error("Nested", slog.Group("group", slog.String("key", "")))
+
+	assert.Equal(t, `[prefix] INFO Hello world count=2 thing="foo bar"
+[prefix] WARN Grouped adapter=test source.key=value
+[prefix] ERROR Nested group.key=""
+`, buf.String())
+}
+
+func TestSync_Logger(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	var buf bytes.Buffer
+
+	source := NewMockAdapter(t)
+	source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+	destination := &describedAdapter{NewMockAdapter(t)}
+	destination.EXPECT().Get(ctx).Return([]string{}, nil)
+	destination.EXPECT().Add(ctx, []string{"foo"}).Return(nil)
+
+	syncService := New(source, WithSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	}))))
+
+	err := syncService.SyncWith(ctx, destination)
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `level=INFO msg="Starting sync" adapter=test/adapter target=foo`)
+	assert.Contains(t, buf.String(), `source.adapter=*gosync.MockAdapter`)
+	assert.Contains(t, buf.String(), `msg="Changing thing" adapter=test/adapter target=foo operation=add thing=foo`)
+
+	t.Run("WithLogger", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		syncService := New(source, WithLogger(log.New(&buf, "", 0)))
+		syncService.Logger.Info("test")
+
+		assert.Equal(t, "INFO test\n", buf.String())
+	})
+}
@@ -0,0 +1,212 @@
+package gosync
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Ensure Metrics can be registered with Prometheus.
+var _ prometheus.Collector = &Metrics{}
+
+// MetricsNamespace is the namespace of metrics exported by Metrics.
+const MetricsNamespace = "gosync"
+
+/*
+Metrics is a Prometheus collector for Sync. Create one with NewMetrics, register it with a [prometheus.Registerer], and
+pass it to each Sync service with WithMetrics.
+
+Metrics are labelled with the kind and target of the adapter, as returned by Describe, so syncs to different
+destinations can share a collector. Exported metrics are:
+
+  - gosync_things_added_total, gosync_things_removed_total, gosync_things_updated_total: things successfully changed
+    in a destination.
+  - gosync_things_failed_total: things that couldn't be changed, labelled by operation.
+  - gosync_operation_duration_seconds: latency of each get, add, remove and update call to an adapter.
+  - gosync_source_things, gosync_destination_things: the number of things last fetched from an adapter.
+  - gosync_last_success_timestamp_seconds: the Unix time that a destination was last synchronised successfully.
+  - gosync_too_many_changes_total: syncs that were stopped by ErrTooManyChanges, labelled by operation.
+*/
+type Metrics struct {
+	added           *prometheus.CounterVec
+	removed         *prometheus.CounterVec
+	updated         *prometheus.CounterVec
+	failed          *prometheus.CounterVec
+	duration        *prometheus.HistogramVec
+	sourceSize      *prometheus.GaugeVec
+	destinationSize *prometheus.GaugeVec
+	lastSuccess     *prometheus.GaugeVec
+	tooManyChanges  *prometheus.CounterVec
+	now             func() time.Time // now allows the passage of time to be mocked in tests.
+}
+
+// NewMetrics creates a new Metrics collector.
+func NewMetrics() *Metrics {
+	labels := []string{LogKeyAdapter, LogKeyTarget}
+	operationLabels := []string{LogKeyAdapter, LogKeyTarget, LogKeyOperation}
+
+	return &Metrics{
+		added: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "things_added_total",
+			Help:      "Number of things added to a destination.",
+		}, labels),
+		removed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "things_removed_total",
+			Help:      "Number of things removed from a destination.",
+		}, labels),
+		updated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "things_updated_total",
+			Help:      "Number of things updated in place in a destination.",
+		}, labels),
+		failed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "things_failed_total",
+			Help:      "Number of things that couldn't be changed in a destination.",
+		}, operationLabels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: MetricsNamespace,
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of calls to an adapter.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12), //nolint:gomnd,mnd
+		}, operationLabels),
+		sourceSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "source_things",
+			Help:      "Number of things last fetched from a source.",
+		}, labels),
+		destinationSize: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "destination_things",
+			Help:      "Number of things last fetched from a destination.",
+		}, labels),
+		lastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "last_success_timestamp_seconds",
+			Help:      "Unix time that a destination was last synchronised successfully.",
+		}, labels),
+		tooManyChanges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "too_many_changes_total",
+			Help:      "Number of syncs stopped because the changes exceeded the maximum change limit.",
+		}, operationLabels),
+		now: time.Now,
+	}
+}
+
+// collectors returns each of the underlying Prometheus collectors.
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.added,
+		m.removed,
+		m.updated,
+		m.failed,
+		m.duration,
+		m.sourceSize,
+		m.destinationSize,
+		m.lastSuccess,
+		m.tooManyChanges,
+	}
+}
+
+// Describe sends the descriptors of each metric to the channel.
+func (m *Metrics) Describe(descs chan<- *prometheus.Desc) {
+	for _, collector := range m.collectors() {
+		collector.Describe(descs)
+	}
+}
+
+// Collect sends the current value of each metric to the channel.
+func (m *Metrics) Collect(metrics chan<- prometheus.Metric) {
+	for _, collector := range m.collectors() {
+		collector.Collect(metrics)
+	}
+}
+
+// observeDuration records the latency of an operation on an adapter. It is safe to call on a nil Metrics.
+func (m *Metrics) observeDuration(adapter Adapter, phase Phase, start time.Time) {
+	if m == nil {
+		return
+	}
+
+	kind, target := Describe(adapter)
+	m.duration.WithLabelValues(kind, target, string(phase)).Observe(m.now().Sub(start).Seconds())
+}
+
+// setSourceSize records the number of things fetched from a source. It is safe to call on a nil Metrics.
+func (m *Metrics) setSourceSize(adapter Adapter, count int) {
+	if m == nil {
+		return
+	}
+
+	kind, target := Describe(adapter)
+	m.sourceSize.WithLabelValues(kind, target).Set(float64(count))
+}
+
+// setDestinationSize records the number of things fetched from a destination. It is safe to call on a nil Metrics.
+func (m *Metrics) setDestinationSize(adapter Adapter, count int) {
+	if m == nil {
+		return
+	}
+
+	kind, target := Describe(adapter)
+	m.destinationSize.WithLabelValues(kind, target).Set(float64(count))
+}
+
+// recordChanges records the things changed by an operation, or failed if it errored. It is safe to call on a nil
+// Metrics.
+func (m *Metrics) recordChanges(adapter Adapter, phase Phase, count int, err error) {
+	if m == nil {
+		return
+	}
+
+	kind, target := Describe(adapter)
+
+	switch {
+	case err != nil:
+		m.failed.WithLabelValues(kind, target, string(phase)).Add(float64(count))
+	case phase == PhaseAdd:
+		m.added.WithLabelValues(kind, target).Add(float64(count))
+	case phase == PhaseRemove:
+		m.removed.WithLabelValues(kind, target).Add(float64(count))
+	case phase == PhaseUpdate:
+		m.updated.WithLabelValues(kind, target).Add(float64(count))
+	}
+}
+
+// recordTooManyChanges records an operation that exceeded the maximum change limit. It is safe to call on a nil
+// Metrics.
+func (m *Metrics) recordTooManyChanges(adapter Adapter, phase Phase) {
+	if m == nil {
+		return
+	}
+
+	kind, target := Describe(adapter)
+	m.tooManyChanges.WithLabelValues(kind, target, string(phase)).Inc()
+}
+
+// recordSuccess records a successful sync with a destination. It is safe to call on a nil Metrics.
+func (m *Metrics) recordSuccess(adapter Adapter) {
+	if m == nil {
+		return
+	}
+
+	kind, target := Describe(adapter)
+	m.lastSuccess.WithLabelValues(kind, target).Set(float64(m.now().Unix()))
+}
+
+/*
+WithMetrics records Prometheus metrics for Sync in the given collector.
+
+	metrics := gosync.NewMetrics()
+	prometheus.MustRegister(metrics)
+
+	syncService := gosync.New(source, gosync.WithMetrics(metrics))
+*/
+func WithMetrics(metrics *Metrics) func(*Sync) {
+	return func(s *Sync) {
+		s.Metrics = metrics
+	}
+}
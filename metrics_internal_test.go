@@ -0,0 +1,123 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMetrics returns a Metrics collector with a fixed clock.
+func newTestMetrics() *Metrics {
+	metrics := NewMetrics()
+	metrics.now = func() time.Time {
+		return time.Unix(1700000000, 0)
+	}
+
+	return metrics
+}
+
+func TestNewMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewMetrics()
+
+	require.NoError(t, prometheus.NewPedanticRegistry().Register(metrics))
+
+	problems, err := testutil.CollectAndLint(metrics)
+
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestSync_Metrics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		metrics := newTestMetrics()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"foo", "bar"}, nil)
+
+		destination := &describedAdapter{NewMockAdapter(t)}
+		destination.EXPECT().Get(ctx).Return([]string{"baz"}, nil)
+		destination.EXPECT().Add(ctx, []string{"foo", "bar"}).Maybe().Return(nil)
+		destination.EXPECT().Add(ctx, []string{"bar", "foo"}).Maybe().Return(nil)
+		destination.EXPECT().Remove(ctx, []string{"baz"}).Return(nil)
+
+		err := New(source, WithMetrics(metrics)).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+
+		assert.InDelta(t, 2, testutil.ToFloat64(metrics.added.WithLabelValues("test/adapter", "foo")), 0)
+		assert.InDelta(t, 1, testutil.ToFloat64(metrics.removed.WithLabelValues("test/adapter", "foo")), 0)
+		assert.InDelta(t, 2, testutil.ToFloat64(metrics.sourceSize.WithLabelValues("*gosync.MockAdapter", "")), 0)
+		assert.InDelta(t, 1, testutil.ToFloat64(metrics.destinationSize.WithLabelValues("test/adapter", "foo")), 0)
+		assert.InDelta(t, 1700000000, testutil.ToFloat64(metrics.lastSuccess.WithLabelValues("test/adapter", "foo")), 0)
+		assert.Equal(t, 4, testutil.CollectAndCount(metrics.duration))
+		assert.Zero(t, testutil.CollectAndCount(metrics.failed))
+		assert.Zero(t, testutil.CollectAndCount(metrics.tooManyChanges))
+
+		err = testutil.CollectAndCompare(metrics, strings.NewReader(`
+# HELP gosync_things_added_total Number of things added to a destination.
+# TYPE gosync_things_added_total counter
+gosync_things_added_total{adapter="test/adapter",target="foo"} 2
+`), "gosync_things_added_total")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		t.Parallel()
+
+		metrics := newTestMetrics()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		destination := &describedAdapter{NewMockAdapter(t)}
+		destination.EXPECT().Get(ctx).Return([]string{}, nil)
+		destination.EXPECT().Add(ctx, []string{"foo"}).Return(testErr)
+
+		err := New(source, WithMetrics(metrics)).SyncWith(ctx, destination)
+
+		require.ErrorIs(t, err, testErr)
+
+		assert.InDelta(t, 1, testutil.ToFloat64(metrics.failed.WithLabelValues("test/adapter", "foo", "add")), 0)
+		assert.Zero(t, testutil.CollectAndCount(metrics.added))
+		assert.Zero(t, testutil.CollectAndCount(metrics.lastSuccess))
+	})
+
+	t.Run("ErrTooManyChanges", func(t *testing.T) {
+		t.Parallel()
+
+		metrics := newTestMetrics()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{}, nil)
+
+		destination := &describedAdapter{NewMockAdapter(t)}
+		destination.EXPECT().Get(ctx).Return([]string{"foo", "bar"}, nil)
+
+		err := New(source, WithMetrics(metrics), func(s *Sync) {
+			s.MaximumChanges = 1
+		}).SyncWith(ctx, destination)
+
+		require.ErrorIs(t, err, ErrTooManyChanges)
+
+		assert.InDelta(t, 1, testutil.ToFloat64(metrics.tooManyChanges.WithLabelValues("test/adapter", "foo", "remove")), 0)
+		assert.Zero(t, testutil.CollectAndCount(metrics.removed))
+	})
+}
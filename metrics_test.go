@@ -0,0 +1,48 @@
+package gosync_test
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/github/team"
+	"github.com/ovotech/go-sync/adapters/slack/conversation"
+)
+
+func ExampleWithMetrics() {
+	ctx := context.Background()
+
+	// Register the collector, and expose it for Prometheus to scrape.
+	metrics := gosync.NewMetrics()
+	prometheus.MustRegister(metrics)
+
+	go func() {
+		log.Fatal(http.ListenAndServe(":9090", promhttp.Handler())) //nolint:gosec
+	}()
+
+	source, err := team.Init(ctx, map[gosync.ConfigKey]string{
+		team.GitHubToken: "some-token",
+		team.GitHubOrg:   "my-org",
+		team.TeamSlug:    "my-team",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	destination, err := conversation.Init(ctx, map[gosync.ConfigKey]string{
+		conversation.SlackAPIKey: "some-key",
+		conversation.Name:        "example",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	err = gosync.New(source, gosync.WithMetrics(metrics)).SyncWith(ctx, destination)
+	if err != nil {
+		log.Panic(err)
+	}
+}
@@ -5,11 +5,11 @@ package gosync
 import mock "github.com/stretchr/testify/mock"
 
 // MockConfigFn is an autogenerated mock type for the ConfigFn type
-type MockConfigFn[T Adapter] struct {
+type MockConfigFn[T interface{}] struct {
 	mock.Mock
 }
 
-type MockConfigFn_Expecter[T Adapter] struct {
+type MockConfigFn_Expecter[T interface{}] struct {
 	mock *mock.Mock
 }
 
@@ -23,7 +23,7 @@ func (_m *MockConfigFn[T]) Execute(_a0 T) {
 }
 
 // MockConfigFn_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
-type MockConfigFn_Execute_Call[T Adapter] struct {
+type MockConfigFn_Execute_Call[T interface{}] struct {
 	*mock.Call
 }
 
@@ -46,13 +46,13 @@ func (_c *MockConfigFn_Execute_Call[T]) Return() *MockConfigFn_Execute_Call[T] {
 }
 
 func (_c *MockConfigFn_Execute_Call[T]) RunAndReturn(run func(T)) *MockConfigFn_Execute_Call[T] {
-	_c.Call.Return(run)
+	_c.Run(run)
 	return _c
 }
 
 // NewMockConfigFn creates a new instance of MockConfigFn. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockConfigFn[T Adapter](t interface {
+func NewMockConfigFn[T interface{}](t interface {
 	mock.TestingT
 	Cleanup(func())
 }) *MockConfigFn[T] {
@@ -9,11 +9,11 @@ import (
 )
 
 // MockInitFn is an autogenerated mock type for the InitFn type
-type MockInitFn[T Adapter] struct {
+type MockInitFn[T interface{}] struct {
 	mock.Mock
 }
 
-type MockInitFn_Expecter[T Adapter] struct {
+type MockInitFn_Expecter[T interface{}] struct {
 	mock *mock.Mock
 }
 
@@ -44,7 +44,9 @@ func (_m *MockInitFn[T]) Execute(_a0 context.Context, _a1 map[string]string, _a2
 	if rf, ok := ret.Get(0).(func(context.Context, map[string]string, ...ConfigFn[T]) T); ok {
 		r0 = rf(_a0, _a1, _a2...)
 	} else {
-		r0 = ret.Get(0).(T)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(T)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, map[string]string, ...ConfigFn[T]) error); ok {
@@ -57,7 +59,7 @@ func (_m *MockInitFn[T]) Execute(_a0 context.Context, _a1 map[string]string, _a2
 }
 
 // MockInitFn_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
-type MockInitFn_Execute_Call[T Adapter] struct {
+type MockInitFn_Execute_Call[T interface{}] struct {
 	*mock.Call
 }
 
@@ -95,7 +97,7 @@ func (_c *MockInitFn_Execute_Call[T]) RunAndReturn(run func(context.Context, map
 
 // NewMockInitFn creates a new instance of MockInitFn. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockInitFn[T Adapter](t interface {
+func NewMockInitFn[T interface{}](t interface {
 	mock.TestingT
 	Cleanup(func())
 }) *MockInitFn[T] {
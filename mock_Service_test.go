@@ -21,17 +21,24 @@ func (_m *MockService) EXPECT() *MockService_Expecter {
 	return &MockService_Expecter{mock: &_m.Mock}
 }
 
-// SyncWith provides a mock function with given fields: ctx, adapter
-func (_m *MockService) SyncWith(ctx context.Context, adapter Adapter) error {
-	ret := _m.Called(ctx, adapter)
+// SyncWith provides a mock function with given fields: ctx, adapter, opts
+func (_m *MockService) SyncWith(ctx context.Context, adapter Adapter, opts ...CallOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, adapter)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SyncWith")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, Adapter) error); ok {
-		r0 = rf(ctx, adapter)
+	if rf, ok := ret.Get(0).(func(context.Context, Adapter, ...CallOption) error); ok {
+		r0 = rf(ctx, adapter, opts...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +54,21 @@ type MockService_SyncWith_Call struct {
 // SyncWith is a helper method to define mock.On call
 //   - ctx context.Context
 //   - adapter Adapter
-func (_e *MockService_Expecter) SyncWith(ctx interface{}, adapter interface{}) *MockService_SyncWith_Call {
-	return &MockService_SyncWith_Call{Call: _e.mock.On("SyncWith", ctx, adapter)}
+//   - opts ...CallOption
+func (_e *MockService_Expecter) SyncWith(ctx interface{}, adapter interface{}, opts ...interface{}) *MockService_SyncWith_Call {
+	return &MockService_SyncWith_Call{Call: _e.mock.On("SyncWith",
+		append([]interface{}{ctx, adapter}, opts...)...)}
 }
 
-func (_c *MockService_SyncWith_Call) Run(run func(ctx context.Context, adapter Adapter)) *MockService_SyncWith_Call {
+func (_c *MockService_SyncWith_Call) Run(run func(ctx context.Context, adapter Adapter, opts ...CallOption)) *MockService_SyncWith_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(Adapter))
+		variadicArgs := make([]CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(Adapter), variadicArgs...)
 	})
 	return _c
 }
@@ -63,7 +78,7 @@ func (_c *MockService_SyncWith_Call) Return(_a0 error) *MockService_SyncWith_Cal
 	return _c
 }
 
-func (_c *MockService_SyncWith_Call) RunAndReturn(run func(context.Context, Adapter) error) *MockService_SyncWith_Call {
+func (_c *MockService_SyncWith_Call) RunAndReturn(run func(context.Context, Adapter, ...CallOption) error) *MockService_SyncWith_Call {
 	_c.Call.Return(run)
 	return _c
 }
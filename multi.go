@@ -0,0 +1,544 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+/*
+MultiAdapter is a variant of Adapter for services with many groups of things, such as every usergroup in a Slack
+workspace. Things are keyed by the group that they belong to, so that a whole service can be fetched at once, and
+adapters can share lookups, such as user IDs, between groups.
+*/
+type MultiAdapter interface {
+	Get(ctx context.Context) (things map[string][]string, err error) // Get things in each group of a service.
+	Add(ctx context.Context, things map[string][]string) error       // Add things to groups in a service.
+	Remove(ctx context.Context, things map[string][]string) error    // Remove things from groups in a service.
+}
+
+/*
+GroupManager is an optional interface for MultiAdapters that can create and delete groups. MultiSync only creates and
+deletes groups if CreateGroups or DeleteGroups are set.
+*/
+type GroupManager interface {
+	CreateGroups(ctx context.Context, groups []string) error // CreateGroups creates empty groups in a service.
+	DeleteGroups(ctx context.Context, groups []string) error // DeleteGroups deletes groups from a service.
+}
+
+/*
+MultiSync synchronises every group in a source MultiAdapter with the same group in a destination, fetching each
+adapter once rather than once per group. The changes to every group are computed first, and then the destination's Add
+and Remove are each called once, with the changes to all of its groups. Sync's options, such as DryRun, OperatingMode
+and MaximumChanges, apply to each group individually, and are reported as if each group were a separate destination.
+
+	multiSync := gosync.NewMulti(source)
+	multiSync.CreateGroups = true
+
+	err := multiSync.SyncWith(ctx, destination)
+
+Groups that only exist in the destination are left unchanged, unless DeleteGroups is set. If a group exceeds the
+MaximumChanges, the remaining groups are still synchronised, and every error is returned. Changes are split into calls
+of up to MaxBatchSize things per group, if the destination sets it in its Capabilities.
+*/
+type MultiSync struct {
+	*Sync
+	CreateGroups bool                // CreateGroups creates groups that are missing from the destination.
+	DeleteGroups bool                // DeleteGroups deletes groups from the destination that aren't in the source.
+	source       MultiAdapter        // The source adapter.
+	groups       map[string][]string // groups prevents polling the source more than once.
+}
+
+// NewMulti creates a new MultiSync service, accepting the same options as New.
+func NewMulti(source MultiAdapter, optsFn ...func(*Sync)) *MultiSync {
+	return &MultiSync{
+		Sync:         New(&multiGroup{adapter: source}, optsFn...),
+		CreateGroups: false,
+		DeleteGroups: false,
+		source:       source,
+	}
+}
+
+// generateGroups populates the cache of groups in the source adapter.
+func (m *MultiSync) generateGroups(ctx context.Context) error {
+	if m.groups != nil {
+		return nil
+	}
+
+	source := &multiGroup{adapter: m.source}
+
+	logger := m.Logger.With(slog.Group("source", LogAttrs(source)...))
+	logger.Info("Getting groups from source adapter")
+
+	groups, err := m.source.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("get -> %w", wrapError(source, PhaseGet, nil, err))
+	}
+
+	logger.Info("Fetched groups from source adapter", slog.Int(LogKeyCount, len(groups)))
+
+	m.groups = groups
+
+	return nil
+}
+
+/*
+SyncWith synchronises each group in the destination with the same group in the source. CallOptions override Sync's
+defaults for every group in this call only.
+*/
+func (m *MultiSync) SyncWith(ctx context.Context, adapter MultiAdapter, opts ...CallOption) error {
+	if sameAdapter(m.source, adapter) {
+		return fmt.Errorf("multisync.syncwith.preflight -> %w: source and destination are the same adapter",
+			ErrInvalidConfig)
+	}
+
+	destination := &multiGroup{adapter: adapter}
+
+	logger := m.Logger.With(LogAttrs(destination)...)
+	logger.Info("Starting multi sync")
+
+	if err := m.generateGroups(ctx); err != nil {
+		return fmt.Errorf("multisync.syncwith.generateGroups -> %w", err)
+	}
+
+	logger.Info("Getting groups from destination adapter")
+
+	fetched, err := adapter.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("multisync.syncwith.get -> %w", wrapError(destination, PhaseGet, nil, err))
+	}
+
+	logger.Info("Fetched groups from destination adapter", slog.Int(LogKeyCount, len(fetched)))
+
+	// Copy the destination's groups, so that groups can be created without changing the adapter's own map.
+	groups := make(map[string][]string, len(fetched))
+	for group, things := range fetched {
+		groups[group] = things
+	}
+
+	// CallOptions are applied to a copy of Sync, which each group's Sync is then copied from.
+	defaults := *m.Sync
+
+	for _, opt := range opts {
+		opt(&defaults)
+	}
+
+	if err := defaults.preflight(destination); err != nil {
+		return fmt.Errorf("multisync.syncwith.preflight -> %w", err)
+	}
+
+	call := *m
+	call.Sync = &defaults
+
+	if err := call.syncGroups(ctx, logger, adapter, groups); err != nil {
+		return fmt.Errorf("multisync.syncwith.groups -> %w", err)
+	}
+
+	errs := make([]error, 0)
+	members := make([]*groupSync, 0, len(m.groups))
+
+	for _, group := range sortedKeys(m.groups) {
+		things, ok := groups[group]
+		if !ok {
+			continue
+		}
+
+		member, err := newGroupSync(ctx, defaults, m.source, adapter, group, m.groups[group], things)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("multisync.syncwith.group(%s) -> %w", group, err))
+		}
+
+		members = append(members, member)
+	}
+
+	logger.Info("Running sync operations", slog.String(LogKeyMode, string(defaults.OperatingMode)))
+
+	for _, phase := range defaults.OperatingMode.phases() {
+		if err := call.performGroups(ctx, logger, adapter, phase, members); err != nil {
+			errs = append(errs, fmt.Errorf("multisync.syncwith.execute -> %w", err))
+		}
+	}
+
+	for _, member := range members {
+		member.Report.finish(member.entry, member.err)
+
+		if member.err == nil {
+			member.Metrics.recordSuccess(member.destination)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// groupSync is a single group being synchronised by MultiSync, with a copy of Sync limited to that group.
+type groupSync struct {
+	*Sync
+	destination *multiGroup     // destination group, as an Adapter, so that changes are reported against it.
+	entry       int             // entry of the destination group in the report.
+	things      map[string]bool // things in the destination group.
+	err         error           // err that the group failed with, after which it isn't changed any further.
+}
+
+// newGroupSync prepares a group to be synchronised, and begins its entry in the report.
+func newGroupSync(
+	ctx context.Context,
+	defaults Sync,
+	source MultiAdapter,
+	adapter MultiAdapter,
+	group string,
+	sourceThings []string,
+	destinationThings []string,
+) (*groupSync, error) {
+	sync := defaults
+	sync.source = &multiGroup{adapter: source, group: group, things: sourceThings}
+	sync.cache = make(map[string]bool)
+
+	member := &groupSync{Sync: &sync, destination: &multiGroup{adapter: adapter, group: group, things: destinationThings}}
+	member.entry = member.Report.begin(member.destination, sync.OperatingMode, sync.DryRun)
+
+	// The groups have already been fetched, so these only fold their case and load identities.
+	if member.err = sync.generateCache(ctx); member.err != nil {
+		return member, member.err
+	}
+
+	member.things, member.err = sync.fetch(ctx, member.destination)
+	if member.err != nil {
+		return member, member.err
+	}
+
+	sync.Metrics.setDestinationSize(member.destination, len(member.things))
+
+	return member, nil
+}
+
+// diff returns the things that an operation changes in the group.
+func (g *groupSync) diff(phase Phase) []string {
+	if phase == PhaseRemove {
+		return g.getThingsToRemove(g.things)
+	}
+
+	return g.getThingsToAdd(g.things)
+}
+
+/*
+performGroups computes an operation's changes to each group, and makes them with a single call to the destination, or
+one call for each batch if the destination has a MaxBatchSize. Groups that have failed, or that exceed MaximumChanges,
+are left unchanged.
+*/
+func (m *MultiSync) performGroups(
+	ctx context.Context,
+	logger *slog.Logger,
+	adapter MultiAdapter,
+	phase Phase,
+	members []*groupSync,
+) error {
+	logger = logger.With(slog.String(LogKeyOperation, string(phase)))
+	logger.Info("Processing things")
+
+	errs := make([]error, 0)
+	changes := make(map[string][]string, len(members))
+	changed := make(map[string]*groupSync, len(members))
+	total := 0
+
+	for _, member := range members {
+		if member.err != nil {
+			continue
+		}
+
+		things := member.diff(phase)
+
+		if len(things) > member.MaximumChanges && member.MaximumChanges != NoChangeLimit {
+			member.Metrics.recordTooManyChanges(member.destination, phase)
+			member.Report.record(member.entry, phase, things,
+				fmt.Sprintf("maximum changes (%d) exceeded", member.MaximumChanges))
+
+			member.err = NewError(member.destination, phase, things,
+				fmt.Errorf("%w(%v)", ErrTooManyChanges, member.MaximumChanges))
+			errs = append(errs, member.err)
+
+			continue
+		}
+
+		if member.err = checkBatches(member.destination, phase, things); member.err != nil {
+			member.Report.record(member.entry, phase, things, "too many changes for a single batch")
+			errs = append(errs, member.err)
+
+			continue
+		}
+
+		member.Report.record(member.entry, phase, things, "")
+
+		if len(things) > 0 {
+			changes[member.destination.group] = things
+			changed[member.destination.group] = member
+			total += len(things)
+		}
+	}
+
+	if m.DryRun {
+		logger.Info("Running in dry run mode, so no changes have been made", slog.Int(LogKeyCount, total))
+
+		return errors.Join(errs...)
+	}
+
+	if total == 0 {
+		logger.Info("No changes required")
+
+		return errors.Join(errs...)
+	}
+
+	logger.Info("Changing things", slog.Int(LogKeyCount, total), slog.Int("groups", len(changes)))
+
+	execute := adapter.Add
+	if phase == PhaseRemove {
+		execute = adapter.Remove
+	}
+
+	destination := &multiGroup{adapter: adapter}
+	ctx, span := m.startSpan(ctx, "gosync.destination."+string(phase), destination, TraceKeyCount.Int(total))
+
+	start := time.Now()
+
+	for _, things := range batchGroups(changes, CapabilitiesOf(destination).MaxBatchSize) {
+		err := execute(ctx, things)
+
+		for group, changedThings := range things {
+			member := changed[group]
+			member.Metrics.recordChanges(member.destination, phase, len(changedThings), err)
+
+			if err != nil {
+				member.err = wrapError(member.destination, phase, changedThings, err)
+			}
+		}
+
+		if err != nil {
+			err = wrapError(destination, phase, flatten(things), err)
+			endSpan(span, err)
+			m.Metrics.observeDuration(destination, phase, start)
+
+			return errors.Join(append(errs, err)...)
+		}
+	}
+
+	endSpan(span, nil)
+	m.Metrics.observeDuration(destination, phase, start)
+
+	return errors.Join(errs...)
+}
+
+// batchGroups splits the things in each group into calls with no more than size things for any group.
+func batchGroups(things map[string][]string, size int) []map[string][]string {
+	calls := make([]map[string][]string, 0, 1)
+
+	for _, group := range sortedKeys(things) {
+		for i, batch := range batch(things[group], size) {
+			if i == len(calls) {
+				calls = append(calls, make(map[string][]string))
+			}
+
+			calls[i][group] = batch
+		}
+	}
+
+	return calls
+}
+
+// flatten returns the things in every group, in the order of the groups.
+func flatten(things map[string][]string) []string {
+	out := make([]string, 0)
+
+	for _, group := range sortedKeys(things) {
+		out = append(out, things[group]...)
+	}
+
+	return out
+}
+
+/*
+syncGroups creates groups that only exist in the source, and deletes groups that only exist in the destination, if
+MultiSync is configured to. Created groups are added to groups, so that their things are added by SyncWith.
+*/
+func (m *MultiSync) syncGroups(
+	ctx context.Context,
+	logger *slog.Logger,
+	adapter MultiAdapter,
+	groups map[string][]string,
+) error {
+	missing := make([]string, 0)
+
+	for _, group := range sortedKeys(m.groups) {
+		if _, ok := groups[group]; !ok {
+			missing = append(missing, group)
+		}
+	}
+
+	unmanaged := make([]string, 0)
+
+	for _, group := range sortedKeys(groups) {
+		if _, ok := m.groups[group]; !ok {
+			unmanaged = append(unmanaged, group)
+		}
+	}
+
+	destination := &multiGroup{adapter: adapter}
+	manager, isManager := adapter.(GroupManager)
+
+	// Creating and deleting groups share an entry in the report, which is only added if groups are changed.
+	reportEntry := -1
+	entry := func() int {
+		if reportEntry < 0 {
+			reportEntry = m.Report.begin(destination, m.OperatingMode, m.DryRun)
+		}
+
+		return reportEntry
+	}
+	phases := m.OperatingMode.phases()
+
+	if m.CreateGroups && slices.Contains(phases, PhaseAdd) && len(missing) > 0 {
+		if !isManager {
+			return NewError(destination, PhaseCreate, missing, ErrUnsupported)
+		}
+
+		create := m.perform(ctx, logger, destination, entry(), PhaseCreate, nil, func(map[string]bool) []string {
+			return missing
+		}, manager.CreateGroups)
+		if err := create(); err != nil {
+			return err
+		}
+
+		for _, group := range missing {
+			groups[group] = nil
+		}
+	} else if len(missing) > 0 {
+		logger.Warn("Skipping groups that don't exist in the destination", slog.Any(LogKeyThings, missing))
+	}
+
+	if m.DeleteGroups && slices.Contains(phases, PhaseRemove) && len(unmanaged) > 0 {
+		if !isManager {
+			return NewError(destination, PhaseDelete, unmanaged, ErrUnsupported)
+		}
+
+		remove := m.perform(ctx, logger, destination, entry(), PhaseDelete, nil, func(map[string]bool) []string {
+			return unmanaged
+		}, manager.DeleteGroups)
+		if err := remove(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys of a map in order, so that groups are always synchronised in the same order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+/*
+Multi combines an Adapter for each group into a MultiAdapter, so that existing adapters can be synchronised with
+MultiSync. Each adapter is called with the things for its own group.
+*/
+func Multi(adapters map[string]Adapter) MultiAdapter { //nolint:ireturn
+	return multiAdapter(adapters)
+}
+
+// multiAdapter is a MultiAdapter made from an Adapter for each group.
+type multiAdapter map[string]Adapter
+
+func (m multiAdapter) Get(ctx context.Context) (map[string][]string, error) {
+	out := make(map[string][]string, len(m))
+
+	for _, group := range sortedKeys(m) {
+		things, err := m[group].Get(ctx)
+		if err != nil {
+			return nil, wrapError(m[group], PhaseGet, nil, err)
+		}
+
+		out[group] = things
+	}
+
+	return out, nil
+}
+
+func (m multiAdapter) Add(ctx context.Context, things map[string][]string) error {
+	return m.each(ctx, PhaseAdd, things, Adapter.Add)
+}
+
+func (m multiAdapter) Remove(ctx context.Context, things map[string][]string) error {
+	return m.each(ctx, PhaseRemove, things, Adapter.Remove)
+}
+
+// each calls fn with the adapter and things for each group.
+func (m multiAdapter) each(
+	ctx context.Context,
+	phase Phase,
+	things map[string][]string,
+	fn func(Adapter, context.Context, []string) error,
+) error {
+	for _, group := range sortedKeys(things) {
+		adapter, ok := m[group]
+		if !ok {
+			return fmt.Errorf("%w(%s)", ErrNotFound, group)
+		}
+
+		if err := fn(adapter, ctx, things[group]); err != nil {
+			return wrapError(adapter, phase, things[group], err)
+		}
+	}
+
+	return nil
+}
+
+// multiGroup is a single group in a MultiAdapter, as an Adapter, so that it can be synchronised by Sync.
+type multiGroup struct {
+	adapter MultiAdapter
+	group   string
+	things  []string // things in the group, which have already been fetched from the MultiAdapter.
+}
+
+// Get things in the group, as previously fetched from the MultiAdapter.
+func (g *multiGroup) Get(_ context.Context) ([]string, error) {
+	return g.things, nil
+}
+
+func (g *multiGroup) Add(ctx context.Context, things []string) error {
+	return g.adapter.Add(ctx, map[string][]string{g.group: things}) //nolint:wrapcheck
+}
+
+func (g *multiGroup) Remove(ctx context.Context, things []string) error {
+	return g.adapter.Remove(ctx, map[string][]string{g.group: things}) //nolint:wrapcheck
+}
+
+// Kind of the MultiAdapter, if it implements Describer.
+func (g *multiGroup) Kind() string {
+	if describer, ok := g.adapter.(Describer); ok {
+		return describer.Kind()
+	}
+
+	return fmt.Sprintf("%T", g.adapter)
+}
+
+// Target is the group.
+func (g *multiGroup) Target() string {
+	return g.group
+}
+
+// Capabilities of the MultiAdapter, if it implements Capable.
+func (g *multiGroup) Capabilities() Capabilities {
+	if capable, ok := g.adapter.(Capable); ok {
+		return capable.Capabilities()
+	}
+
+	return Capabilities{SupportsAdd: true, SupportsRemove: true}
+}
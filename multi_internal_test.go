@@ -0,0 +1,363 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryGroups is a MultiAdapter and GroupManager that keeps its groups in memory, and records changes made to them.
+type memoryGroups struct {
+	groups  map[string][]string
+	added   map[string][]string
+	removed map[string][]string
+	created []string
+	deleted []string
+	gets    int
+	calls   int // calls to Add and Remove.
+	err     error
+}
+
+func newMemoryGroups(groups map[string][]string) *memoryGroups {
+	return &memoryGroups{
+		groups:  groups,
+		added:   make(map[string][]string),
+		removed: make(map[string][]string),
+	}
+}
+
+func (m *memoryGroups) Get(_ context.Context) (map[string][]string, error) {
+	m.gets++
+
+	return m.groups, m.err
+}
+
+func (m *memoryGroups) Add(_ context.Context, things map[string][]string) error {
+	m.calls++
+
+	for group, add := range things {
+		m.added[group] = append(m.added[group], add...)
+	}
+
+	return m.err
+}
+
+func (m *memoryGroups) Remove(_ context.Context, things map[string][]string) error {
+	m.calls++
+
+	for group, remove := range things {
+		m.removed[group] = append(m.removed[group], remove...)
+	}
+
+	return m.err
+}
+
+func (m *memoryGroups) CreateGroups(_ context.Context, groups []string) error {
+	m.created = append(m.created, groups...)
+
+	return m.err
+}
+
+func (m *memoryGroups) DeleteGroups(_ context.Context, groups []string) error {
+	m.deleted = append(m.deleted, groups...)
+
+	return m.err
+}
+
+func (m *memoryGroups) Kind() string {
+	return "test/groups"
+}
+
+func (m *memoryGroups) Target() string {
+	return ""
+}
+
+func TestMultiSync_SyncWith(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Synchronises each group", func(t *testing.T) {
+		t.Parallel()
+
+		source := newMemoryGroups(map[string][]string{
+			"foo": {"alice", "bob"},
+			"bar": {"carol"},
+			"new": {"dave"},
+		})
+		destination := newMemoryGroups(map[string][]string{
+			"foo": {"bob", "erin"},
+			"bar": {"carol"},
+			"old": {"frank"},
+		})
+
+		multiSync := NewMulti(source)
+
+		require.NoError(t, multiSync.SyncWith(ctx, destination))
+		require.NoError(t, multiSync.SyncWith(ctx, destination))
+
+		// The source is only fetched once, and groups that don't exist in both adapters are left alone.
+		assert.Equal(t, 1, source.gets)
+		assert.Equal(t, map[string][]string{"foo": {"alice", "alice"}}, destination.added)
+		assert.Equal(t, map[string][]string{"foo": {"erin", "erin"}}, destination.removed)
+		assert.Empty(t, destination.created)
+		assert.Empty(t, destination.deleted)
+	})
+
+	t.Run("Changes every group at once", func(t *testing.T) {
+		t.Parallel()
+
+		source := newMemoryGroups(map[string][]string{"foo": {"alice"}, "bar": {"bob"}, "baz": {"carol"}})
+		destination := newMemoryGroups(map[string][]string{"foo": {"dave"}, "bar": {"erin"}, "baz": {"carol"}})
+		report := &Report{}
+
+		require.NoError(t, NewMulti(source, func(s *Sync) {
+			s.Report = report
+		}).SyncWith(ctx, destination))
+
+		// One call to Remove and one to Add, each with the changes to every group.
+		assert.Equal(t, 2, destination.calls)
+		assert.Equal(t, map[string][]string{"foo": {"alice"}, "bar": {"bob"}}, destination.added)
+		assert.Equal(t, map[string][]string{"foo": {"dave"}, "bar": {"erin"}}, destination.removed)
+
+		// Each group is still reported as a separate destination.
+		require.Len(t, report.Destinations, 3)
+		assert.Equal(t, "bar", report.Destinations[0].Target)
+		assert.Equal(t, 1, report.Destinations[0].count(PhaseAdd))
+		assert.Equal(t, "baz", report.Destinations[1].Target)
+		assert.Equal(t, 0, report.Destinations[1].count(PhaseAdd))
+	})
+
+	t.Run("Creates and deletes groups", func(t *testing.T) {
+		t.Parallel()
+
+		source := newMemoryGroups(map[string][]string{"foo": {"alice"}, "new": {"bob"}})
+		destination := newMemoryGroups(map[string][]string{"foo": {"alice"}, "old": {"carol"}})
+
+		multiSync := NewMulti(source)
+		multiSync.CreateGroups = true
+		multiSync.DeleteGroups = true
+
+		require.NoError(t, multiSync.SyncWith(ctx, destination))
+
+		assert.Equal(t, []string{"new"}, destination.created)
+		assert.Equal(t, []string{"old"}, destination.deleted)
+		assert.Equal(t, map[string][]string{"new": {"bob"}}, destination.added)
+		assert.Empty(t, destination.removed)
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		t.Parallel()
+
+		source := newMemoryGroups(map[string][]string{"foo": {"alice"}, "new": {"bob"}})
+		destination := newMemoryGroups(map[string][]string{"foo": {"carol"}, "old": {"dave"}})
+
+		multiSync := NewMulti(source, func(s *Sync) {
+			s.DryRun = true
+		})
+		multiSync.CreateGroups = true
+		multiSync.DeleteGroups = true
+
+		require.NoError(t, multiSync.SyncWith(ctx, destination))
+
+		assert.Empty(t, destination.created)
+		assert.Empty(t, destination.deleted)
+		assert.Empty(t, destination.added)
+		assert.Empty(t, destination.removed)
+	})
+
+	t.Run("Add only mode doesn't delete groups", func(t *testing.T) {
+		t.Parallel()
+
+		source := newMemoryGroups(map[string][]string{"new": {"alice"}})
+		destination := newMemoryGroups(map[string][]string{"old": {"bob"}})
+
+		multiSync := NewMulti(source, func(s *Sync) {
+			s.OperatingMode = AddOnly
+		})
+		multiSync.CreateGroups = true
+		multiSync.DeleteGroups = true
+
+		require.NoError(t, multiSync.SyncWith(ctx, destination))
+
+		assert.Equal(t, []string{"new"}, destination.created)
+		assert.Empty(t, destination.deleted)
+	})
+
+	t.Run("Change limit applies to each group", func(t *testing.T) {
+		t.Parallel()
+
+		source := newMemoryGroups(map[string][]string{"foo": {"alice", "bob"}, "bar": {"carol"}})
+		destination := newMemoryGroups(map[string][]string{"foo": {}, "bar": {}})
+
+		err := NewMulti(source, func(s *Sync) {
+			s.MaximumChanges = 1
+		}).SyncWith(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, ErrTooManyChanges)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, "test/groups", syncErr.Kind)
+		assert.Equal(t, "foo", syncErr.Target)
+		assert.Equal(t, PhaseAdd, syncErr.Phase)
+
+		// Groups within the limit are still synchronised.
+		assert.Equal(t, map[string][]string{"bar": {"carol"}}, destination.added)
+	})
+
+	t.Run("Call options", func(t *testing.T) {
+		t.Parallel()
+
+		source := newMemoryGroups(map[string][]string{"foo": {"alice"}, "new": {"bob"}})
+		destination := newMemoryGroups(map[string][]string{"foo": {"carol"}})
+
+		multiSync := NewMulti(source)
+		multiSync.CreateGroups = true
+
+		require.NoError(t, multiSync.SyncWith(ctx, destination, WithDryRun(true)))
+		assert.Empty(t, destination.created)
+		assert.Empty(t, destination.added)
+
+		require.NoError(t, multiSync.SyncWith(ctx, destination, WithOperatingMode(AddOnly)))
+		assert.Equal(t, []string{"new"}, destination.created)
+		assert.Equal(t, map[string][]string{"foo": {"alice"}, "new": {"bob"}}, destination.added)
+		assert.Empty(t, destination.removed)
+		assert.Equal(t, 1, source.gets)
+	})
+
+	t.Run("Creating groups requires a GroupManager", func(t *testing.T) {
+		t.Parallel()
+
+		source := newMemoryGroups(map[string][]string{"new": {"alice"}})
+		destination := Multi(map[string]Adapter{})
+
+		multiSync := NewMulti(source)
+		multiSync.CreateGroups = true
+
+		err := multiSync.SyncWith(ctx, destination)
+
+		require.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("Failed call", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		source := newMemoryGroups(map[string][]string{"foo": {"alice"}, "bar": {"bob"}})
+		destination := &failingGroups{memoryGroups: newMemoryGroups(map[string][]string{"foo": {}, "bar": {}}), err: testErr}
+		report := &Report{}
+
+		err := NewMulti(source, func(s *Sync) {
+			s.Report = report
+		}).SyncWith(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, testErr)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, PhaseAdd, syncErr.Phase)
+		assert.Equal(t, []string{"bob", "alice"}, syncErr.Things)
+
+		// The failure is reported against every group in the call.
+		require.Len(t, report.Destinations, 2)
+
+		for _, destination := range report.Destinations {
+			assert.Contains(t, destination.Error, "foo", destination.Target)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		source := newMemoryGroups(map[string][]string{"foo": {"alice"}})
+		destination := newMemoryGroups(nil)
+		destination.err = testErr
+
+		err := NewMulti(source).SyncWith(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, testErr)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, PhaseGet, syncErr.Phase)
+	})
+
+	t.Run("Same source and destination", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := newMemoryGroups(nil)
+
+		err := NewMulti(adapter).SyncWith(ctx, adapter)
+
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestMulti(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	foo := NewMockAdapter(t)
+	foo.EXPECT().Get(ctx).Return([]string{"alice"}, nil)
+	foo.EXPECT().Add(ctx, []string{"bob"}).Return(nil)
+
+	bar := NewMockAdapter(t)
+	bar.EXPECT().Get(ctx).Return([]string{"carol"}, nil)
+	bar.EXPECT().Remove(ctx, []string{"carol"}).Return(nil)
+
+	adapter := Multi(map[string]Adapter{"foo": foo, "bar": bar})
+
+	things, err := adapter.Get(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"foo": {"alice"}, "bar": {"carol"}}, things)
+	require.NoError(t, adapter.Add(ctx, map[string][]string{"foo": {"bob"}}))
+	require.NoError(t, adapter.Remove(ctx, map[string][]string{"bar": {"carol"}}))
+	require.ErrorIs(t, adapter.Add(ctx, map[string][]string{"baz": {"dave"}}), ErrNotFound)
+}
+
+// failingGroups is a MultiAdapter that can be fetched, but fails to add things to any groups.
+type failingGroups struct {
+	*memoryGroups
+	err error
+}
+
+func (f *failingGroups) Add(ctx context.Context, things map[string][]string) error {
+	_ = f.memoryGroups.Add(ctx, things)
+
+	return f.err
+}
+
+func TestBatchGroups(t *testing.T) {
+	t.Parallel()
+
+	things := map[string][]string{"foo": {"a", "b", "c"}, "bar": {"d"}}
+
+	for name, test := range map[string]struct {
+		size int
+		want []map[string][]string
+	}{
+		"No limit": {
+			size: 0,
+			want: []map[string][]string{things},
+		},
+		"Limit": {
+			size: 2,
+			want: []map[string][]string{{"foo": {"a", "b"}, "bar": {"d"}}, {"foo": {"c"}}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want, batchGroups(things, test.size))
+		})
+	}
+}
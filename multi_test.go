@@ -0,0 +1,49 @@
+package gosync_test
+
+import (
+	"context"
+	"log"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/github/team"
+	"github.com/ovotech/go-sync/adapters/slack/usergroups"
+)
+
+func ExampleNewMulti() {
+	ctx := context.Background()
+
+	// Combine an adapter for each GitHub team into a single source, keyed by the Slack UserGroup handle.
+	source := make(map[string]gosync.Adapter)
+
+	for handle, slug := range map[string]string{"team-foo": "foo", "team-bar": "bar"} {
+		adapter, err := team.Init(ctx, map[gosync.ConfigKey]string{
+			team.GitHubToken: "some-token",
+			team.GitHubOrg:   "ovotech",
+			team.TeamSlug:    slug,
+		})
+		if err != nil {
+			log.Panic(err)
+		}
+
+		source[handle] = adapter
+	}
+
+	// Synchronise every UserGroup in Slack whose handle starts with `team-`.
+	destination, err := usergroups.Init(ctx, map[gosync.ConfigKey]string{
+		usergroups.SlackAPIKey:  "some-token",
+		usergroups.HandlePrefix: "team-",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	multiSync := gosync.NewMulti(gosync.Multi(source), func(s *gosync.Sync) {
+		s.MaximumChanges = 10
+	})
+	multiSync.CreateGroups = true
+
+	err = multiSync.SyncWith(ctx, destination)
+	if err != nil {
+		log.Panic(err)
+	}
+}
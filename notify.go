@@ -0,0 +1,178 @@
+package gosync
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// Summary of a sync, such as a run of a Runner's job, which is sent to Notifiers.
+type Summary struct {
+	Job          string              `json:"job,omitempty"`   // Job is the name of the Runner's job, if any.
+	Destinations []DestinationReport `json:"destinations"`    // Destinations and their changes.
+	Error        string              `json:"error,omitempty"` // Error that the sync failed with, if any.
+}
+
+// Changed returns true if any things were changed, or would have been changed in dry run mode.
+func (s Summary) Changed() bool {
+	for _, destination := range s.Destinations {
+		for _, change := range destination.Changes {
+			if change.Count > 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Summarise creates a Summary of the destinations in a Report, and the error that the sync returned.
+func Summarise(job string, report *Report, err error) Summary {
+	summary := Summary{Job: job, Destinations: report.snapshot()}
+
+	if err != nil {
+		summary.Error = err.Error()
+	}
+
+	return summary
+}
+
+/*
+Notifier is an interface for sending a Summary of a sync somewhere, such as a webhook or an email, so that people know
+when things have been changed, or a sync has failed.
+
+Notifiers can be given to a [Runner], which sends a Summary after each run of a job that changed something or failed.
+Use [Filter] to only notify about some destinations or changes.
+*/
+type Notifier interface {
+	Notify(ctx context.Context, summary Summary) error
+}
+
+// NotifyFilter limits the destinations and changes in a Summary that are sent to a Notifier.
+type NotifyFilter struct {
+	/*
+		Destinations are selectors matched against destination names by [MatchSelector], e.g. `github/team(platform-*)`.
+		Default is every destination.
+	*/
+	Destinations []string
+	Operations   []Phase // Operations to notify about, e.g. PhaseAdd. Default is every operation.
+}
+
+// Filter wraps a Notifier, so that it's only sent the destinations and changes that match the filter.
+func Filter(notifier Notifier, filter NotifyFilter) Notifier { //nolint:ireturn
+	return &filteredNotifier{notifier: notifier, filter: filter}
+}
+
+// filteredNotifier is a Notifier that filters summaries before sending them.
+type filteredNotifier struct {
+	notifier Notifier
+	filter   NotifyFilter
+}
+
+/*
+Notify sends the filtered summary. Changes to other operations, or that don't change any things, are removed, along with
+destinations that are left without changes or errors. If nothing is left and the sync didn't fail, nothing is sent.
+*/
+func (f *filteredNotifier) Notify(ctx context.Context, summary Summary) error {
+	destinations := make([]DestinationReport, 0, len(summary.Destinations))
+
+	for _, destination := range summary.Destinations {
+		matched, err := f.matches(destination)
+		if err != nil {
+			return fmt.Errorf("notify.filter -> %w", err)
+		}
+
+		if !matched {
+			continue
+		}
+
+		changes := make([]Change, 0, len(destination.Changes))
+
+		for _, change := range destination.Changes {
+			if change.Count > 0 && f.notifies(change.Operation) {
+				changes = append(changes, change)
+			}
+		}
+
+		if len(changes) > 0 || destination.Error != "" {
+			destination.Changes = changes
+			destinations = append(destinations, destination)
+		}
+	}
+
+	if len(destinations) == 0 && summary.Error == "" {
+		return nil
+	}
+
+	summary.Destinations = destinations
+
+	return f.notifier.Notify(ctx, summary) //nolint:wrapcheck
+}
+
+// notifies returns true if the filter notifies about changes made by an operation.
+func (f *filteredNotifier) notifies(operation Phase) bool {
+	return len(f.filter.Operations) == 0 || slices.Contains(f.filter.Operations, operation)
+}
+
+// matches returns true if the destination matches one of the filter's selectors.
+func (f *filteredNotifier) matches(destination DestinationReport) (bool, error) {
+	if len(f.filter.Destinations) == 0 {
+		return true, nil
+	}
+
+	for _, selector := range f.filter.Destinations {
+		matched, err := MatchSelector(selector, destination.name())
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+
+	return false, nil
+}
+
+/*
+DefaultNotifyTemplate is the message body that notifiers send if they aren't given a template. Templates are passed a
+[Summary], and can use the `join` function to join a list of things.
+*/
+const DefaultNotifyTemplate = `Go Sync{{ with .Job }} job {{ . }}{{ end }}
+{{- if .Error }} failed: {{ .Error }}{{ else }} finished{{ end }}
+{{- range .Destinations }}
+
+{{ .Adapter }}({{ .Target }}){{ if .DryRun }} (dry run){{ end }}
+{{- range .Changes }}{{ if .Count }}
+  {{ .Operation }}: {{ join .Things ", " }}{{ end }}{{ with .Safeguard }} (stopped: {{ . }}){{ end }}{{ end }}
+{{- with .Error }}
+  error: {{ . }}{{ end }}
+{{- end }}
+`
+
+// ParseNotifyTemplate parses the body of a notification, making the functions available to templates.
+func ParseNotifyTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("notify").Funcs(template.FuncMap{"join": strings.Join}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w(template): %w", ErrInvalidConfig, err)
+	}
+
+	return tmpl, nil
+}
+
+// RenderNotification renders the body of a notification, using DefaultNotifyTemplate if the template is nil.
+func RenderNotification(tmpl *template.Template, summary Summary) (string, error) {
+	if tmpl == nil {
+		var err error
+
+		if tmpl, err = ParseNotifyTemplate(DefaultNotifyTemplate); err != nil {
+			return "", err
+		}
+	}
+
+	var builder strings.Builder
+
+	if err := tmpl.Execute(&builder, summary); err != nil {
+		return "", fmt.Errorf("notify.render -> %w", err)
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,55 @@
+package notify_test
+
+import (
+	"context"
+	"log"
+	"net/smtp"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/notify"
+)
+
+func ExampleNewWebhook() {
+	ctx := context.Background()
+
+	tmpl, err := gosync.ParseNotifyTemplate(`{{ .Job }} changed {{ len .Destinations }} destination(s)`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	webhook := notify.NewWebhook("https://example.com/hooks/go-sync", func(w *notify.Webhook) {
+		w.Headers.Set("Authorization", "Bearer some-token")
+		w.Template = tmpl
+	})
+
+	runner := gosync.NewRunner([]gosync.Job{}, func(r *gosync.Runner) {
+		r.Notifiers = []gosync.Notifier{webhook}
+	})
+
+	if err = runner.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleNewSMTP() {
+	ctx := context.Background()
+
+	email := notify.NewSMTP("smtp.example.com:587", "go-sync@example.com", []string{"lead@example.com"},
+		func(s *notify.SMTP) {
+			s.Auth = smtp.PlainAuth("", "go-sync@example.com", "some-password", "smtp.example.com")
+		})
+
+	// Only email about people being added or removed from the on-call schedule.
+	notifier := gosync.Filter(email, gosync.NotifyFilter{
+		Destinations: []string{"opsgenie/*"},
+		Operations:   []gosync.Phase{gosync.PhaseAdd, gosync.PhaseRemove},
+	})
+
+	runner := gosync.NewRunner([]gosync.Job{}, func(r *gosync.Runner) {
+		r.Notifiers = []gosync.Notifier{notifier}
+	})
+
+	if err := runner.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+}
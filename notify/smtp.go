@@ -0,0 +1,193 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// Ensure [notify.SMTP] fully satisfies the [gosync.Notifier] interface.
+var _ gosync.Notifier = &SMTP{}
+
+// SMTP emails a summary of each sync.
+type SMTP struct {
+	Addr     string             // Addr of the SMTP server, e.g. `smtp.example.com:587`.
+	Auth     smtp.Auth          // Auth for the SMTP server, e.g. smtp.PlainAuth. Default is none.
+	From     string             // From is the sender's email address.
+	To       []string           // To are the recipients' email addresses.
+	Subject  string             // Subject of each email. Default summarises the job and whether it failed.
+	Template *template.Template // Template for the email's body. Default is gosync.DefaultNotifyTemplate.
+
+	sendMail func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// errNoAuth is returned if Auth is set, but the SMTP server doesn't support authentication.
+var errNoAuth = errors.New("smtp server doesn't support AUTH")
+
+// NewSMTP creates a new SMTP notifier.
+func NewSMTP(addr string, from string, to []string, optsFn ...func(*SMTP)) *SMTP {
+	notifier := &SMTP{
+		Addr: addr,
+		From: from,
+		To:   to,
+	}
+
+	notifier.sendMail = notifier.send
+
+	for _, fn := range optsFn {
+		fn(notifier)
+	}
+
+	return notifier
+}
+
+// newlines are stripped from the subject, so that a job's name can't add headers to the email.
+var newlines = strings.NewReplacer("\r", "", "\n", "") //nolint:gochecknoglobals
+
+// subject returns the subject of the email for a summary.
+func (s *SMTP) subject(summary gosync.Summary) string {
+	if s.Subject != "" {
+		return newlines.Replace(s.Subject)
+	}
+
+	subject := "Go Sync"
+	if summary.Job != "" {
+		subject += " job " + newlines.Replace(summary.Job)
+	}
+
+	if summary.Error != "" {
+		return subject + " failed"
+	}
+
+	return subject + " finished"
+}
+
+// Notify emails the summary to each recipient.
+func (s *SMTP) Notify(ctx context.Context, summary gosync.Summary) error {
+	if len(s.To) == 0 {
+		return fmt.Errorf("notify.smtp -> %w(to)", gosync.ErrMissingConfig)
+	}
+
+	body, err := gosync.RenderNotification(s.Template, summary)
+	if err != nil {
+		return fmt.Errorf("notify.smtp -> %w", err)
+	}
+
+	var message strings.Builder
+
+	fmt.Fprintf(&message, "From: %s\r\n", s.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", s.subject(summary))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	message.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	if err = s.sendMail(ctx, s.Addr, s.Auth, s.From, s.To, []byte(message.String())); err != nil {
+		return fmt.Errorf("notify.smtp.sendmail -> %w", err)
+	}
+
+	return nil
+}
+
+/*
+send is smtp.SendMail, but the connection is dialled with the context, and is closed if the context is cancelled or
+its deadline passes, so that an unresponsive server can't block a sync.
+*/
+func (s *SMTP) send(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("addr -> %w", err)
+	}
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("dial -> %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return fmt.Errorf("deadline -> %w", err)
+		}
+	}
+
+	// Interrupt any command that's waiting for the server when the context is cancelled.
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if err = exchange(conn, host, a, from, to, msg); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// exchange sends an email over a connection to an SMTP server, as smtp.SendMail does.
+func exchange(conn net.Conn, host string, a smtp.Auth, from string, to []string, msg []byte) error {
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return fmt.Errorf("client -> %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("starttls -> %w", err)
+		}
+	}
+
+	if a != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("auth -> %w", errNoAuth)
+		}
+
+		if err = client.Auth(a); err != nil {
+			return fmt.Errorf("auth -> %w", err)
+		}
+	}
+
+	if err = client.Mail(from); err != nil {
+		return fmt.Errorf("mail -> %w", err)
+	}
+
+	for _, recipient := range to {
+		if err = client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("rcpt(%s) -> %w", recipient, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("data -> %w", err)
+	}
+
+	if _, err = writer.Write(msg); err != nil {
+		return fmt.Errorf("data -> %w", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf("data -> %w", err)
+	}
+
+	if err = client.Quit(); err != nil {
+		return fmt.Errorf("quit -> %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// smtpServer is a local SMTP stand-in, which accepts a single email and returns its data.
+func smtpServer(t *testing.T) (string, <-chan string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	messages := make(chan string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) {
+			_, _ = conn.Write([]byte(line + "\r\n"))
+		}
+
+		reply("220 localhost")
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			switch command := strings.ToUpper(strings.Fields(line)[0]); command {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "DATA":
+				reply("354 go ahead")
+
+				var data strings.Builder
+
+				for {
+					line, err = reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+
+					data.WriteString(line)
+				}
+
+				messages <- data.String()
+
+				reply("250 ok")
+			case "QUIT":
+				reply("221 bye")
+
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), messages
+}
+
+// silentServer is an SMTP server that accepts connections, but never replies.
+func silentServer(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			t.Cleanup(func() {
+				_ = conn.Close()
+			})
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestSMTP_Notify(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		addr, messages := smtpServer(t)
+
+		notifier := NewSMTP(addr, "go-sync@example.com", []string{"lead@example.com", "team@example.com"})
+
+		require.NoError(t, notifier.Notify(ctx, testSummary))
+
+		message := <-messages
+
+		assert.Contains(t, message, "From: go-sync@example.com\r\n")
+		assert.Contains(t, message, "To: lead@example.com, team@example.com\r\n")
+		assert.Contains(t, message, "Subject: Go Sync job platform finished\r\n")
+		assert.Contains(t, message, "  add: baz@example.com, foo@example.com\r\n")
+	})
+
+	t.Run("Unresponsive server", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond) //nolint:gomnd,mnd
+		defer cancel()
+
+		err := NewSMTP(silentServer(t), "go-sync@example.com", []string{"lead@example.com"}).Notify(ctx, testSummary)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Newlines in the job name", func(t *testing.T) {
+		t.Parallel()
+
+		var sent []byte
+
+		notifier := NewSMTP("smtp.example.com:587", "go-sync@example.com", []string{"lead@example.com"},
+			func(s *SMTP) {
+				s.sendMail = func(_ context.Context, _ string, _ smtp.Auth, _ string, _ []string, msg []byte) error {
+					sent = msg
+
+					return nil
+				}
+			})
+
+		require.NoError(t, notifier.Notify(ctx, gosync.Summary{Job: "platform\r\nBcc: attacker@example.com"}))
+		assert.Contains(t, string(sent), "Subject: Go Sync job platformBcc: attacker@example.com finished\r\n")
+	})
+
+	t.Run("Failed sync", func(t *testing.T) {
+		t.Parallel()
+
+		var sent []byte
+
+		notifier := NewSMTP("smtp.example.com:587", "go-sync@example.com", []string{"lead@example.com"},
+			func(s *SMTP) {
+				s.sendMail = func(_ context.Context, _ string, _ smtp.Auth, _ string, _ []string, msg []byte) error {
+					sent = msg
+
+					return nil
+				}
+			})
+
+		require.NoError(t, notifier.Notify(ctx, gosync.Summary{Job: "platform", Error: "foo"}))
+		assert.Contains(t, string(sent), "Subject: Go Sync job platform failed\r\n")
+		assert.Contains(t, string(sent), "Go Sync job platform failed: foo")
+	})
+
+	t.Run("Send error", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		notifier := NewSMTP("smtp.example.com:587", "go-sync@example.com", []string{"lead@example.com"},
+			func(s *SMTP) {
+				s.sendMail = func(context.Context, string, smtp.Auth, string, []string, []byte) error {
+					return testErr
+				}
+			})
+
+		require.ErrorIs(t, notifier.Notify(ctx, testSummary), testErr)
+	})
+
+	t.Run("Missing recipients", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewSMTP("smtp.example.com:587", "go-sync@example.com", nil).Notify(ctx, testSummary)
+
+		require.ErrorIs(t, err, gosync.ErrMissingConfig)
+	})
+}
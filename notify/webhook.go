@@ -0,0 +1,90 @@
+/*
+Package notify sends summaries of syncs to people, so that they know when things have been changed, or a sync has
+failed. Each notifier implements [gosync.Notifier], and can be passed to a [gosync.Runner], or wrapped in
+[gosync.Filter] to only notify about some destinations or changes.
+
+The message body is rendered from a [text/template], which is passed a [gosync.Summary]. If a notifier isn't given a
+template, it uses [gosync.DefaultNotifyTemplate].
+
+To notify a Slack channel, see the Slack adapter's webhook package.
+*/
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+// Ensure [notify.Webhook] fully satisfies the [gosync.Notifier] interface.
+var _ gosync.Notifier = &Webhook{}
+
+// WebhookPayload is the JSON body that a Webhook posts.
+type WebhookPayload struct {
+	Text    string         `json:"text"`    // Text is the message rendered from the Webhook's template.
+	Summary gosync.Summary `json:"summary"` // Summary of the sync.
+}
+
+// Webhook posts a JSON summary of each sync to a URL.
+type Webhook struct {
+	URL      string             // URL to post summaries to.
+	Headers  http.Header        // Headers added to each request, e.g. for authentication.
+	Template *template.Template // Template for the payload's text. Default is gosync.DefaultNotifyTemplate.
+	Client   *http.Client       // Client used to post summaries. Default is http.DefaultClient.
+}
+
+// NewWebhook creates a new Webhook notifier.
+func NewWebhook(url string, optsFn ...func(*Webhook)) *Webhook {
+	webhook := &Webhook{
+		URL:     url,
+		Headers: make(http.Header),
+		Client:  http.DefaultClient,
+	}
+
+	for _, fn := range optsFn {
+		fn(webhook)
+	}
+
+	return webhook
+}
+
+// Notify posts the summary to the webhook's URL, and fails unless it responds with a 2xx status code.
+func (w *Webhook) Notify(ctx context.Context, summary gosync.Summary) error {
+	text, err := gosync.RenderNotification(w.Template, summary)
+	if err != nil {
+		return fmt.Errorf("notify.webhook -> %w", err)
+	}
+
+	body, err := json.Marshal(WebhookPayload{Text: text, Summary: summary})
+	if err != nil {
+		return fmt.Errorf("notify.webhook.marshal -> %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notify.webhook.request -> %w", err)
+	}
+
+	for key, values := range w.Headers {
+		request.Header[key] = values
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := w.Client.Do(request)
+	if err != nil {
+		return fmt.Errorf("notify.webhook.post -> %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("notify.webhook.post -> unexpected status %s", response.Status) //nolint:goerr113
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gosync "github.com/ovotech/go-sync"
+)
+
+var testSummary = gosync.Summary{
+	Job: "platform",
+	Destinations: []gosync.DestinationReport{{
+		Adapter: "github/team",
+		Target:  "platform",
+		Mode:    gosync.RemoveAdd,
+		Changes: []gosync.Change{
+			{Operation: gosync.PhaseRemove, Count: 1, Things: []string{"bar@example.com"}},
+			{Operation: gosync.PhaseAdd, Count: 2, Things: []string{"baz@example.com", "foo@example.com"}},
+		},
+	}},
+}
+
+func TestWebhook_Notify(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		var payload WebhookPayload
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodPost, request.Method)
+			assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+			assert.Equal(t, "Bearer secret", request.Header.Get("Authorization"))
+			assert.NoError(t, json.NewDecoder(request.Body).Decode(&payload))
+
+			writer.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		webhook := NewWebhook(server.URL, func(w *Webhook) {
+			w.Headers.Set("Authorization", "Bearer secret")
+		})
+
+		require.NoError(t, webhook.Notify(ctx, testSummary))
+		assert.Equal(t, testSummary, payload.Summary)
+		assert.Equal(t, "Go Sync job platform finished\n\n"+
+			"github/team(platform)\n"+
+			"  remove: bar@example.com\n"+
+			"  add: baz@example.com, foo@example.com\n", payload.Text)
+	})
+
+	t.Run("Template", func(t *testing.T) {
+		t.Parallel()
+
+		var payload WebhookPayload
+
+		server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+			assert.NoError(t, json.NewDecoder(request.Body).Decode(&payload))
+		}))
+		defer server.Close()
+
+		tmpl, err := gosync.ParseNotifyTemplate(`{{ len .Destinations }} destination(s) changed`)
+		require.NoError(t, err)
+
+		require.NoError(t, NewWebhook(server.URL, func(w *Webhook) {
+			w.Template = tmpl
+		}).Notify(ctx, testSummary))
+		assert.Equal(t, "1 destination(s) changed", payload.Text)
+	})
+
+	t.Run("Error status", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			writer.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		err := NewWebhook(server.URL).Notify(ctx, testSummary)
+
+		require.ErrorContains(t, err, "500")
+	})
+
+	t.Run("Template error", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewWebhook("http://localhost", func(w *Webhook) {
+			w.Template = template.Must(template.New("").Parse(`{{ .Missing }}`))
+		}).Notify(ctx, testSummary)
+
+		require.Error(t, err)
+	})
+}
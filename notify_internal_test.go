@@ -0,0 +1,262 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier is a Notifier that records the summaries it's sent.
+type recordingNotifier struct {
+	summaries []Summary
+	ctxErrs   []error // ctxErrs are the errors of the contexts that summaries were sent with.
+	err       error
+}
+
+func (r *recordingNotifier) Notify(ctx context.Context, summary Summary) error {
+	r.summaries = append(r.summaries, summary)
+	r.ctxErrs = append(r.ctxErrs, ctx.Err())
+
+	return r.err
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	summary := Summary{
+		Job: "foo",
+		Destinations: []DestinationReport{
+			{Adapter: "github/team", Target: "platform", Changes: []Change{
+				{Operation: PhaseRemove, Count: 0, Things: []string{}},
+				{Operation: PhaseAdd, Count: 1, Things: []string{"alice"}},
+			}},
+			{Adapter: "github/team", Target: "data", Changes: []Change{
+				{Operation: PhaseRemove, Count: 1, Things: []string{"bob"}},
+			}},
+			{Adapter: "slack/usergroup", Target: "S123", Error: "bar"},
+		},
+	}
+
+	tests := map[string]struct {
+		filter  NotifyFilter
+		summary Summary
+		want    []DestinationReport
+	}{
+		"Everything": {
+			summary: summary,
+			want: []DestinationReport{
+				{Adapter: "github/team", Target: "platform", Changes: []Change{
+					{Operation: PhaseAdd, Count: 1, Things: []string{"alice"}},
+				}},
+				summary.Destinations[1],
+				{Adapter: "slack/usergroup", Target: "S123", Changes: []Change{}, Error: "bar"},
+			},
+		},
+		"Destinations": {
+			filter:  NotifyFilter{Destinations: []string{"github/team(plat*)"}},
+			summary: summary,
+			want: []DestinationReport{
+				{Adapter: "github/team", Target: "platform", Changes: []Change{
+					{Operation: PhaseAdd, Count: 1, Things: []string{"alice"}},
+				}},
+			},
+		},
+		"Operations": {
+			filter:  NotifyFilter{Operations: []Phase{PhaseRemove}},
+			summary: summary,
+			want: []DestinationReport{
+				summary.Destinations[1],
+				{Adapter: "slack/usergroup", Target: "S123", Changes: []Change{}, Error: "bar"},
+			},
+		},
+		"Nothing to notify": {
+			filter:  NotifyFilter{Destinations: []string{"opsgenie/*"}},
+			summary: summary,
+		},
+		"Failures are always notified": {
+			filter:  NotifyFilter{Destinations: []string{"opsgenie/*"}},
+			summary: Summary{Job: "foo", Error: "baz"},
+			want:    []DestinationReport{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &recordingNotifier{}
+
+			require.NoError(t, Filter(recorder, test.filter).Notify(ctx, test.summary))
+
+			if test.want == nil {
+				assert.Empty(t, recorder.summaries)
+
+				return
+			}
+
+			require.Len(t, recorder.summaries, 1)
+			assert.Equal(t, test.want, recorder.summaries[0].Destinations)
+			assert.Equal(t, test.summary.Error, recorder.summaries[0].Error)
+		})
+	}
+
+	t.Run("Invalid selector", func(t *testing.T) {
+		t.Parallel()
+
+		err := Filter(&recordingNotifier{}, NotifyFilter{Destinations: []string{"["}}).Notify(ctx, summary)
+
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestRenderNotification(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default template", func(t *testing.T) {
+		t.Parallel()
+
+		text, err := RenderNotification(nil, Summary{
+			Error: "foo",
+			Destinations: []DestinationReport{{
+				Adapter: "github/team",
+				Target:  "platform",
+				DryRun:  true,
+				Changes: []Change{
+					{Operation: PhaseRemove, Count: 0, Things: []string{}},
+					{Operation: PhaseAdd, Count: 2, Things: []string{"alice", "bob"}, Safeguard: "maximum changes (1)"},
+				},
+				Error: "foo",
+			}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Go Sync failed: foo\n\n"+
+			"github/team(platform) (dry run)\n"+
+			"  add: alice, bob (stopped: maximum changes (1))\n"+
+			"  error: foo\n", text)
+	})
+
+	t.Run("Invalid template", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseNotifyTemplate("{{ .Job ")
+
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestRunner_Notifiers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	source := NewMockAdapter(t)
+	source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+	destination := NewMockAdapter(t)
+	destination.EXPECT().Get(ctx).Return([]string{"bar"}, nil)
+	destination.EXPECT().Remove(ctx, []string{"bar"}).Return(nil)
+	destination.EXPECT().Add(ctx, []string{"foo"}).Return(nil)
+
+	recorder := &recordingNotifier{}
+	failing := &recordingNotifier{err: errors.New("foo")} //nolint:goerr113
+
+	runner := NewRunner([]Job{{Name: "foo", Source: source, Destinations: []Adapter{destination}}},
+		func(r *Runner) {
+			r.Notifiers = []Notifier{failing, recorder}
+		})
+
+	// Notifiers that fail don't fail the job.
+	require.NoError(t, runner.RunOnce(ctx))
+	require.Len(t, recorder.summaries, 1)
+
+	summary := recorder.summaries[0]
+
+	assert.Equal(t, "foo", summary.Job)
+	assert.True(t, summary.Changed())
+	require.Len(t, summary.Destinations, 1)
+	assert.Equal(t, []Change{
+		{Operation: PhaseRemove, Count: 1, Things: []string{"bar"}},
+		{Operation: PhaseAdd, Count: 1, Things: []string{"foo"}},
+	}, summary.Destinations[0].Changes)
+}
+
+func TestRunner_Notifiers_Timeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	source := NewMockAdapter(t)
+	source.EXPECT().Get(mock.Anything).RunAndReturn(func(ctx context.Context) ([]string, error) {
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	})
+
+	recorder := &recordingNotifier{}
+
+	runner := NewRunner([]Job{{Name: "foo", Source: source, Destinations: []Adapter{NewMockAdapter(t)}}},
+		func(r *Runner) {
+			r.Timeout = time.Millisecond
+			r.Notifiers = []Notifier{recorder}
+		})
+
+	// Jobs that time out are still notified about, on a context that hasn't been cancelled with the job.
+	require.ErrorIs(t, runner.RunOnce(ctx), context.DeadlineExceeded)
+	require.Len(t, recorder.summaries, 1)
+	assert.Contains(t, recorder.summaries[0].Error, context.DeadlineExceeded.Error())
+	assert.Equal(t, []error{nil}, recorder.ctxErrs)
+}
+
+func TestRunner_Notifiers_Unchanged(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	for name, test := range map[string]struct {
+		always bool
+		err    error
+		want   int
+	}{
+		"Unchanged": {
+			want: 0,
+		},
+		"Unchanged with NotifyAlways": {
+			always: true,
+			want:   1,
+		},
+		"Failed": {
+			err:  errors.New("foo"), //nolint:goerr113
+			want: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			source := NewMockAdapter(t)
+			source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+			destination := NewMockAdapter(t)
+			destination.EXPECT().Get(ctx).Return([]string{"foo"}, test.err)
+
+			recorder := &recordingNotifier{}
+
+			runner := NewRunner([]Job{{Name: "foo", Source: source, Destinations: []Adapter{destination}}},
+				func(r *Runner) {
+					r.Notifiers = []Notifier{recorder}
+					r.NotifyAlways = test.always
+				})
+
+			_ = runner.RunOnce(ctx)
+
+			assert.Len(t, recorder.summaries, test.want)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package gosync
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// AdapterInitFn is an InitFn with the adapter's type erased, so that adapters can be initialised by name.
+type AdapterInitFn func(ctx context.Context, config map[ConfigKey]string) (Adapter, error)
+
+// MultiAdapterInitFn is an InitFn with the MultiAdapter's type erased, so that it can be initialised by name.
+type MultiAdapterInitFn func(ctx context.Context, config map[ConfigKey]string) (MultiAdapter, error)
+
+// registration is an adapter's init function, which is either for an Adapter or a MultiAdapter.
+type registration struct {
+	adapter AdapterInitFn
+	multi   MultiAdapterInitFn
+	schema  ConfigSchema // schema of the config that the init function accepts, if it has been registered.
+}
+
+//nolint:gochecknoglobals
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]registration)
+)
+
+/*
+Register makes an adapter's InitFn available by name, such as `github/team`, so that adapters can be initialised from
+configuration without a switch statement. Adapters in this repository register themselves under their kind when their
+package is imported:
+
+	import _ "github.com/ovotech/go-sync/adapters/github/team"
+
+	initFn, err := gosync.Lookup("github/team")
+	adapter, err := initFn(ctx, map[gosync.ConfigKey]string{"github_org": "my-org", "team_slug": "my-team"})
+
+Register panics if the name has already been registered, or initFn is nil.
+*/
+func Register[T Adapter](name string, initFn InitFn[T]) {
+	if initFn == nil {
+		panic("gosync: Register init function is nil for " + name)
+	}
+
+	register(name, registration{adapter: func(ctx context.Context, config map[ConfigKey]string) (Adapter, error) {
+		return initFn(ctx, config)
+	}})
+}
+
+// RegisterMulti makes a MultiAdapter's InitFn available by name, such as `slack/usergroups`. See [Register].
+func RegisterMulti[T MultiAdapter](name string, initFn InitFn[T]) {
+	if initFn == nil {
+		panic("gosync: RegisterMulti init function is nil for " + name)
+	}
+
+	register(name, registration{multi: func(ctx context.Context, config map[ConfigKey]string) (MultiAdapter, error) {
+		return initFn(ctx, config)
+	}})
+}
+
+// register adds an init function to the registry.
+func register(name string, entry registration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic("gosync: adapter registered twice: " + name)
+	}
+
+	registry[name] = entry
+}
+
+// RegisterSchema sets the ConfigSchema of a registered adapter. It panics if the adapter hasn't been registered.
+func RegisterSchema(name string, schema ConfigSchema) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	entry, ok := registry[name]
+	if !ok {
+		panic("gosync: RegisterSchema called for unregistered adapter: " + name)
+	}
+
+	entry.schema = schema
+	registry[name] = entry
+}
+
+// LookupSchema returns the ConfigSchema of a registered Adapter or MultiAdapter, which is nil if it hasn't been set.
+func LookupSchema(name string) (ConfigSchema, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	entry, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("registry.lookupschema(%s) -> %w", name, ErrNotRegistered)
+	}
+
+	return entry.schema, nil
+}
+
+/*
+Lookup returns the init function of an Adapter registered with [Register], or an error wrapping ErrNotRegistered. Secret
+references in the configuration are resolved before the adapter's InitFn is called, and the values of secret keys are
+redacted from its errors. See [WithSecrets].
+*/
+func Lookup(name string) (AdapterInitFn, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	entry, ok := registry[name]
+	if !ok || entry.adapter == nil {
+		return nil, fmt.Errorf("registry.lookup(%s) -> %w", name, ErrNotRegistered)
+	}
+
+	return func(ctx context.Context, config map[ConfigKey]string) (Adapter, error) {
+		return initWithSecrets(ctx, entry.schema, config, entry.adapter)
+	}, nil
+}
+
+/*
+LookupMulti returns the init function of a MultiAdapter registered with [RegisterMulti], or an error wrapping
+ErrNotRegistered. Secrets are resolved like [Lookup].
+*/
+func LookupMulti(name string) (MultiAdapterInitFn, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	entry, ok := registry[name]
+	if !ok || entry.multi == nil {
+		return nil, fmt.Errorf("registry.lookupmulti(%s) -> %w", name, ErrNotRegistered)
+	}
+
+	return func(ctx context.Context, config map[ConfigKey]string) (MultiAdapter, error) {
+		return initWithSecrets(ctx, entry.schema, config, entry.multi)
+	}, nil
+}
+
+// Registered returns the sorted names of every registered Adapter and MultiAdapter.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,161 @@
+package gosync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unregister removes a name from the registry when a test finishes.
+func unregister(t *testing.T, name string) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+
+		delete(registry, name)
+	})
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	adapter := NewMockAdapter(t)
+	multi := NewMockMultiAdapter(t)
+
+	unregister(t, "test/register")
+	unregister(t, "test/register-multi")
+
+	Register("test/register", func(_ context.Context, config map[ConfigKey]string, _ ...ConfigFn[*MockAdapter]) (
+		*MockAdapter, error,
+	) {
+		assert.Equal(t, map[ConfigKey]string{"foo": "bar"}, config)
+
+		return adapter, nil
+	})
+	RegisterMulti("test/register-multi", func(context.Context, map[ConfigKey]string, ...ConfigFn[*MockMultiAdapter]) (
+		*MockMultiAdapter, error,
+	) {
+		return multi, nil
+	})
+
+	assert.Subset(t, Registered(), []string{"test/register", "test/register-multi"})
+
+	initFn, err := Lookup("test/register")
+	require.NoError(t, err)
+
+	got, err := initFn(ctx, map[ConfigKey]string{"foo": "bar"})
+	require.NoError(t, err)
+	assert.Same(t, adapter, got)
+
+	multiInitFn, err := LookupMulti("test/register-multi")
+	require.NoError(t, err)
+
+	gotMulti, err := multiInitFn(ctx, nil)
+	require.NoError(t, err)
+	assert.Same(t, multi, gotMulti)
+
+	t.Run("Not registered", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Lookup("test/unknown")
+		require.ErrorIs(t, err, ErrNotRegistered)
+
+		// Adapters and MultiAdapters can't be looked up as each other.
+		_, err = Lookup("test/register-multi")
+		require.ErrorIs(t, err, ErrNotRegistered)
+
+		_, err = LookupMulti("test/register")
+		require.ErrorIs(t, err, ErrNotRegistered)
+	})
+
+	t.Run("Registered twice", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithValue(t, "gosync: adapter registered twice: test/register", func() {
+			Register("test/register", func(context.Context, map[ConfigKey]string, ...ConfigFn[*MockAdapter]) (
+				*MockAdapter, error,
+			) {
+				return nil, nil
+			})
+		})
+	})
+
+	t.Run("Nil init function", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() {
+			Register[*MockAdapter]("test/nil", nil)
+		})
+	})
+}
+
+func TestRegisterSchema(t *testing.T) {
+	t.Parallel()
+
+	unregister(t, "test/schema")
+
+	Register("test/schema", func(context.Context, map[ConfigKey]string, ...ConfigFn[*MockAdapter]) (
+		*MockAdapter, error,
+	) {
+		return nil, nil
+	})
+
+	schema, err := LookupSchema("test/schema")
+	require.NoError(t, err)
+	assert.Nil(t, schema)
+
+	RegisterSchema("test/schema", ConfigSchema{{Key: "foo", Required: true}})
+
+	schema, err = LookupSchema("test/schema")
+	require.NoError(t, err)
+	assert.Equal(t, ConfigSchema{{Key: "foo", Required: true}}, schema)
+
+	// The init function is kept when the schema is set.
+	_, err = Lookup("test/schema")
+	require.NoError(t, err)
+
+	_, err = LookupSchema("test/unknown")
+	require.ErrorIs(t, err, ErrNotRegistered)
+
+	assert.PanicsWithValue(t, "gosync: RegisterSchema called for unregistered adapter: test/unknown", func() {
+		RegisterSchema("test/unknown", ConfigSchema{})
+	})
+}
+
+func TestLookup_Secrets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	adapter := NewMockAdapter(t)
+	token := writeSecret(t, "token", "file-secret\n")
+
+	unregister(t, "test/secrets")
+
+	Register("test/secrets", func(_ context.Context, config map[ConfigKey]string, _ ...ConfigFn[*MockAdapter]) (
+		*MockAdapter, error,
+	) {
+		if config["api_key"] != "file-secret" {
+			return nil, fmt.Errorf("unexpected api_key %s", config["api_key"]) //nolint:goerr113
+		}
+
+		return adapter, nil
+	})
+	RegisterSchema("test/secrets", ConfigSchema{{Key: "api_key", Secret: true}})
+
+	initFn, err := Lookup("test/secrets")
+	require.NoError(t, err)
+
+	got, err := initFn(ctx, map[ConfigKey]string{"api_key": "file://" + token})
+	require.NoError(t, err)
+	assert.Same(t, adapter, got)
+
+	// Secret keys are redacted from the init function's errors.
+	_, err = initFn(ctx, map[ConfigKey]string{"api_key": "plain-secret"})
+	require.EqualError(t, err, "unexpected api_key REDACTED")
+}
@@ -0,0 +1,38 @@
+package gosync_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/github/team"
+	_ "github.com/ovotech/go-sync/adapters/slack/conversation" // Register the slack/conversation adapter.
+)
+
+func ExampleLookup() {
+	ctx := context.Background()
+
+	// Adapters are looked up by name, e.g. from a configuration file, rather than with a switch statement.
+	initFn, err := gosync.Lookup("github/team")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	source, err := initFn(ctx, map[gosync.ConfigKey]string{
+		team.GitHubToken: "some-token",
+		team.GitHubOrg:   "my-org",
+		team.TeamSlug:    "my-team",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	gosync.New(source)
+}
+
+func ExampleRegistered() {
+	for _, name := range gosync.Registered() {
+		fmt.Println(name)
+	}
+}
@@ -0,0 +1,327 @@
+package gosync
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// ReportVersion is the version of the JSON schema written by [Report.Render]. It changes if the schema changes.
+const ReportVersion = 1
+
+// ReportFormat is a format that a Report can be rendered in.
+type ReportFormat string
+
+const (
+	// ReportJSON renders a Report as JSON, with a stable schema for parsing in CI.
+	ReportJSON ReportFormat = "json"
+	// ReportMarkdown renders a Report as a Markdown summary, suitable for pull request comments.
+	ReportMarkdown ReportFormat = "markdown"
+	// ReportTable renders a Report as a plain text table, for terminals.
+	ReportTable ReportFormat = "table"
+)
+
+// Change is an operation that Sync has computed for a destination, and the things it changes.
+type Change struct {
+	Operation Phase    `json:"operation"`
+	Count     int      `json:"count"`
+	Things    []string `json:"things"`
+	Safeguard string   `json:"safeguard,omitempty"` // Safeguard that stopped the change, e.g. a change limit.
+}
+
+// DestinationReport is the changes computed for a destination during one call to SyncWith.
+type DestinationReport struct {
+	Adapter string        `json:"adapter"`
+	Target  string        `json:"target"`
+	Mode    OperatingMode `json:"mode"`
+	DryRun  bool          `json:"dryRun"`
+	Changes []Change      `json:"changes"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// count returns the number of things changed by an operation.
+func (d DestinationReport) count(operation Phase) int {
+	total := 0
+
+	for _, change := range d.Changes {
+		if change.Operation == operation {
+			total += change.Count
+		}
+	}
+
+	return total
+}
+
+// status summarises whether the destination was synchronised, or a safeguard or error stopped it.
+func (d DestinationReport) status() string {
+	for _, change := range d.Changes {
+		if change.Safeguard != "" {
+			return "safeguard: " + change.Safeguard
+		}
+	}
+
+	if d.Error != "" {
+		return "error: " + d.Error
+	}
+
+	return "ok"
+}
+
+// name of the destination, e.g. `github/team(my-team)`.
+func (d DestinationReport) name() string {
+	return fmt.Sprintf("%s(%s)", d.Adapter, d.Target)
+}
+
+/*
+Report collects the changes that Sync computes for each destination, including in DryRun mode, so that they can be
+rendered as JSON, Markdown or a table. A Report can be shared by many Sync services, and is safe for concurrent use.
+
+	report := &gosync.Report{}
+	syncService := gosync.New(source, gosync.WithReport(report), func(s *gosync.Sync) {
+		s.DryRun = true
+	})
+
+	err := syncService.SyncWith(ctx, destination)
+	err = report.Render(os.Stdout, gosync.ReportMarkdown)
+*/
+type Report struct {
+	Destinations []DestinationReport // Destinations in the order they were synchronised.
+	mu           sync.Mutex
+}
+
+// WithReport records the changes computed by Sync in a Report. It can be passed to New, or to a single SyncWith call.
+func WithReport(report *Report) CallOption {
+	return func(s *Sync) {
+		s.Report = report
+	}
+}
+
+/*
+begin adds a destination to the report, and returns its index, which following changes to the destination are recorded
+against. Entries are kept by index rather than by adapter, as many destinations can share a kind and target.
+*/
+func (r *Report) begin(adapter Adapter, mode OperatingMode, dryRun bool) int {
+	if r == nil {
+		return -1
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kind, target := Describe(adapter)
+
+	r.Destinations = append(r.Destinations, DestinationReport{
+		Adapter: kind,
+		Target:  target,
+		Mode:    mode,
+		DryRun:  dryRun,
+		Changes: make([]Change, 0),
+	})
+
+	return len(r.Destinations) - 1
+}
+
+// record a change computed for the destination that begin returned entry for, and the safeguard that stopped it.
+func (r *Report) record(entry int, operation Phase, things []string, safeguard string) {
+	if r == nil || entry < 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sorted := slices.Clone(things)
+	if sorted == nil {
+		sorted = make([]string, 0)
+	}
+
+	slices.Sort(sorted)
+
+	r.Destinations[entry].Changes = append(r.Destinations[entry].Changes, Change{
+		Operation: operation,
+		Count:     len(sorted),
+		Things:    sorted,
+		Safeguard: safeguard,
+	})
+}
+
+// finish records the error that the sync of the destination that begin returned entry for returned, if any.
+func (r *Report) finish(entry int, err error) {
+	if r == nil || entry < 0 || err == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Destinations[entry].Error = err.Error()
+}
+
+// reportDocument is the JSON schema of a rendered Report.
+type reportDocument struct {
+	Version      int                 `json:"version"`
+	Totals       map[Phase]int       `json:"totals"`
+	Destinations []DestinationReport `json:"destinations"`
+}
+
+// snapshot returns a copy of the report's destinations.
+func (r *Report) snapshot() []DestinationReport {
+	if r == nil {
+		return make([]DestinationReport, 0)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	destinations := slices.Clone(r.Destinations)
+	if destinations == nil {
+		destinations = make([]DestinationReport, 0)
+	}
+
+	return destinations
+}
+
+// Render writes the report to w in the given format.
+func (r *Report) Render(w io.Writer, format ReportFormat) error {
+	destinations := r.snapshot()
+
+	var err error
+
+	switch format {
+	case ReportJSON:
+		err = renderJSON(w, destinations)
+	case ReportMarkdown:
+		err = renderMarkdown(w, destinations)
+	case ReportTable:
+		err = renderTable(w, destinations)
+	default:
+		return fmt.Errorf("report.render -> %w(format %s)", ErrInvalidConfig, format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("report.render(%s) -> %w", format, err)
+	}
+
+	return nil
+}
+
+// reportOperations are the operations that have their own columns in Markdown and table reports.
+var reportOperations = []Phase{PhaseAdd, PhaseRemove, PhaseUpdate}
+
+// renderJSON writes destinations as a JSON document, with the total number of things changed by each operation.
+func renderJSON(w io.Writer, destinations []DestinationReport) error {
+	totals := make(map[Phase]int)
+
+	for _, destination := range destinations {
+		for _, change := range destination.Changes {
+			totals[change.Operation] += change.Count
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(reportDocument{ //nolint:wrapcheck
+		Version:      ReportVersion,
+		Totals:       totals,
+		Destinations: destinations,
+	})
+}
+
+// renderMarkdown writes destinations as a Markdown table, with the things changed in each in collapsible details.
+func renderMarkdown(w io.Writer, destinations []DestinationReport) error {
+	var builder strings.Builder
+
+	builder.WriteString("## Go Sync report\n\n")
+
+	if len(destinations) == 0 {
+		builder.WriteString("No destinations were synchronised.\n")
+
+		_, err := io.WriteString(w, builder.String())
+
+		return err //nolint:wrapcheck
+	}
+
+	builder.WriteString("| Destination | Mode |")
+
+	for _, operation := range reportOperations {
+		fmt.Fprintf(&builder, " %s |", strings.ToUpper(string(operation[:1]))+string(operation[1:]))
+	}
+
+	builder.WriteString(" Status |\n| --- | --- |" + strings.Repeat(" ---: |", len(reportOperations)) + " --- |\n")
+
+	for _, destination := range destinations {
+		mode := string(destination.Mode)
+		if destination.DryRun {
+			mode += " (dry run)"
+		}
+
+		fmt.Fprintf(&builder, "| `%s` | %s |", destination.name(), mode)
+
+		for _, operation := range reportOperations {
+			fmt.Fprintf(&builder, " %d |", destination.count(operation))
+		}
+
+		fmt.Fprintf(&builder, " %s |\n", markdownEscape(destination.status()))
+	}
+
+	for _, destination := range destinations {
+		if len(destination.Changes) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&builder, "\n<details>\n<summary><code>%s</code></summary>\n", destination.name())
+
+		for _, change := range destination.Changes {
+			fmt.Fprintf(&builder, "\n**%s (%d)**", change.Operation, change.Count)
+
+			if change.Safeguard != "" {
+				fmt.Fprintf(&builder, " ⚠️ %s", markdownEscape(change.Safeguard))
+			}
+
+			builder.WriteString("\n\n")
+
+			for _, thing := range change.Things {
+				fmt.Fprintf(&builder, "- `%s`\n", thing)
+			}
+		}
+
+		builder.WriteString("\n</details>\n")
+	}
+
+	_, err := io.WriteString(w, builder.String())
+
+	return err //nolint:wrapcheck
+}
+
+// markdownEscape escapes text so that it can be written in a Markdown table cell.
+func markdownEscape(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
+
+// renderTable writes destinations as a plain text table, with the number of things changed by each operation.
+func renderTable(w io.Writer, destinations []DestinationReport) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint:gomnd,mnd
+
+	columns := []string{"DESTINATION", "MODE", "DRY RUN"}
+	for _, operation := range reportOperations {
+		columns = append(columns, strings.ToUpper(string(operation)))
+	}
+
+	fmt.Fprintln(table, strings.Join(append(columns, "STATUS"), "\t"))
+
+	for _, destination := range destinations {
+		row := []string{destination.name(), string(destination.Mode), fmt.Sprint(destination.DryRun)}
+		for _, operation := range reportOperations {
+			row = append(row, fmt.Sprint(destination.count(operation)))
+		}
+
+		fmt.Fprintln(table, strings.Join(append(row, destination.status()), "\t"))
+	}
+
+	return table.Flush() //nolint:wrapcheck
+}
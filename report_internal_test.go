@@ -0,0 +1,205 @@
+package gosync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namedAdapter is a MockAdapter with a Kind and Target, so that it can be identified in reports.
+type namedAdapter struct {
+	*MockAdapter
+	target string
+}
+
+func (n *namedAdapter) Kind() string {
+	return "test/named"
+}
+
+func (n *namedAdapter) Target() string {
+	return n.target
+}
+
+// createReport dry runs a sync with two destinations, one of which trips a change limit.
+func createReport(ctx context.Context, t *testing.T) *Report {
+	t.Helper()
+
+	source := NewMockAdapter(t)
+	source.EXPECT().Get(ctx).Once().Return([]string{"foo", "bar", "baz"}, nil)
+
+	team := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "team"}
+	team.EXPECT().Get(ctx).Return([]string{"foo", "qux"}, nil)
+
+	channel := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "channel"}
+	channel.EXPECT().Get(ctx).Return([]string{"qux"}, nil)
+
+	report := &Report{}
+	syncService := New(source, WithReport(report), func(s *Sync) {
+		s.DryRun = true
+	})
+
+	require.NoError(t, syncService.SyncWith(ctx, team))
+	require.ErrorIs(t, syncService.SyncWith(ctx, channel, WithMaximumChanges(2)), ErrTooManyChanges)
+
+	return report
+}
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	report := createReport(ctx, t)
+
+	require.Len(t, report.Destinations, 2)
+	assert.Equal(t, DestinationReport{
+		Adapter: "test/named",
+		Target:  "team",
+		Mode:    RemoveAdd,
+		DryRun:  true,
+		Changes: []Change{
+			{Operation: PhaseRemove, Count: 1, Things: []string{"qux"}},
+			{Operation: PhaseAdd, Count: 2, Things: []string{"bar", "baz"}},
+		},
+	}, report.Destinations[0])
+
+	channel := report.Destinations[1]
+
+	assert.Equal(t, []Change{
+		{Operation: PhaseRemove, Count: 1, Things: []string{"qux"}},
+		{Operation: PhaseAdd, Count: 3, Things: []string{"bar", "baz", "foo"}, Safeguard: "maximum changes (2) exceeded"},
+	}, channel.Changes)
+	assert.Contains(t, channel.Error, "too many changes")
+}
+
+func TestReport_SameTarget(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	report := &Report{}
+
+	// Both syncs begin before either records its changes, so each must record against its own entry.
+	var started sync.WaitGroup
+
+	started.Add(2) //nolint:gomnd,mnd
+
+	newDestination := func(things ...string) Adapter {
+		destination := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "team"}
+		destination.EXPECT().Get(ctx).RunAndReturn(func(context.Context) ([]string, error) {
+			started.Done()
+			started.Wait()
+
+			return things, nil
+		})
+
+		return destination
+	}
+
+	errs := make(chan error, 2) //nolint:gomnd,mnd
+
+	for _, destination := range []Adapter{newDestination("foo"), newDestination("bar")} {
+		go func() {
+			errs <- New(&pagedAdapter{pages: [][]string{{"foo"}}}, WithReport(report), func(s *Sync) {
+				s.DryRun = true
+			}).SyncWith(ctx, destination)
+		}()
+	}
+
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+	require.Len(t, report.Destinations, 2)
+
+	for _, destination := range report.Destinations {
+		assert.Len(t, destination.Changes, 2)
+	}
+}
+
+func TestReport_Render(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, createReport(ctx, t).Render(&buf, ReportJSON))
+
+		var document struct {
+			Version      int            `json:"version"`
+			Totals       map[string]int `json:"totals"`
+			Destinations []struct {
+				Target  string `json:"target"`
+				DryRun  bool   `json:"dryRun"`
+				Changes []struct {
+					Operation string   `json:"operation"`
+					Things    []string `json:"things"`
+					Safeguard string   `json:"safeguard"`
+				} `json:"changes"`
+			} `json:"destinations"`
+		}
+
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+		assert.Equal(t, ReportVersion, document.Version)
+		assert.Equal(t, map[string]int{"add": 5, "remove": 2}, document.Totals)
+		require.Len(t, document.Destinations, 2)
+		assert.Equal(t, "team", document.Destinations[0].Target)
+		assert.True(t, document.Destinations[0].DryRun)
+		assert.Equal(t, "maximum changes (2) exceeded", document.Destinations[1].Changes[1].Safeguard)
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, createReport(ctx, t).Render(&buf, ReportMarkdown))
+
+		markdown := buf.String()
+
+		assert.Contains(t, markdown, "| Destination | Mode | Add | Remove | Update | Status |\n")
+		assert.Contains(t, markdown, "| `test/named(team)` | RemoveAdd (dry run) | 2 | 1 | 0 | ok |\n")
+		assert.Contains(t, markdown,
+			"| `test/named(channel)` | RemoveAdd (dry run) | 3 | 1 | 0 | safeguard: maximum changes (2) exceeded |\n")
+		assert.Contains(t, markdown, "**add (3)** ⚠️ maximum changes (2) exceeded\n\n- `bar`\n- `baz`\n- `foo`\n")
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, createReport(ctx, t).Render(&buf, ReportTable))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+		require.Len(t, lines, 3)
+		assert.Equal(t, []string{"DESTINATION", "MODE", "DRY", "RUN", "ADD", "REMOVE", "UPDATE", "STATUS"},
+			strings.Fields(lines[0]))
+		assert.Equal(t, []string{"test/named(team)", "RemoveAdd", "true", "2", "1", "0", "ok"}, strings.Fields(lines[1]))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, (&Report{}).Render(&buf, ReportJSON))
+		assert.JSONEq(t, `{"version": 1, "totals": {}, "destinations": []}`, buf.String())
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		t.Parallel()
+
+		err := (&Report{}).Render(&bytes.Buffer{}, "xml")
+
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
@@ -0,0 +1,47 @@
+package gosync_test
+
+import (
+	"context"
+	"log"
+	"os"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/github/team"
+	"github.com/ovotech/go-sync/adapters/slack/conversation"
+)
+
+func ExampleReport() {
+	ctx := context.Background()
+
+	source, err := team.Init(ctx, map[gosync.ConfigKey]string{
+		team.GitHubToken: "some-token",
+		team.GitHubOrg:   "my-org",
+		team.TeamSlug:    "my-team",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	destination, err := conversation.Init(ctx, map[gosync.ConfigKey]string{
+		conversation.SlackAPIKey: "some-key",
+		conversation.Name:        "my-channel",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// Record the changes that would be made, without making them.
+	report := &gosync.Report{}
+	syncService := gosync.New(source, gosync.WithReport(report), func(s *gosync.Sync) {
+		s.DryRun = true
+	})
+
+	if err = syncService.SyncWith(ctx, destination); err != nil {
+		log.Print(err)
+	}
+
+	// Render the report as Markdown, e.g. to post as a pull request comment.
+	if err = report.Render(os.Stdout, gosync.ReportMarkdown); err != nil {
+		log.Panic(err)
+	}
+}
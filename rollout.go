@@ -0,0 +1,237 @@
+package gosync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// ErrRolloutHalted is returned when a wave of a rollout fails, so later waves aren't synchronised.
+var ErrRolloutHalted = errors.New("rollout halted")
+
+// ErrNotConverged is returned when a destination still differs from its source after it has been synchronised.
+var ErrNotConverged = errors.New("destination has not converged")
+
+// Wave is a group of destinations in a staged rollout, which are synchronised together.
+type Wave struct {
+	Name         string       // Name identifies the wave in logs and reports, e.g. `canary`.
+	Destinations []Adapter    // Destinations synchronised in this wave, in order.
+	Options      []CallOption // Options override Sync's defaults for this wave, e.g. WithMaximumChanges.
+}
+
+// WaveStatus is the outcome of a wave in a staged rollout.
+type WaveStatus string
+
+const (
+	// WaveCompleted waves were synchronised, and their destinations converged with the source.
+	WaveCompleted WaveStatus = "completed"
+	// WaveFailed waves returned an error, tripped a safeguard or didn't converge, which halted the rollout.
+	WaveFailed WaveStatus = "failed"
+	// WaveSkipped waves weren't synchronised, because an earlier wave failed.
+	WaveSkipped WaveStatus = "skipped"
+)
+
+// WaveReport is the outcome of a wave in a staged rollout.
+type WaveReport struct {
+	Name         string             `json:"name"`
+	Status       WaveStatus         `json:"status"`
+	Destinations []string           `json:"destinations"`
+	Drift        []DestinationDrift `json:"drift,omitempty"` // Drift in destinations that didn't converge.
+	Error        string             `json:"error,omitempty"`
+}
+
+// RolloutReport is the outcome of each wave in a staged rollout.
+type RolloutReport struct {
+	Waves []WaveReport `json:"waves"`
+}
+
+// Halted returns true if a wave failed, so the rollout didn't finish.
+func (r RolloutReport) Halted() bool {
+	for _, wave := range r.Waves {
+		if wave.Status == WaveFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+Rollout synchronises waves of destinations in order, so that a change to the source can be applied to a low-risk
+destination first:
+
+	report, err := syncService.Rollout(ctx, []gosync.Wave{
+		{Name: "canary", Destinations: []gosync.Adapter{sandbox}, Options: []gosync.CallOption{
+			gosync.WithMaximumChanges(5),
+		}},
+		{Name: "everyone", Destinations: []gosync.Adapter{engineering, support}},
+	})
+
+After each wave, its destinations are checked again to make sure they've converged with the source, taking the
+OperatingMode into account. If a destination fails to sync, trips a safeguard such as MaximumChanges, or hasn't
+converged, the rollout halts with an error wrapping ErrRolloutHalted, and later waves are left untouched. Convergence
+isn't checked in DryRun mode, as no changes are made.
+*/
+func (s *Sync) Rollout(ctx context.Context, waves []Wave) (RolloutReport, error) {
+	report := RolloutReport{Waves: make([]WaveReport, 0, len(waves))}
+
+	var halted error
+
+	for index, wave := range waves {
+		name := wave.Name
+		if name == "" {
+			name = fmt.Sprint(index + 1)
+		}
+
+		result := WaveReport{Name: name, Status: WaveSkipped, Destinations: make([]string, 0, len(wave.Destinations))}
+
+		for _, destination := range wave.Destinations {
+			kind, target := Describe(destination)
+			result.Destinations = append(result.Destinations, fmt.Sprintf("%s(%s)", kind, target))
+		}
+
+		if halted == nil {
+			s.Logger.Info("Starting rollout wave", slog.String("wave", name),
+				slog.Int(LogKeyCount, len(wave.Destinations)))
+
+			if err := s.rolloutWave(ctx, wave, &result); err != nil {
+				halted = fmt.Errorf("sync.rollout.wave(%s) -> %w: %w", name, ErrRolloutHalted, err)
+				result.Status = WaveFailed
+				result.Error = err.Error()
+
+				s.Logger.Error("Rollout halted", slog.String("wave", name), slog.Any("error", err))
+			} else {
+				result.Status = WaveCompleted
+			}
+		}
+
+		report.Waves = append(report.Waves, result)
+	}
+
+	return report, halted
+}
+
+// rolloutWave synchronises each destination in a wave, and then checks that they've converged with the source.
+func (s *Sync) rolloutWave(ctx context.Context, wave Wave, result *WaveReport) error {
+	for _, destination := range wave.Destinations {
+		if err := s.SyncWith(ctx, destination, wave.Options...); err != nil {
+			return err
+		}
+	}
+
+	// The wave's options decide which differences are expected after the sync.
+	call := *s
+
+	for _, opt := range wave.Options {
+		opt(&call)
+	}
+
+	call.cache = foldCache(s.cache, s.CaseSensitive, call.CaseSensitive)
+	if call.cache == nil {
+		call.cache = make(map[string]bool)
+	}
+
+	if call.DryRun {
+		return nil
+	}
+
+	phases := call.OperatingMode.phases()
+	errs := make([]error, 0)
+
+	for _, destination := range wave.Destinations {
+		drift, err := call.Check(ctx, destination)
+		if err != nil {
+			return fmt.Errorf("converge -> %w", err)
+		}
+
+		// Things that the operating mode doesn't add or remove are expected to differ.
+		if !slices.Contains(phases, PhaseAdd) {
+			drift.Missing = make([]string, 0)
+		}
+
+		if !slices.Contains(phases, PhaseRemove) {
+			drift.Unexpected = make([]string, 0)
+		}
+
+		if drift.Drifted() {
+			result.Drift = append(result.Drift, drift)
+			errs = append(errs, fmt.Errorf("converge(%s) -> %w", drift.name(), ErrNotConverged))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Render writes the rollout report to w in the given format.
+func (r RolloutReport) Render(w io.Writer, format ReportFormat) error {
+	var err error
+
+	switch format {
+	case ReportJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		err = encoder.Encode(r)
+	case ReportMarkdown:
+		err = r.renderMarkdown(w)
+	case ReportTable:
+		err = r.renderTable(w)
+	default:
+		return fmt.Errorf("rollout.render -> %w(format %s)", ErrInvalidConfig, format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("rollout.render(%s) -> %w", format, err)
+	}
+
+	return nil
+}
+
+// renderMarkdown writes the rollout as a Markdown table of waves.
+func (r RolloutReport) renderMarkdown(w io.Writer) error {
+	var builder strings.Builder
+
+	builder.WriteString("## Go Sync rollout\n\n")
+
+	if r.Halted() {
+		builder.WriteString("The rollout was halted, and later waves were left untouched.\n\n")
+	} else {
+		builder.WriteString("Every wave was completed.\n\n")
+	}
+
+	builder.WriteString("| Wave | Destinations | Status |\n| --- | --- | --- |\n")
+
+	for _, wave := range r.Waves {
+		status := string(wave.Status)
+		if wave.Error != "" {
+			status += ": " + wave.Error
+		}
+
+		fmt.Fprintf(&builder, "| %s | `%s` | %s |\n", wave.Name, strings.Join(wave.Destinations, "`, `"),
+			markdownEscape(status))
+	}
+
+	_, err := io.WriteString(w, builder.String())
+
+	return err //nolint:wrapcheck
+}
+
+// renderTable writes the rollout as a plain text table of waves.
+func (r RolloutReport) renderTable(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint:gomnd,mnd
+
+	fmt.Fprintln(table, "WAVE\tDESTINATIONS\tSTATUS\tERROR")
+
+	for _, wave := range r.Waves {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", wave.Name, strings.Join(wave.Destinations, ", "), wave.Status,
+			strings.ReplaceAll(wave.Error, "\n", " "))
+	}
+
+	return table.Flush() //nolint:wrapcheck
+}
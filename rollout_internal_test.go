@@ -0,0 +1,241 @@
+package gosync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync_Rollout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Completes every wave", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Once().Return([]string{"foo", "bar"}, nil)
+
+		canary := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "canary"}
+		canary.EXPECT().Get(ctx).Once().Return([]string{"bar"}, nil)
+		canary.EXPECT().Add(ctx, []string{"foo"}).Return(nil)
+		canary.EXPECT().Get(ctx).Once().Return([]string{"foo", "bar"}, nil)
+
+		everyone := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "everyone"}
+		everyone.EXPECT().Get(ctx).Return([]string{"foo", "bar"}, nil)
+
+		report, err := New(source).Rollout(ctx, []Wave{
+			{Name: "canary", Destinations: []Adapter{canary}},
+			{Destinations: []Adapter{everyone}},
+		})
+
+		require.NoError(t, err)
+		assert.False(t, report.Halted())
+		assert.Equal(t, RolloutReport{Waves: []WaveReport{
+			{Name: "canary", Status: WaveCompleted, Destinations: []string{"test/named(canary)"}},
+			{Name: "2", Status: WaveCompleted, Destinations: []string{"test/named(everyone)"}},
+		}}, report)
+	})
+
+	t.Run("Halts when a wave exceeds its change limit", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"foo", "bar"}, nil)
+
+		canary := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "canary"}
+		canary.EXPECT().Get(ctx).Return([]string{}, nil)
+
+		// Later waves aren't expected to be called, so the mock fails the test if they are.
+		everyone := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "everyone"}
+
+		report, err := New(source).Rollout(ctx, []Wave{
+			{Name: "canary", Destinations: []Adapter{canary}, Options: []CallOption{WithMaximumChanges(1)}},
+			{Name: "everyone", Destinations: []Adapter{everyone}},
+		})
+
+		require.ErrorIs(t, err, ErrRolloutHalted)
+		require.ErrorIs(t, err, ErrTooManyChanges)
+		assert.True(t, report.Halted())
+		require.Len(t, report.Waves, 2)
+		assert.Equal(t, WaveFailed, report.Waves[0].Status)
+		assert.Contains(t, report.Waves[0].Error, ErrTooManyChanges.Error())
+		assert.Equal(t, WaveSkipped, report.Waves[1].Status)
+	})
+
+	t.Run("Halts when a wave doesn't converge", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		// The destination accepts the change, but doesn't apply it.
+		canary := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "canary"}
+		canary.EXPECT().Get(ctx).Return([]string{}, nil)
+		canary.EXPECT().Add(ctx, []string{"foo"}).Return(nil)
+
+		everyone := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "everyone"}
+
+		report, err := New(source).Rollout(ctx, []Wave{
+			{Name: "canary", Destinations: []Adapter{canary}},
+			{Name: "everyone", Destinations: []Adapter{everyone}},
+		})
+
+		require.ErrorIs(t, err, ErrRolloutHalted)
+		require.ErrorIs(t, err, ErrNotConverged)
+		assert.Equal(t, WaveFailed, report.Waves[0].Status)
+		assert.Equal(t, []DestinationDrift{{
+			Adapter:    "test/named",
+			Target:     "canary",
+			Missing:    []string{"foo"},
+			Unexpected: []string{},
+		}}, report.Waves[0].Drift)
+		assert.Equal(t, WaveSkipped, report.Waves[1].Status)
+	})
+
+	t.Run("Convergence respects the operating mode", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		canary := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "canary"}
+		canary.EXPECT().Get(ctx).Return([]string{"foo", "bar"}, nil)
+
+		report, err := New(source).Rollout(ctx, []Wave{
+			{Name: "canary", Destinations: []Adapter{canary}, Options: []CallOption{WithOperatingMode(AddOnly)}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, WaveCompleted, report.Waves[0].Status)
+	})
+
+	t.Run("Convergence respects the wave's options", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"Foo"}, nil)
+
+		// Only a case-insensitive check treats the destination as converged.
+		canary := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "canary"}
+		canary.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		report, err := New(source).Rollout(ctx, []Wave{
+			{Name: "canary", Destinations: []Adapter{canary}, Options: []CallOption{WithCaseSensitive(false)}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, WaveCompleted, report.Waves[0].Status)
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+		// Convergence isn't checked, so the destination is only fetched once.
+		canary := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "canary"}
+		canary.EXPECT().Get(ctx).Once().Return([]string{}, nil)
+
+		report, err := New(source).Rollout(ctx, []Wave{
+			{Name: "canary", Destinations: []Adapter{canary}, Options: []CallOption{WithDryRun(true)}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, WaveCompleted, report.Waves[0].Status)
+	})
+}
+
+func TestRolloutReport_Render(t *testing.T) {
+	t.Parallel()
+
+	report := RolloutReport{Waves: []WaveReport{
+		{Name: "canary", Status: WaveFailed, Destinations: []string{"test/named(canary)"}, Error: "foo | bar"},
+		{Name: "everyone", Status: WaveSkipped, Destinations: []string{"test/named(a)", "test/named(b)"}},
+	}}
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, report.Render(&buf, ReportJSON))
+
+		var document RolloutReport
+
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+		assert.Equal(t, report, document)
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, report.Render(&buf, ReportMarkdown))
+		assert.Contains(t, buf.String(), "The rollout was halted")
+		assert.Contains(t, buf.String(), "| canary | `test/named(canary)` | failed: foo \\| bar |")
+		assert.Contains(t, buf.String(), "| everyone | `test/named(a)`, `test/named(b)` | skipped |")
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		require.NoError(t, report.Render(&buf, ReportTable))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, []string{"WAVE", "DESTINATIONS", "STATUS", "ERROR"}, strings.Fields(lines[0]))
+		assert.Contains(t, lines[2], "skipped")
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, report.Render(&bytes.Buffer{}, "foo"), ErrInvalidConfig)
+	})
+}
+
+func TestRunner_Waves(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	source := NewMockAdapter(t)
+	source.EXPECT().Get(ctx).Return([]string{"foo"}, nil)
+
+	canary := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "canary"}
+	canary.EXPECT().Get(ctx).Return([]string{"bar", "baz"}, nil)
+
+	everyone := &namedAdapter{MockAdapter: NewMockAdapter(t), target: "everyone"}
+
+	runner := NewRunner([]Job{{
+		Name:   "foo",
+		Source: source,
+		Waves: []Wave{
+			{Name: "canary", Destinations: []Adapter{canary}, Options: []CallOption{WithMaximumChanges(1)}},
+			{Name: "everyone", Destinations: []Adapter{everyone}},
+		},
+	}})
+
+	err := runner.RunOnce(ctx)
+
+	require.ErrorIs(t, err, ErrRolloutHalted)
+	require.ErrorIs(t, err, ErrTooManyChanges)
+
+	// The outcome of each wave is recorded in the job's status.
+	health := runner.Health()
+	require.Len(t, health, 1)
+	require.NotNil(t, health[0].LastRollout)
+	assert.Equal(t, WaveFailed, health[0].LastRollout.Waves[0].Status)
+	assert.Equal(t, WaveSkipped, health[0].LastRollout.Waves[1].Status)
+}
@@ -0,0 +1,55 @@
+package gosync_test
+
+import (
+	"context"
+	"log"
+	"os"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/github/team"
+	"github.com/ovotech/go-sync/adapters/slack/conversation"
+)
+
+func ExampleSync_Rollout() {
+	ctx := context.Background()
+
+	source, err := team.Init(ctx, map[gosync.ConfigKey]string{
+		team.GitHubToken: "some-token",
+		team.GitHubOrg:   "my-org",
+		team.TeamSlug:    "my-team",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	destinations := make(map[string]gosync.Adapter)
+
+	for _, name := range []string{"sandbox", "engineering", "support"} {
+		destinations[name], err = conversation.Init(ctx, map[gosync.ConfigKey]string{
+			conversation.SlackAPIKey: "some-key",
+			conversation.Name:        name,
+		})
+		if err != nil {
+			log.Panic(err)
+		}
+	}
+
+	// Sync the low-risk sandbox channel first, and only continue if it converges without too many changes.
+	report, err := gosync.New(source).Rollout(ctx, []gosync.Wave{
+		{
+			Name:         "canary",
+			Destinations: []gosync.Adapter{destinations["sandbox"]},
+			Options:      []gosync.CallOption{gosync.WithMaximumChanges(5)},
+		},
+		{
+			Name:         "everyone",
+			Destinations: []gosync.Adapter{destinations["engineering"], destinations["support"]},
+		},
+	})
+
+	_ = report.Render(os.Stdout, gosync.ReportTable)
+
+	if err != nil {
+		log.Panic(err)
+	}
+}
@@ -0,0 +1,402 @@
+package gosync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Ensure Runner can be used as a health check endpoint.
+var _ http.Handler = &Runner{}
+
+const (
+	// DefaultInterval is the default time a Runner waits between successful runs of a job.
+	DefaultInterval = 5 * time.Minute
+	// DefaultBackoffInitial is the default time a Runner waits after the first failed run of a job.
+	DefaultBackoffInitial = 30 * time.Second
+	// DefaultBackoffMax is the default upper limit for the time a Runner waits after consecutive failures.
+	DefaultBackoffMax = time.Hour
+	// NoTimeout tells a Runner not to set a timeout on each run of a job.
+	NoTimeout time.Duration = 0
+	// DefaultNotifyTimeout is the default time a Runner waits for its notifiers after each run of a job.
+	DefaultNotifyTimeout = 30 * time.Second
+)
+
+// Job is a unit of work for a Runner, synchronising a source adapter with one or more destination adapters.
+type Job struct {
+	Name         string        // Name identifies the job in logs and health status, and must be unique.
+	Source       Adapter       // Source adapter.
+	Destinations []Adapter     // Destination adapters, synchronised in order.
+	Waves        []Wave        // Waves of destinations rolled out after Destinations. See [Sync.Rollout].
+	Discovery    *Discovery    // Discovery finds more destinations on each run, after Destinations. See [FanOut].
+	Options      []func(*Sync) // Options are passed to New when creating the Sync service for each run.
+	Timeout      time.Duration // Timeout overrides the Runner's timeout for this job.
+}
+
+// JobStatus is a point-in-time health status of a Job.
+type JobStatus struct {
+	Name                string    `json:"name"`
+	Running             bool      `json:"running"`
+	Runs                int       `json:"runs"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	LastRun             time.Time `json:"lastRun"`
+	LastSuccess         time.Time `json:"lastSuccess"`
+	LastError           string    `json:"lastError,omitempty"`
+	NextRun             time.Time `json:"nextRun"`
+	// LastRollout is the outcome of each wave in the job's last run, if it has Waves.
+	LastRollout *RolloutReport `json:"lastRollout,omitempty"`
+}
+
+// Healthy returns true if the job hasn't run yet, or its last run was successful.
+func (j JobStatus) Healthy() bool {
+	return j.ConsecutiveFailures == 0
+}
+
+/*
+Runner executes a set of Jobs on an interval, until its context is cancelled or the process receives a shutdown signal.
+
+Each run of a job creates a new Sync service, so the source adapter is polled once per run. Successful runs are
+repeated every Interval, with up to Jitter added to spread the load on third party services. Failed runs are retried
+with an exponential backoff, starting at BackoffInitial and doubling for each consecutive failure up to BackoffMax.
+*/
+type Runner struct {
+	Interval       time.Duration // Wait between successful runs of a job. Default is DefaultInterval.
+	Jitter         time.Duration // Maximum random duration added to each wait. Default is 0.
+	BackoffInitial time.Duration // Wait after a job first fails. Default is DefaultBackoffInitial.
+	BackoffMax     time.Duration // Maximum wait after consecutive failures. Default is DefaultBackoffMax.
+	Timeout        time.Duration // Timeout for each run of a job. Default is NoTimeout.
+	Signals        []os.Signal   // Signals that gracefully shut down the Runner. Default is SIGTERM and SIGINT.
+	Notifiers      []Notifier    // Notifiers are sent a Summary of runs that change things or fail. Default is none.
+	NotifyTimeout  time.Duration // Timeout for sending notifications. Default is DefaultNotifyTimeout.
+	NotifyAlways   bool          // NotifyAlways sends a Summary of runs that didn't change anything. Default is false.
+	DriftState     DriftState    // DriftState records when drift was first seen by Check. Default is none.
+	Logger         *slog.Logger
+
+	jobs   []Job
+	mu     sync.RWMutex
+	status map[string]*JobStatus
+	after  func(time.Duration) <-chan time.Time // after allows the passage of time to be mocked in tests.
+}
+
+// NewRunner creates a new Runner for a set of jobs.
+func NewRunner(jobs []Job, optsFn ...func(*Runner)) *Runner {
+	runner := &Runner{
+		Interval:       DefaultInterval,
+		Jitter:         0,
+		BackoffInitial: DefaultBackoffInitial,
+		BackoffMax:     DefaultBackoffMax,
+		Timeout:        NoTimeout,
+		NotifyTimeout:  DefaultNotifyTimeout,
+		Signals:        []os.Signal{syscall.SIGTERM, os.Interrupt},
+		Logger:         slog.Default(),
+		jobs:           jobs,
+		status:         make(map[string]*JobStatus, len(jobs)),
+		after:          time.After,
+	}
+
+	for _, job := range jobs {
+		runner.status[job.Name] = &JobStatus{Name: job.Name}
+	}
+
+	for _, fn := range optsFn {
+		fn(runner)
+	}
+
+	return runner
+}
+
+// validate ensures the jobs can be run.
+func (r *Runner) validate() error {
+	names := make(map[string]bool, len(r.jobs))
+
+	for _, job := range r.jobs {
+		if job.Name == "" {
+			return fmt.Errorf("job name -> %w", ErrMissingConfig)
+		}
+
+		if names[job.Name] {
+			return fmt.Errorf("job(%s) -> %w(duplicate name)", job.Name, ErrInvalidConfig)
+		}
+
+		// A job's Source may only be omitted if its destinations are all discovered with their own sources.
+		explicit := len(job.Destinations) > 0 || len(job.Waves) > 0
+		if job.Source == nil && (explicit || job.Discovery == nil || job.Discovery.Source == nil) {
+			return fmt.Errorf("job(%s).source -> %w", job.Name, ErrMissingConfig)
+		}
+
+		names[job.Name] = true
+	}
+
+	return nil
+}
+
+// delay calculates how long to wait before the next run of a job, given the number of consecutive failures.
+func (r *Runner) delay(failures int) time.Duration {
+	wait := r.Interval
+
+	if failures > 0 {
+		wait = r.BackoffInitial
+
+		for i := 1; i < failures && wait < r.BackoffMax; i++ {
+			wait *= 2
+		}
+
+		if wait > r.BackoffMax {
+			wait = r.BackoffMax
+		}
+	}
+
+	if r.Jitter > 0 {
+		wait += rand.N(r.Jitter) //nolint:gosec
+	}
+
+	return wait
+}
+
+// execute runs a job once, synchronising each of its destinations in turn.
+func (r *Runner) execute(ctx context.Context, job Job) error {
+	timeout := r.Timeout
+	if job.Timeout != NoTimeout {
+		timeout = job.Timeout
+	}
+
+	if timeout != NoTimeout {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	r.setStatus(job.Name, func(status *JobStatus) {
+		status.Running = true
+	})
+
+	// Changes are recorded in a report for each run, if there are any notifiers to summarise them for.
+	var report *Report
+
+	options := job.Options
+
+	if len(r.Notifiers) > 0 {
+		report = &Report{}
+		options = append(slices.Clone(options), WithReport(report))
+	}
+
+	syncService := New(job.Source, options...)
+
+	var (
+		err     error
+		rollout *RolloutReport
+	)
+
+	for _, destination := range job.Destinations {
+		if err = syncService.SyncWith(ctx, destination); err != nil {
+			err = fmt.Errorf("runner.execute(%s) -> %w", job.Name, err)
+
+			break
+		}
+	}
+
+	if err == nil && len(job.Waves) > 0 {
+		var result RolloutReport
+
+		result, err = syncService.Rollout(ctx, job.Waves)
+		rollout = &result
+
+		for _, wave := range result.Waves {
+			r.Logger.Info("Rollout wave finished", slog.String(LogKeyJob, job.Name), slog.String("wave", wave.Name),
+				slog.String("status", string(wave.Status)))
+		}
+
+		if err != nil {
+			err = fmt.Errorf("runner.execute(%s) -> %w", job.Name, err)
+		}
+	}
+
+	if err == nil && job.Discovery != nil {
+		discovery := *job.Discovery
+
+		// Discovered destinations are synchronised with the job's source, unless the Discovery derives its own.
+		if discovery.Source == nil {
+			discovery.Source = StaticSource(job.Source)
+		}
+
+		if err = FanOut(ctx, discovery, options...); err != nil {
+			err = fmt.Errorf("runner.execute(%s) -> %w", job.Name, err)
+		}
+	}
+
+	r.notify(ctx, Summarise(job.Name, report, err))
+
+	r.setStatus(job.Name, func(status *JobStatus) {
+		status.Running = false
+		status.Runs++
+		status.LastRun = time.Now()
+		status.LastRollout = rollout
+
+		if err != nil {
+			status.ConsecutiveFailures++
+			status.LastError = err.Error()
+		} else {
+			status.ConsecutiveFailures = 0
+			status.LastSuccess = status.LastRun
+			status.LastError = ""
+		}
+	})
+
+	return err
+}
+
+/*
+notify sends a summary to each of the Runner's notifiers. Notifiers that fail are logged, but don't fail the job.
+
+The job's context is likely to be done when the job timed out or the Runner is shutting down, which is when a summary is
+most useful, so notifications are sent on a context that isn't cancelled with it, and has its own NotifyTimeout.
+*/
+func (r *Runner) notify(ctx context.Context, summary Summary) {
+	// Most runs don't change anything, so they're only worth a notification if they failed, or it's been asked for.
+	if len(r.Notifiers) == 0 || (!r.NotifyAlways && !summary.Changed() && summary.Error == "") {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.NotifyTimeout)
+	defer cancel()
+
+	for _, notifier := range r.Notifiers {
+		if err := notifier.Notify(ctx, summary); err != nil {
+			r.Logger.Error("Failed to send notification", slog.String(LogKeyJob, summary.Job), slog.Any("error", err))
+		}
+	}
+}
+
+// loop runs a job repeatedly until the context is cancelled.
+func (r *Runner) loop(ctx context.Context, job Job) {
+	for {
+		if err := r.execute(ctx, job); err != nil {
+			r.Logger.Error("Job failed", slog.String(LogKeyJob, job.Name), slog.Any("error", err))
+		} else {
+			r.Logger.Info("Job finished successfully", slog.String(LogKeyJob, job.Name))
+		}
+
+		var wait time.Duration
+
+		r.setStatus(job.Name, func(status *JobStatus) {
+			wait = r.delay(status.ConsecutiveFailures)
+			status.NextRun = time.Now().Add(wait)
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.after(wait):
+		}
+	}
+}
+
+// setStatus safely updates the status of a job.
+func (r *Runner) setStatus(name string, fn func(status *JobStatus)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fn(r.status[name])
+}
+
+/*
+Run the jobs until the context is cancelled, or the process receives one of the Runner's shutdown signals.
+
+Each job runs immediately, and then runs in its own loop independently of the other jobs. On shutdown, no further
+runs are scheduled and the context passed to any in-flight runs is cancelled. Run returns once all jobs have stopped.
+*/
+func (r *Runner) Run(ctx context.Context) error {
+	if err := r.validate(); err != nil {
+		return fmt.Errorf("runner.run -> %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, r.Signals...)
+	defer stop()
+
+	r.Logger.Info("Starting jobs", slog.Int(LogKeyCount, len(r.jobs)))
+
+	var waitGroup sync.WaitGroup
+
+	for _, job := range r.jobs {
+		waitGroup.Add(1)
+
+		go func(job Job) {
+			defer waitGroup.Done()
+
+			r.loop(ctx, job)
+		}(job)
+	}
+
+	waitGroup.Wait()
+
+	r.Logger.Info("All jobs stopped")
+
+	return nil
+}
+
+// RunOnce runs each job once in order, and returns the first error encountered.
+func (r *Runner) RunOnce(ctx context.Context) error {
+	if err := r.validate(); err != nil {
+		return fmt.Errorf("runner.runonce -> %w", err)
+	}
+
+	for _, job := range r.jobs {
+		if err := r.execute(ctx, job); err != nil {
+			return fmt.Errorf("runner.runonce -> %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Health returns the status of each job, ordered by name.
+func (r *Runner) Health() []JobStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]JobStatus, 0, len(r.status))
+	for _, status := range r.status {
+		out = append(out, *status)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+
+	return out
+}
+
+// Healthy returns true if every job is healthy.
+func (r *Runner) Healthy() bool {
+	for _, status := range r.Health() {
+		if !status.Healthy() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ServeHTTP responds with the health of each job as JSON, and a 503 status code if any job is unhealthy.
+func (r *Runner) ServeHTTP(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+
+	if r.Healthy() {
+		writer.WriteHeader(http.StatusOK)
+	} else {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_ = json.NewEncoder(writer).Encode(r.Health())
+}
@@ -0,0 +1,223 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRunner(t *testing.T) {
+	t.Parallel()
+
+	runner := NewRunner([]Job{{Name: "foo"}})
+
+	assert.Equal(t, DefaultInterval, runner.Interval)
+	assert.Equal(t, DefaultBackoffInitial, runner.BackoffInitial)
+	assert.Equal(t, DefaultBackoffMax, runner.BackoffMax)
+	assert.Equal(t, NoTimeout, runner.Timeout)
+	assert.Equal(t, DefaultNotifyTimeout, runner.NotifyTimeout)
+	assert.Len(t, runner.Health(), 1)
+	assert.True(t, runner.Healthy())
+}
+
+func TestRunner_delay(t *testing.T) {
+	t.Parallel()
+
+	runner := NewRunner(nil, func(r *Runner) {
+		r.Interval = time.Minute
+		r.BackoffInitial = time.Second
+		r.BackoffMax = 10 * time.Second
+	})
+
+	assert.Equal(t, time.Minute, runner.delay(0))
+	assert.Equal(t, time.Second, runner.delay(1))
+	assert.Equal(t, 2*time.Second, runner.delay(2))
+	assert.Equal(t, 8*time.Second, runner.delay(4))
+	assert.Equal(t, 10*time.Second, runner.delay(5))
+	assert.Equal(t, 10*time.Second, runner.delay(100))
+
+	t.Run("Jitter", func(t *testing.T) {
+		t.Parallel()
+
+		runner := NewRunner(nil, func(r *Runner) {
+			r.Interval = time.Minute
+			r.Jitter = time.Second
+		})
+
+		for range 100 {
+			delay := runner.delay(0)
+
+			assert.GreaterOrEqual(t, delay, time.Minute)
+			assert.Less(t, delay, time.Minute+time.Second)
+		}
+	})
+}
+
+func TestRunner_RunOnce(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		destination := NewMockAdapter(t)
+
+		source.EXPECT().Get(ctx).Once().Return([]string{"foo"}, nil)
+		destination.EXPECT().Get(ctx).Once().Return([]string{"bar"}, nil)
+		destination.EXPECT().Add(ctx, []string{"foo"}).Once().Return(nil)
+		destination.EXPECT().Remove(ctx, []string{"bar"}).Once().Return(nil)
+
+		runner := NewRunner([]Job{{Name: "foo", Source: source, Destinations: []Adapter{destination}}})
+
+		err := runner.RunOnce(ctx)
+
+		require.NoError(t, err)
+
+		health := runner.Health()
+		assert.Equal(t, 1, health[0].Runs)
+		assert.False(t, health[0].LastSuccess.IsZero())
+		assert.True(t, runner.Healthy())
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		destination := NewMockAdapter(t)
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		source.EXPECT().Get(ctx).Once().Return([]string{"foo"}, nil)
+		destination.EXPECT().Get(ctx).Once().Return(nil, testErr)
+
+		runner := NewRunner([]Job{{Name: "foo", Source: source, Destinations: []Adapter{destination}}})
+
+		err := runner.RunOnce(ctx)
+
+		require.ErrorIs(t, err, testErr)
+
+		health := runner.Health()
+		assert.Equal(t, 1, health[0].ConsecutiveFailures)
+		assert.Contains(t, health[0].LastError, "foo")
+		assert.False(t, runner.Healthy())
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(mock.Anything).RunAndReturn(
+			func(ctx context.Context) ([]string, error) {
+				<-ctx.Done()
+
+				return nil, ctx.Err()
+			},
+		).Once()
+
+		runner := NewRunner([]Job{{
+			Name:         "foo",
+			Source:       source,
+			Destinations: []Adapter{NewMockAdapter(t)},
+			Timeout:      time.Millisecond,
+		}})
+
+		err := runner.RunOnce(ctx)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Invalid jobs", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+
+		err := NewRunner([]Job{{Name: "foo", Source: source}, {Name: "foo", Source: source}}).RunOnce(ctx)
+		require.ErrorIs(t, err, ErrInvalidConfig)
+
+		err = NewRunner([]Job{{Source: source}}).RunOnce(ctx)
+		require.ErrorIs(t, err, ErrMissingConfig)
+
+		err = NewRunner([]Job{{Name: "foo"}}).RunOnce(ctx)
+		require.ErrorIs(t, err, ErrMissingConfig)
+	})
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	testErr := errors.New("foo") //nolint:goerr113
+
+	source := NewMockAdapter(t)
+	source.EXPECT().Get(mock.Anything).Once().Return(nil, testErr)
+	source.EXPECT().Get(mock.Anything).Once().Return([]string{"foo"}, nil)
+
+	destination := NewMockAdapter(t)
+	destination.EXPECT().Get(mock.Anything).Once().Return([]string{"foo"}, nil)
+
+	waits := make([]time.Duration, 0)
+
+	runner := NewRunner([]Job{{Name: "foo", Source: source, Destinations: []Adapter{destination}}},
+		func(r *Runner) {
+			r.Interval = time.Minute
+			r.BackoffInitial = time.Second
+			r.after = func(wait time.Duration) <-chan time.Time {
+				waits = append(waits, wait)
+
+				// Stop the runner after the second run, and never fire the timer.
+				if len(waits) == 2 { //nolint:gomnd,mnd
+					cancel()
+
+					return nil
+				}
+
+				out := make(chan time.Time, 1)
+				out <- time.Now()
+
+				return out
+			}
+		},
+	)
+
+	err := runner.Run(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second, time.Minute}, waits)
+
+	health := runner.Health()
+	assert.Equal(t, 2, health[0].Runs)
+	assert.Zero(t, health[0].ConsecutiveFailures)
+	assert.False(t, health[0].Running)
+}
+
+func TestRunner_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	runner := NewRunner([]Job{{Name: "foo"}})
+
+	recorder := httptest.NewRecorder()
+	runner.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"name":"foo"`)
+
+	runner.setStatus("foo", func(status *JobStatus) {
+		status.ConsecutiveFailures = 1
+	})
+
+	recorder = httptest.NewRecorder()
+	runner.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
@@ -0,0 +1,102 @@
+package gosync_test
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/github/team"
+	"github.com/ovotech/go-sync/adapters/slack/conversation"
+)
+
+func ExampleNewRunner() {
+	ctx := context.Background()
+
+	source, err := team.Init(ctx, map[gosync.ConfigKey]string{
+		team.GitHubToken:        "some-token",
+		team.GitHubOrg:          "some-org",
+		team.TeamSlug:           "some-team",
+		team.DiscoveryMechanism: "saml",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	destination, err := conversation.Init(ctx, map[gosync.ConfigKey]string{
+		conversation.SlackAPIKey: "some-key",
+		conversation.Name:        "example",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	runner := gosync.NewRunner([]gosync.Job{
+		{
+			Name:         "github-to-slack",
+			Source:       source,
+			Destinations: []gosync.Adapter{destination},
+			Options: []func(*gosync.Sync){
+				func(s *gosync.Sync) { s.MaximumChanges = 5 },
+			},
+		},
+	}, func(r *gosync.Runner) {
+		r.Interval = 10 * time.Minute
+		r.Jitter = time.Minute
+		r.Timeout = 5 * time.Minute
+	})
+
+	// Expose the health of the runner's jobs.
+	go func() {
+		log.Println(http.ListenAndServe(":8080", runner)) //nolint:gosec
+	}()
+
+	// Run the jobs until the context is cancelled, or the process receives a SIGTERM.
+	err = runner.Run(ctx)
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func ExampleRunner_Check() {
+	ctx := context.Background()
+
+	source, err := team.Init(ctx, map[gosync.ConfigKey]string{
+		team.GitHubToken: "some-token",
+		team.GitHubOrg:   "some-org",
+		team.TeamSlug:    "some-team",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	destination, err := conversation.Init(ctx, map[gosync.ConfigKey]string{
+		conversation.SlackAPIKey: "some-key",
+		conversation.Name:        "example",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	runner := gosync.NewRunner([]gosync.Job{
+		{Name: "github-to-slack", Source: source, Destinations: []gosync.Adapter{destination}},
+	}, func(r *gosync.Runner) {
+		r.DriftState = gosync.FileDriftState("drift.json")
+	})
+
+	// Fetch every source and destination, without changing anything.
+	drift, err := runner.Check(ctx)
+	if renderErr := drift.Render(os.Stdout, gosync.ReportTable); renderErr != nil {
+		log.Panic(renderErr)
+	}
+
+	// Exit with a non-zero status if any destination has drifted, or couldn't be checked.
+	if errors.Is(err, gosync.ErrDrift) {
+		os.Exit(1)
+	} else if err != nil {
+		log.Panic(err)
+	}
+}
@@ -0,0 +1,293 @@
+package gosync
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigType is the type of value that a ConfigKey accepts.
+type ConfigType string
+
+const (
+	// ConfigString keys accept any string. It's the default type.
+	ConfigString ConfigType = "string"
+	// ConfigBool keys accept a boolean, such as `true` or `false`. See [strconv.ParseBool].
+	ConfigBool ConfigType = "bool"
+	// ConfigInt keys accept a whole number.
+	ConfigInt ConfigType = "int"
+	// ConfigDuration keys accept a duration, such as `24h`. See [time.ParseDuration].
+	ConfigDuration ConfigType = "duration"
+)
+
+// RedactedConfig replaces the values of secret keys in redacted configuration.
+const RedactedConfig = "REDACTED"
+
+// ConfigField describes a key that an adapter's InitFn accepts.
+type ConfigField struct {
+	Key         ConfigKey
+	Type        ConfigType // Type of value the key accepts. Default is ConfigString.
+	Required    bool       // Required keys must be set, or the InitFn returns ErrMissingConfig.
+	Default     string     // Default value of the key, if it isn't set.
+	Allowed     []string   // Allowed values of the key, which are matched case-insensitively. Default is any value.
+	Secret      bool       // Secret values, such as API keys, are redacted. See [ConfigSchema.Redact].
+	Description string
+}
+
+/*
+ConfigSchema describes the keys that an adapter's InitFn accepts, so that configuration is validated and parsed in the
+same way by every adapter. Adapters publish their schema as `Schema`, and register it with [RegisterSchema]:
+
+	var Schema = gosync.ConfigSchema{
+		{Key: Name, Required: true, Description: "Name of the group."},
+		{Key: Role, Default: "MEMBER", Allowed: []string{"MANAGER", "MEMBER", "OWNER"}},
+		{Key: MuteErrors, Type: gosync.ConfigBool, Default: "false"},
+	}
+
+	func Init(ctx context.Context, config map[gosync.ConfigKey]string, fns ...gosync.ConfigFn[*Group]) (*Group, error) {
+		parsed, err := Schema.Parse(config)
+		if err != nil {
+			return nil, fmt.Errorf("google.group.init -> %w", err)
+		}
+
+		adapter := &Group{name: parsed.String(Name), role: parsed.String(Role)}
+	}
+*/
+type ConfigSchema []ConfigField
+
+// field returns the schema's field for a key.
+func (s ConfigSchema) field(key ConfigKey) (ConfigField, bool) {
+	for _, field := range s {
+		if field.Key == key {
+			return field, true
+		}
+	}
+
+	return ConfigField{}, false
+}
+
+/*
+Parse checks configuration against the schema, and returns the parsed values with defaults applied. Missing required
+keys return an error wrapping ErrMissingConfig, and values of the wrong type or that aren't allowed return an error
+wrapping ErrInvalidConfig. Every problem is returned, joined together.
+
+Keys that aren't in the schema don't cause an error, but are returned by [Config.Unknown] so that they can be reported.
+Use [ConfigSchema.Validate] to reject them.
+*/
+func (s ConfigSchema) Parse(config map[ConfigKey]string) (Config, error) {
+	return s.parse(config, false)
+}
+
+// parse checks configuration against the schema. If references is true, values that are secret references are
+// skipped, as they can only be checked once they're resolved.
+func (s ConfigSchema) parse(config map[ConfigKey]string, references bool) (Config, error) {
+	parsed := Config{values: make(map[ConfigKey]any, len(s)), unknown: s.Unknown(config), secrets: s.secrets()}
+	errs := make([]error, 0)
+
+	for _, field := range s {
+		raw, ok := config[field.Key]
+
+		switch {
+		case !ok && field.Required:
+			errs = append(errs, fmt.Errorf("%w(%s)", ErrMissingConfig, field.Key))
+
+			continue
+		case !ok && field.Default == "":
+			continue
+		case !ok:
+			raw = field.Default
+		case references && isSecretReference(raw):
+			continue
+		}
+
+		value, err := field.parse(raw)
+
+		switch {
+		case err != nil && field.Secret:
+			// The parse error isn't wrapped, as it quotes the secret value.
+			errs = append(errs, fmt.Errorf("%w(%s): invalid %s", ErrInvalidConfig, field.Key, field.Type))
+
+			continue
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%w(%s): %w", ErrInvalidConfig, field.Key, err))
+
+			continue
+		}
+
+		parsed.values[field.Key] = value
+	}
+
+	if len(errs) > 0 {
+		return parsed, errors.Join(errs...)
+	}
+
+	return parsed, nil
+}
+
+// parse converts a raw value to the field's type, and checks that it's allowed.
+func (f ConfigField) parse(raw string) (any, error) {
+	if len(f.Allowed) > 0 {
+		index := slices.IndexFunc(f.Allowed, func(allowed string) bool {
+			return strings.EqualFold(allowed, raw)
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("%q is not one of %s", raw, strings.Join(f.Allowed, ", ")) //nolint:goerr113
+		}
+
+		raw = f.Allowed[index]
+	}
+
+	switch f.Type {
+	case ConfigBool:
+		return strconv.ParseBool(raw) //nolint:wrapcheck
+	case ConfigInt:
+		return strconv.Atoi(raw) //nolint:wrapcheck
+	case ConfigDuration:
+		return time.ParseDuration(raw) //nolint:wrapcheck
+	case ConfigString, "":
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("unknown type %s", f.Type) //nolint:goerr113
+	}
+}
+
+// Unknown returns the keys in the configuration that aren't in the schema, sorted.
+func (s ConfigSchema) Unknown(config map[ConfigKey]string) []ConfigKey {
+	unknown := make([]ConfigKey, 0)
+
+	for key := range config {
+		if _, ok := s.field(key); !ok {
+			unknown = append(unknown, key)
+		}
+	}
+
+	slices.Sort(unknown)
+
+	return unknown
+}
+
+/*
+Validate checks configuration against the schema like Parse, but also returns ErrInvalidConfig for unknown keys. Values
+that are secret references are only checked for being set, as their values aren't known until they're resolved. See
+[ResolveSecrets].
+*/
+func (s ConfigSchema) Validate(config map[ConfigKey]string) error {
+	parsed, err := s.parse(config, true)
+
+	errs := []error{err}
+	for _, key := range parsed.Unknown() {
+		errs = append(errs, fmt.Errorf("%w(%s): unknown key", ErrInvalidConfig, key))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Redact returns a copy of the configuration with the values of secret keys replaced, so that it can be logged.
+func (s ConfigSchema) Redact(config map[ConfigKey]string) map[ConfigKey]string {
+	out := make(map[ConfigKey]string, len(config))
+
+	for key, value := range config {
+		if field, ok := s.field(key); ok && field.Secret {
+			value = RedactedConfig
+		}
+
+		out[key] = value
+	}
+
+	return out
+}
+
+// secrets returns the schema's secret keys.
+func (s ConfigSchema) secrets() map[ConfigKey]bool {
+	secrets := make(map[ConfigKey]bool)
+
+	for _, field := range s {
+		if field.Secret {
+			secrets[field.Key] = true
+		}
+	}
+
+	return secrets
+}
+
+// Config is configuration that has been parsed by a ConfigSchema.
+type Config struct {
+	values  map[ConfigKey]any
+	unknown []ConfigKey
+	secrets map[ConfigKey]bool // secrets are redacted when the configuration is logged.
+}
+
+// Has returns true if the key was set, or has a default value.
+func (c Config) Has(key ConfigKey) bool {
+	_, ok := c.values[key]
+
+	return ok
+}
+
+// String returns the value of a ConfigString key, or an empty string if it isn't set.
+func (c Config) String(key ConfigKey) string {
+	value, _ := c.values[key].(string)
+
+	return value
+}
+
+// Bool returns the value of a ConfigBool key, or false if it isn't set.
+func (c Config) Bool(key ConfigKey) bool {
+	value, _ := c.values[key].(bool)
+
+	return value
+}
+
+// Int returns the value of a ConfigInt key, or 0 if it isn't set.
+func (c Config) Int(key ConfigKey) int {
+	value, _ := c.values[key].(int)
+
+	return value
+}
+
+// Duration returns the value of a ConfigDuration key, or 0 if it isn't set.
+func (c Config) Duration(key ConfigKey) time.Duration {
+	value, _ := c.values[key].(time.Duration)
+
+	return value
+}
+
+// Unknown returns the keys that were set, but aren't in the schema.
+func (c Config) Unknown() []ConfigKey {
+	return c.unknown
+}
+
+// LogUnknown logs a warning for each key that was set, but isn't in the schema, which is usually a typo.
+func (c Config) LogUnknown(logger *slog.Logger) {
+	for _, key := range c.unknown {
+		logger.Warn("Ignoring unknown configuration key", slog.String("key", string(key)))
+	}
+}
+
+// LogValue logs the parsed configuration with the values of secret keys redacted, so that it's safe to log.
+func (c Config) LogValue() slog.Value {
+	keys := make([]ConfigKey, 0, len(c.values))
+	for key := range c.values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys))
+
+	for _, key := range keys {
+		if c.secrets[key] {
+			attrs = append(attrs, slog.String(string(key), RedactedConfig))
+
+			continue
+		}
+
+		attrs = append(attrs, slog.Any(string(key), c.values[key]))
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,165 @@
+package gosync
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSchema = ConfigSchema{ //nolint:gochecknoglobals
+	{Key: "name", Required: true},
+	{Key: "role", Default: "MEMBER", Allowed: []string{"MANAGER", "MEMBER", "OWNER"}},
+	{Key: "mute", Type: ConfigBool},
+	{Key: "retries", Type: ConfigInt, Default: "3"},
+	{Key: "warning", Type: ConfigDuration},
+	{Key: "token", Secret: true},
+}
+
+func TestConfigSchema_Parse(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := testSchema.Parse(map[ConfigKey]string{
+		"name":    "foo",
+		"role":    "owner",
+		"mute":    "TRUE",
+		"warning": "48h",
+		"other":   "bar",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "foo", parsed.String("name"))
+	assert.Equal(t, "OWNER", parsed.String("role"))
+	assert.True(t, parsed.Bool("mute"))
+	assert.Equal(t, 3, parsed.Int("retries"))
+	assert.Equal(t, 48*time.Hour, parsed.Duration("warning"))
+	assert.False(t, parsed.Has("token"))
+	assert.Equal(t, "", parsed.String("token"))
+	assert.Equal(t, []ConfigKey{"other"}, parsed.Unknown())
+}
+
+func TestConfigSchema_Parse_Errors(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		config   map[ConfigKey]string
+		wantErrs []error
+		contains []string
+	}{
+		"Missing required key": {
+			config:   map[ConfigKey]string{},
+			wantErrs: []error{ErrMissingConfig},
+			contains: []string{"missing configuration(name)"},
+		},
+		"Value not allowed": {
+			config:   map[ConfigKey]string{"name": "foo", "role": "admin"},
+			wantErrs: []error{ErrInvalidConfig},
+			contains: []string{`invalid configuration(role): "admin" is not one of MANAGER, MEMBER, OWNER`},
+		},
+		"Invalid bool": {
+			config:   map[ConfigKey]string{"name": "foo", "mute": "maybe"},
+			wantErrs: []error{ErrInvalidConfig},
+			contains: []string{"invalid configuration(mute)"},
+		},
+		"Invalid int": {
+			config:   map[ConfigKey]string{"name": "foo", "retries": "three"},
+			wantErrs: []error{ErrInvalidConfig},
+			contains: []string{"invalid configuration(retries)"},
+		},
+		"Invalid duration": {
+			config:   map[ConfigKey]string{"name": "foo", "warning": "2 days"},
+			wantErrs: []error{ErrInvalidConfig},
+			contains: []string{"invalid configuration(warning)"},
+		},
+		"Every problem is reported": {
+			config:   map[ConfigKey]string{"mute": "maybe"},
+			wantErrs: []error{ErrMissingConfig, ErrInvalidConfig},
+			contains: []string{"missing configuration(name)", "invalid configuration(mute)"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := testSchema.Parse(test.config)
+
+			for _, want := range test.wantErrs {
+				require.ErrorIs(t, err, want)
+			}
+
+			for _, contains := range test.contains {
+				require.ErrorContains(t, err, contains)
+			}
+		})
+	}
+}
+
+func TestConfigSchema_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, testSchema.Validate(map[ConfigKey]string{"name": "foo"}))
+
+	err := testSchema.Validate(map[ConfigKey]string{"name": "foo", "nmae": "bar"})
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	require.ErrorContains(t, err, "invalid configuration(nmae): unknown key")
+}
+
+func TestConfigSchema_Validate_SecretReferences(t *testing.T) {
+	t.Parallel()
+
+	schema := ConfigSchema{
+		{Key: "name", Required: true},
+		{Key: "mute", Type: ConfigBool},
+		{Key: "pin", Type: ConfigInt, Secret: true},
+	}
+
+	// References are checked once they're resolved, as their values aren't known yet.
+	require.NoError(t, schema.Validate(map[ConfigKey]string{"name": "${NAME}", "mute": "${MUTE}", "pin": "file:///pin"}))
+
+	err := schema.Validate(map[ConfigKey]string{"mute": "${MUTE}"})
+	require.ErrorIs(t, err, ErrMissingConfig)
+
+	// Values of secret keys aren't quoted in errors.
+	_, err = schema.Parse(map[ConfigKey]string{"name": "foo", "pin": "12ab"})
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	require.ErrorContains(t, err, "invalid configuration(pin): invalid int")
+	assert.NotContains(t, err.Error(), "12ab")
+}
+
+func TestConfigSchema_Redact(t *testing.T) {
+	t.Parallel()
+
+	config := map[ConfigKey]string{"name": "foo", "token": "secret"}
+
+	assert.Equal(t, map[ConfigKey]string{"name": "foo", "token": RedactedConfig}, testSchema.Redact(config))
+	assert.Equal(t, "secret", config["token"], "The configuration isn't modified.")
+}
+
+func TestConfig_LogUnknown(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	parsed, err := testSchema.Parse(map[ConfigKey]string{"name": "foo", "nmae": "bar"})
+	require.NoError(t, err)
+
+	parsed.LogUnknown(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	assert.Contains(t, buf.String(), `level=WARN msg="Ignoring unknown configuration key" key=nmae`)
+}
+
+func TestConfig_LogValue(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	parsed, err := testSchema.Parse(map[ConfigKey]string{"name": "foo", "token": "secret"})
+	require.NoError(t, err)
+
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("Initialising adapter", slog.Any("config", parsed))
+
+	assert.Contains(t, buf.String(), "config.name=foo config.retries=3 config.role=MEMBER config.token=REDACTED")
+	assert.NotContains(t, buf.String(), "secret")
+}
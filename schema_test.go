@@ -0,0 +1,38 @@
+package gosync_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/github/team"
+)
+
+func ExampleConfigSchema_Validate() {
+	err := team.Schema.Validate(map[gosync.ConfigKey]string{
+		team.GitHubOrg:          "my-org",
+		team.DiscoveryMechanism: "email",
+		"team_slg":              "my-team",
+	})
+
+	fmt.Println(errors.Is(err, gosync.ErrMissingConfig), errors.Is(err, gosync.ErrInvalidConfig))
+	// Output: true true
+}
+
+func ExampleLookupSchema() {
+	schema, err := gosync.LookupSchema("github/team")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	for _, field := range schema {
+		fmt.Println(field.Key, field.Required, field.Secret)
+	}
+	// Output:
+	// github_token false true
+	// github_org true false
+	// team_slug true false
+	// discovery_mechanism false false
+	// saml_mute_user_not_found_err false false
+}
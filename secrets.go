@@ -0,0 +1,358 @@
+package gosync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// fileScheme is the scheme of secret references that are read from a local file, e.g. `file:///run/secrets/token`.
+const fileScheme = "file"
+
+/*
+SecretProvider resolves references to secrets stored outside of configuration, such as in Vault or AWS SSM. Providers
+are registered under a scheme with [RegisterSecretProvider], and are passed the rest of the reference, e.g.
+`secret/data/slack#token` for `vault://secret/data/slack#token`.
+*/
+type SecretProvider interface {
+	Secret(ctx context.Context, reference string) (string, error)
+}
+
+// SecretProviderFunc is a function that resolves secret references, which can be registered as a SecretProvider.
+type SecretProviderFunc func(ctx context.Context, reference string) (string, error)
+
+// Secret calls the function.
+func (f SecretProviderFunc) Secret(ctx context.Context, reference string) (string, error) {
+	return f(ctx, reference)
+}
+
+//nolint:gochecknoglobals
+var (
+	secretProvidersMu sync.RWMutex
+	secretProviders   = make(map[string]SecretProvider)
+
+	// envReference matches `${ENV_VAR}`, or an escaped `$${`.
+	envReference = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)}`)
+	// schemeReference matches a reference to a secret that's resolved by scheme, e.g. `vault://secret/data/slack`.
+	schemeReference = regexp.MustCompile(`^([a-z][a-z0-9+.-]*)://(.*)$`)
+)
+
+/*
+RegisterSecretProvider resolves config values starting with `<scheme>://` with a SecretProvider:
+
+	gosync.RegisterSecretProvider("local", gosync.FileSecretProvider("/etc/go-sync/secrets.json"))
+
+	// slack_api_key: local://slack
+
+RegisterSecretProvider panics if the scheme has already been registered, is the reserved `file` scheme, or provider is
+nil. Values with schemes that haven't been registered, such as `https://`, are left as they are.
+*/
+func RegisterSecretProvider(scheme string, provider SecretProvider) {
+	if provider == nil {
+		panic("gosync: RegisterSecretProvider provider is nil for " + scheme)
+	}
+
+	if scheme == fileScheme {
+		panic("gosync: RegisterSecretProvider called for reserved scheme: " + scheme)
+	}
+
+	secretProvidersMu.Lock()
+	defer secretProvidersMu.Unlock()
+
+	if _, ok := secretProviders[scheme]; ok {
+		panic("gosync: secret provider registered twice: " + scheme)
+	}
+
+	secretProviders[scheme] = provider
+}
+
+// lookupSecretProvider returns the provider registered for a scheme.
+func lookupSecretProvider(scheme string) (SecretProvider, bool) {
+	secretProvidersMu.RLock()
+	defer secretProvidersMu.RUnlock()
+
+	provider, ok := secretProviders[scheme]
+
+	return provider, ok
+}
+
+// FileSecretProvider returns a SecretProvider that reads secrets by name from a local JSON file of names and values.
+func FileSecretProvider(path string) SecretProvider { //nolint:ireturn
+	return &fileSecretProvider{path: path}
+}
+
+// fileSecretProvider is a SecretProvider backed by a JSON file, which is read each time a secret is resolved.
+type fileSecretProvider struct {
+	path string
+}
+
+// Secret returns the value of the named secret in the file, or an error wrapping ErrNotFound.
+func (f *fileSecretProvider) Secret(_ context.Context, name string) (string, error) {
+	contents, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("secrets.file(%s) -> %w", f.path, err)
+	}
+
+	secrets := make(map[string]string)
+	if err = json.Unmarshal(contents, &secrets); err != nil {
+		// The JSON error isn't wrapped, as it can quote the file's contents.
+		return "", fmt.Errorf("secrets.file(%s) -> %w(not a JSON object of strings)", f.path, ErrInvalidConfig)
+	}
+
+	secret, ok := secrets[name]
+	if !ok {
+		return "", fmt.Errorf("secrets.file(%s).secret(%s) -> %w", f.path, name, ErrNotFound)
+	}
+
+	return secret, nil
+}
+
+/*
+ResolveSecrets returns a copy of the configuration with secret references replaced by their values, so that secrets
+don't need to be written into configuration files or passed on the command line:
+
+  - `${ENV_VAR}` is replaced by the environment variable anywhere in a value. Write `$${` for a literal `${`.
+  - `file:///path/to/secret` is replaced by the contents of the file, without trailing newlines.
+  - `<scheme>://reference` is resolved by the SecretProvider registered for the scheme. See [RegisterSecretProvider].
+
+Environment variables are replaced first, so `file://${HOME}/.slack-token` reads a file in the home directory. Errors
+name the key and reference, but never contain a resolved value.
+*/
+func ResolveSecrets(ctx context.Context, config map[ConfigKey]string) (map[ConfigKey]string, error) {
+	resolved, _, err := resolveSecrets(ctx, nil, config)
+
+	return resolved, err
+}
+
+// resolveSecrets resolves the configuration, and returns the values that must be redacted: the resolved values of
+// secret keys in the schema, and any value read from a file or SecretProvider.
+func resolveSecrets(
+	ctx context.Context,
+	schema ConfigSchema,
+	config map[ConfigKey]string,
+) (map[ConfigKey]string, []string, error) {
+	resolved := make(map[ConfigKey]string, len(config))
+	secrets := make([]string, 0)
+	errs := make([]error, 0)
+
+	keys := make([]ConfigKey, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		value, fromReference, err := resolveSecret(ctx, config[key])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("secrets(%s) -> %w", key, err))
+
+			continue
+		}
+
+		if field, ok := schema.field(key); fromReference || (ok && field.Secret) {
+			secrets = append(secrets, value)
+		}
+
+		resolved[key] = value
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	return resolved, secrets, nil
+}
+
+// resolveSecret resolves a single value, and returns true if it was read from a file or SecretProvider.
+func resolveSecret(ctx context.Context, value string) (string, bool, error) {
+	value, err := interpolate(value)
+	if err != nil {
+		return "", false, err
+	}
+
+	match := schemeReference.FindStringSubmatch(value)
+	if match == nil {
+		return value, false, nil
+	}
+
+	scheme, reference := match[1], match[2]
+
+	if scheme == fileScheme {
+		contents, err := os.ReadFile(reference)
+		if err != nil {
+			return "", false, fmt.Errorf("file(%s) -> %w", reference, err)
+		}
+
+		return strings.TrimRight(string(contents), "\r\n"), true, nil
+	}
+
+	provider, ok := lookupSecretProvider(scheme)
+	if !ok {
+		return value, false, nil
+	}
+
+	secret, err := provider.Secret(ctx, reference)
+	if err != nil {
+		return "", false, fmt.Errorf("%s(%s) -> %w", scheme, reference, err)
+	}
+
+	return secret, true, nil
+}
+
+// interpolate replaces `${ENV_VAR}` with environment variables, and returns ErrMissingConfig for any that aren't set.
+func interpolate(value string) (string, error) {
+	missing := make([]string, 0)
+
+	value = envReference.ReplaceAllStringFunc(value, func(match string) string {
+		if match == "$${" {
+			return "${"
+		}
+
+		name := match[2 : len(match)-1]
+
+		env, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+
+		return env
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("env -> %w(%s)", ErrMissingConfig, strings.Join(missing, ", "))
+	}
+
+	return value, nil
+}
+
+// isSecretReference returns true if a value will be changed by ResolveSecrets.
+func isSecretReference(value string) bool {
+	if envReference.MatchString(value) {
+		return true
+	}
+
+	match := schemeReference.FindStringSubmatch(value)
+	if match == nil {
+		return false
+	}
+
+	if match[1] == fileScheme {
+		return true
+	}
+
+	_, ok := lookupSecretProvider(match[1])
+
+	return ok
+}
+
+/*
+WithSecrets returns an InitFn that resolves secret references in its configuration with [ResolveSecrets] before calling
+initFn. The values of the schema's secret keys, and of every resolved reference, are redacted from any error that
+initFn returns. Adapters initialised with [Lookup] or [LookupMulti] already resolve their secrets.
+
+Only errors from initFn are redacted. The adapter holds the resolved values once it has been initialised, so errors
+from its Get, Add and Remove methods aren't redacted, and adapters must not include their credentials in them.
+
+Redacted errors match sentinel errors with [errors.Is], and an [*Error] with [errors.As], whose Target, Things and Err
+are redacted too. Other errors that initFn wrapped can't be unwrapped, as they may contain the secrets.
+*/
+func WithSecrets[T any](schema ConfigSchema, initFn InitFn[T]) InitFn[T] {
+	return func(ctx context.Context, config map[ConfigKey]string, configFns ...ConfigFn[T]) (T, error) {
+		return initWithSecrets(ctx, schema, config, func(ctx context.Context, config map[ConfigKey]string) (T, error) {
+			return initFn(ctx, config, configFns...)
+		})
+	}
+}
+
+// initWithSecrets resolves the configuration's secrets, and calls the init function with it.
+func initWithSecrets[T any](
+	ctx context.Context,
+	schema ConfigSchema,
+	config map[ConfigKey]string,
+	initFn func(context.Context, map[ConfigKey]string) (T, error),
+) (T, error) {
+	resolved, secrets, err := resolveSecrets(ctx, schema, config)
+	if err != nil {
+		var zero T
+
+		return zero, err
+	}
+
+	adapter, err := initFn(ctx, resolved)
+	if err != nil {
+		return adapter, redact(err, secrets)
+	}
+
+	return adapter, nil
+}
+
+// redactedError is an error with secret values replaced in its message. The original error isn't unwrapped, but can
+// still be matched with errors.Is, and an *Error in it is redacted for errors.As.
+type redactedError struct {
+	err     error
+	secrets []string
+}
+
+// redact wraps the error, so that none of the secrets appear in its message.
+func redact(err error, secrets []string) error {
+	if err == nil || len(secrets) == 0 {
+		return err
+	}
+
+	return &redactedError{err: err, secrets: secrets}
+}
+
+func (e *redactedError) Error() string {
+	return e.replace(e.err.Error())
+}
+
+// replace the secrets in a string.
+func (e *redactedError) replace(value string) string {
+	for _, secret := range e.secrets {
+		if secret != "" {
+			value = strings.ReplaceAll(value, secret, RedactedConfig)
+		}
+	}
+
+	return value
+}
+
+// Is reports whether the original error matches target, without exposing it.
+func (e *redactedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
+
+// As finds an *Error in the original error, and sets target to a copy of it with the secrets redacted.
+func (e *redactedError) As(target any) bool {
+	errTarget, ok := target.(**Error)
+	if !ok {
+		return false
+	}
+
+	var syncErr *Error
+	if !errors.As(e.err, &syncErr) {
+		return false
+	}
+
+	things := make([]string, 0, len(syncErr.Things))
+	for _, thing := range syncErr.Things {
+		things = append(things, e.replace(thing))
+	}
+
+	*errTarget = &Error{
+		Phase:  syncErr.Phase,
+		Kind:   syncErr.Kind,
+		Target: e.replace(syncErr.Target),
+		Things: things,
+		Err:    redact(syncErr.Err, e.secrets),
+	}
+
+	return true
+}
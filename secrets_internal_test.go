@@ -0,0 +1,203 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSecretProvider = errors.New("secret provider failed")
+
+// unregisterSecretProvider removes a scheme from the secret providers when a test finishes.
+func unregisterSecretProvider(t *testing.T, scheme string) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		secretProvidersMu.Lock()
+		defer secretProvidersMu.Unlock()
+
+		delete(secretProviders, scheme)
+	})
+}
+
+// writeSecret writes a secret to a file in a temporary directory, and returns its path.
+func writeSecret(t *testing.T, name string, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+
+	return path
+}
+
+func TestResolveSecrets(t *testing.T) { //nolint:paralleltest
+	ctx := context.TODO()
+	tokenFile := writeSecret(t, "token", "file-secret\n")
+
+	unregisterSecretProvider(t, "test-resolve")
+	RegisterSecretProvider("test-resolve", SecretProviderFunc(func(_ context.Context, reference string) (string, error) {
+		if reference == "fail" {
+			return "", errSecretProvider
+		}
+
+		return "provided-" + reference, nil
+	}))
+
+	t.Setenv("GOSYNC_TEST_TOKEN", "env-secret")
+	t.Setenv("GOSYNC_TEST_DIR", filepath.Dir(tokenFile))
+
+	for name, test := range map[string]struct {
+		value    string
+		want     string
+		wantErr  error
+		contains string
+	}{
+		"Plain value": {
+			value: "foo",
+			want:  "foo",
+		},
+		"Environment variable": {
+			value: "${GOSYNC_TEST_TOKEN}",
+			want:  "env-secret",
+		},
+		"Interpolated environment variable": {
+			value: "Bearer ${GOSYNC_TEST_TOKEN}!",
+			want:  "Bearer env-secret!",
+		},
+		"Escaped": {
+			value: "$${GOSYNC_TEST_TOKEN}",
+			want:  "${GOSYNC_TEST_TOKEN}",
+		},
+		"Missing environment variable": {
+			value:    "${GOSYNC_TEST_MISSING}",
+			wantErr:  ErrMissingConfig,
+			contains: "secrets(key) -> env -> missing configuration(GOSYNC_TEST_MISSING)",
+		},
+		"File": {
+			value: "file://" + tokenFile,
+			want:  "file-secret",
+		},
+		"File in an environment variable's directory": {
+			value: "file://${GOSYNC_TEST_DIR}/token",
+			want:  "file-secret",
+		},
+		"Missing file": {
+			value:    "file:///gosync/missing",
+			wantErr:  os.ErrNotExist,
+			contains: "secrets(key) -> file(/gosync/missing)",
+		},
+		"Provider": {
+			value: "test-resolve://slack",
+			want:  "provided-slack",
+		},
+		"Provider error": {
+			value:    "test-resolve://fail",
+			wantErr:  errSecretProvider,
+			contains: "secrets(key) -> test-resolve(fail)",
+		},
+		"Unregistered scheme": {
+			value: "https://app.terraform.io",
+			want:  "https://app.terraform.io",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resolved, err := ResolveSecrets(ctx, map[ConfigKey]string{"key": test.value})
+
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				require.ErrorContains(t, err, test.contains)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, map[ConfigKey]string{"key": test.want}, resolved)
+		})
+	}
+}
+
+func TestRegisterSecretProvider(t *testing.T) {
+	t.Parallel()
+
+	provider := FileSecretProvider("secrets.json")
+
+	unregisterSecretProvider(t, "test-register")
+	RegisterSecretProvider("test-register", provider)
+
+	got, ok := lookupSecretProvider("test-register")
+	assert.True(t, ok)
+	assert.Same(t, provider, got)
+
+	assert.Panics(t, func() { RegisterSecretProvider("test-register", provider) }, "Registered twice")
+	assert.Panics(t, func() { RegisterSecretProvider("file", provider) }, "Reserved scheme")
+	assert.Panics(t, func() { RegisterSecretProvider("test-nil", nil) }, "Nil provider")
+}
+
+func TestFileSecretProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	provider := FileSecretProvider(writeSecret(t, "secrets.json", `{"slack": "xoxb-secret"}`))
+
+	secret, err := provider.Secret(ctx, "slack")
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-secret", secret)
+
+	_, err = provider.Secret(ctx, "github")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = FileSecretProvider(writeSecret(t, "invalid.json", `["xoxb-secret"]`)).Secret(ctx, "slack")
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	assert.NotContains(t, err.Error(), "xoxb-secret")
+
+	_, err = FileSecretProvider(filepath.Join(t.TempDir(), "missing.json")).Secret(ctx, "slack")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestWithSecrets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	adapter := NewMockAdapter(t)
+	password := writeSecret(t, "password", "file-secret")
+
+	initFn := WithSecrets(testSchema, func(_ context.Context, config map[ConfigKey]string, _ ...ConfigFn[*MockAdapter]) (
+		*MockAdapter, error,
+	) {
+		if config["name"] == "fail" {
+			return nil, NewError(adapter, PhaseValidate, nil,
+				fmt.Errorf("rejected %s and %s -> %w", config["token"], config["password"], ErrInvalidConfig))
+		}
+
+		return adapter, nil
+	})
+
+	got, err := initFn(ctx, map[ConfigKey]string{"name": "foo", "token": "plain-secret"})
+	require.NoError(t, err)
+	assert.Same(t, adapter, got)
+
+	// Secret keys, and values read from references, are redacted from errors.
+	_, err = initFn(ctx, map[ConfigKey]string{"name": "fail", "token": "plain-secret", "password": "file://" + password})
+
+	var syncErr *Error
+
+	require.ErrorAs(t, err, &syncErr)
+	assert.Equal(t, PhaseValidate, syncErr.Phase)
+	assert.Contains(t, err.Error(), "rejected REDACTED and REDACTED")
+	assert.NotContains(t, err.Error(), "plain-secret")
+	assert.NotContains(t, err.Error(), "file-secret")
+
+	// Errors found with errors.As, or by unwrapping, don't expose the secrets either.
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, syncErr.Err.Error(), "rejected REDACTED and REDACTED")
+	assert.Nil(t, errors.Unwrap(err))
+
+	_, err = initFn(ctx, map[ConfigKey]string{"name": "foo", "token": "file:///gosync/missing"})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,43 @@
+package gosync_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/slack/conversation"
+)
+
+func ExampleResolveSecrets() {
+	ctx := context.Background()
+
+	// Secrets can be read from a local JSON file, or from Vault, SSM, etc. with a SecretProvider.
+	dir, err := os.MkdirTemp("", "secrets")
+	if err != nil {
+		log.Panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "secrets.json")
+	if err = os.WriteFile(path, []byte(`{"slack": "xoxb-from-file"}`), 0o600); err != nil {
+		log.Panic(err)
+	}
+
+	gosync.RegisterSecretProvider("example", gosync.FileSecretProvider(path))
+
+	os.Setenv("SLACK_CHANNEL", "platform")
+
+	resolved, err := gosync.ResolveSecrets(ctx, map[gosync.ConfigKey]string{
+		conversation.SlackAPIKey: "example://slack",
+		conversation.Name:        "${SLACK_CHANNEL}",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	fmt.Println(resolved[conversation.SlackAPIKey], resolved[conversation.Name])
+	// Output: xoxb-from-file platform
+}
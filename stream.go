@@ -0,0 +1,71 @@
+package gosync
+
+import (
+	"context"
+)
+
+/*
+Streamer is an optional interface for adapters that fetch things in pages. Sync uses Stream instead of Get when it's
+available, so that it can start building its view of an adapter as soon as the first page arrives, without holding
+every page in memory at once.
+
+Stream calls fn with each page of things as it's fetched. If fn returns an error, Stream must stop and return it.
+*/
+type Streamer interface {
+	Stream(ctx context.Context, fn func(things []string) error) error
+}
+
+/*
+Collect streams every page of things into a single slice. It's intended for adapters that implement Streamer, so that
+Get can be written in terms of Stream. Errors from Stream are returned unchanged.
+
+	func (a *Adapter) Get(ctx context.Context) ([]string, error) {
+		return gosync.Collect(ctx, a)
+	}
+*/
+func Collect(ctx context.Context, streamer Streamer) ([]string, error) {
+	out := make([]string, 0)
+
+	err := streamer.Stream(ctx, func(things []string) error {
+		out = append(out, things...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return out, nil
+}
+
+/*
+fetch gets things from an adapter as a hash map of { thing => true }, streaming them if the adapter supports it.
+Adapters that RequiresGet are always fetched with Get, as they may rely on it before Add or Remove are called.
+*/
+func (s *Sync) fetch(ctx context.Context, adapter Adapter) (map[string]bool, error) {
+	out := make(map[string]bool)
+
+	streamer, ok := adapter.(Streamer)
+	if !ok || CapabilitiesOf(adapter).RequiresGet {
+		things, err := adapter.Get(ctx)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		s.addToHashMap(out, things)
+
+		return out, nil
+	}
+
+	err := streamer.Stream(ctx, func(things []string) error {
+		s.addToHashMap(out, things)
+
+		// Stop streaming if the sync has been cancelled.
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return out, nil
+}
@@ -0,0 +1,185 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedAdapter fetches things a page at a time, and collects every page when Get is called.
+type pagedAdapter struct {
+	*MockAdapter
+	pages [][]string
+}
+
+func (p *pagedAdapter) Get(_ context.Context) ([]string, error) {
+	out := make([]string, 0)
+
+	for _, page := range p.pages {
+		out = append(out, page...)
+	}
+
+	return out, nil
+}
+
+// streamingAdapter is a pagedAdapter that also streams each page.
+type streamingAdapter struct {
+	*pagedAdapter
+	err error
+}
+
+func (s *streamingAdapter) Stream(_ context.Context, fn func(things []string) error) error {
+	for _, page := range s.pages {
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+
+	return s.err
+}
+
+// requiresGetAdapter is a streamingAdapter that must be fetched with Get.
+type requiresGetAdapter struct {
+	*streamingAdapter
+}
+
+func (r *requiresGetAdapter) Capabilities() Capabilities {
+	return Capabilities{SupportsAdd: true, SupportsRemove: true, RequiresGet: true}
+}
+
+// newPages creates count things, split into pages of size.
+func newPages(count int, size int) [][]string {
+	pages := make([][]string, 0, count/size+1)
+
+	for start := 0; start < count; start += size {
+		page := make([]string, 0, size)
+
+		for i := start; i < min(start+size, count); i++ {
+			page = append(page, fmt.Sprintf("user-%d@example.com", i))
+		}
+
+		pages = append(pages, page)
+	}
+
+	return pages
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	things, err := Collect(ctx, &streamingAdapter{&pagedAdapter{pages: [][]string{{"foo", "bar"}, {"baz"}}}, nil})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar", "baz"}, things)
+
+	testErr := errors.New("foo") //nolint:goerr113
+
+	_, err = Collect(ctx, &streamingAdapter{&pagedAdapter{pages: [][]string{{"foo"}}}, testErr})
+
+	require.ErrorIs(t, err, testErr)
+}
+
+func TestSync_Stream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Streams source and destination", func(t *testing.T) {
+		t.Parallel()
+
+		// The mock adapters have no expectations for Get, so the test fails if Sync calls it instead of Stream.
+		source := &streamingAdapter{&pagedAdapter{NewMockAdapter(t), [][]string{{"foo", "bar"}, {"baz"}}}, nil}
+		destination := &streamingAdapter{&pagedAdapter{NewMockAdapter(t), [][]string{{"bar"}, {"qux"}}}, nil}
+
+		destination.EXPECT().Remove(ctx, []string{"qux"}).Return(nil)
+		destination.EXPECT().Add(ctx, []string{"foo", "baz"}).Maybe().Return(nil)
+		destination.EXPECT().Add(ctx, []string{"baz", "foo"}).Maybe().Return(nil)
+
+		err := New(source).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Gets destinations that require it", func(t *testing.T) {
+		t.Parallel()
+
+		source := &streamingAdapter{&pagedAdapter{NewMockAdapter(t), [][]string{{"foo"}}}, nil}
+
+		// Streaming the destination fails, so the test fails if Sync calls Stream instead of Get.
+		destination := &requiresGetAdapter{
+			&streamingAdapter{&pagedAdapter{NewMockAdapter(t), [][]string{{"foo"}}}, errors.New("streamed")},
+		}
+
+		err := New(source).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Stream error", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		source := &streamingAdapter{&pagedAdapter{NewMockAdapter(t), [][]string{{"foo"}}}, testErr}
+
+		err := New(source).SyncWith(ctx, NewMockAdapter(t))
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, testErr)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, PhaseGet, syncErr.Phase)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		source := &streamingAdapter{&pagedAdapter{NewMockAdapter(t), [][]string{{"foo"}, {"bar"}}}, nil}
+
+		err := New(source).SyncWith(ctx, NewMockAdapter(t))
+
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+// benchmarkSyncWith synchronises two identical adapters, so that only fetching and comparing things is measured.
+func benchmarkSyncWith(b *testing.B, newAdapter func(pages [][]string) Adapter) {
+	b.Helper()
+
+	ctx := context.TODO()
+	pages := newPages(60000, 100) //nolint:gomnd,mnd
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		err := New(newAdapter(pages), WithSlogLogger(logger)).SyncWith(ctx, newAdapter(pages))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSync_SyncWith_Get(b *testing.B) {
+	benchmarkSyncWith(b, func(pages [][]string) Adapter {
+		return &pagedAdapter{pages: pages}
+	})
+}
+
+func BenchmarkSync_SyncWith_Stream(b *testing.B) {
+	benchmarkSyncWith(b, func(pages [][]string) Adapter {
+		return &streamingAdapter{&pagedAdapter{pages: pages}, nil}
+	})
+}
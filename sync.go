@@ -3,9 +3,12 @@ package gosync
 import (
 	"context"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
 	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Ensure Sync fully satisfies the Service interface.
@@ -28,14 +31,14 @@ const (
 )
 
 type Sync struct {
-	DryRun        bool            // DryRun mode calculates membership, but doesn't add or remove.
+	DryRun        bool            // DryRun mode calculates membership, but doesn't add, remove or update.
 	OperatingMode OperatingMode   // Change the order of Sync's operation. Default is RemoveAdd.
 	CaseSensitive bool            // CaseSensitive sets if Go Sync is case-sensitive. Default is true.
 	source        Adapter         // The source adapter.
 	cache         map[string]bool // cache prevents polling the source more than once.
 	/*
-		MaximumChanges sets the maximum number of allowed changes per add/remove operation. It is not a cumulative
-		total, and the number only applies to each distinct operation.
+		MaximumChanges sets the maximum number of allowed changes per add/remove/update operation. It is not a
+		cumulative total, and the number only applies to each distinct operation.
 
 		For example:
 
@@ -45,7 +48,14 @@ type Sync struct {
 		Default is NoChangeLimit (or -1).
 	*/
 	MaximumChanges int
-	Logger         *log.Logger
+	Logger         *slog.Logger
+	Metrics        *Metrics       // Metrics records Prometheus metrics for each sync, if set. Default is nil.
+	Report         *Report        // Report records the changes computed by each sync, if set. Default is nil.
+	Identities     IdentitySource // Identities matches aliases of the same identity, if set. See [WithIdentities].
+	// PreferAlias picks which of an identity's aliases is added to destinations. See [WithPreferredAlias].
+	PreferAlias func(aliases []string) string
+	identities  *identityIndex // identities are loaded from Identities the first time they're needed.
+	tracer      trace.Tracer   // tracer creates spans for syncs and adapter calls, if tracing is enabled.
 }
 
 // New creates a new Sync service.
@@ -57,7 +67,7 @@ func New(source Adapter, optsFn ...func(*Sync)) *Sync {
 		source:         source,
 		cache:          make(map[string]bool),
 		MaximumChanges: NoChangeLimit,
-		Logger:         log.New(os.Stderr, "[go-sync/sync] ", log.LstdFlags|log.Lshortfile|log.Lmsgprefix),
+		Logger:         slog.Default(),
 	}
 
 	for _, fn := range optsFn {
@@ -67,28 +77,27 @@ func New(source Adapter, optsFn ...func(*Sync)) *Sync {
 	return sync
 }
 
-// generateHashMap takes a list of strings and returns a hashed map of { item => true }.
-func (s *Sync) generateHashMap(i []string) map[string]bool {
-	out := map[string]bool{}
-
+// addToHashMap adds a list of strings to a hashed map of { item => true }.
+func (s *Sync) addToHashMap(hashMap map[string]bool, i []string) {
 	for _, str := range i {
 		if s.CaseSensitive {
-			out[str] = true
+			hashMap[str] = true
 		} else {
-			out[strings.ToLower(str)] = true
+			hashMap[strings.ToLower(str)] = true
 		}
 	}
-
-	return out
 }
 
 // getThingsToAdd determines things that should be added to the destination service.
-func (s *Sync) getThingsToAdd(things []string) []string {
-	out := make([]string, 0, len(things))
-	hashMap := s.generateHashMap(things)
+func (s *Sync) getThingsToAdd(things map[string]bool) []string {
+	if s.identities != nil {
+		return s.getIdentitiesToAdd(things)
+	}
+
+	out := make([]string, 0, len(s.cache))
 
 	for thing := range s.cache {
-		if !hashMap[thing] {
+		if !things[thing] {
 			out = append(out, thing)
 		}
 	}
@@ -97,11 +106,14 @@ func (s *Sync) getThingsToAdd(things []string) []string {
 }
 
 // getThingsToRemove determines things that should be removed from the destination service.
-func (s *Sync) getThingsToRemove(things []string) []string {
+func (s *Sync) getThingsToRemove(things map[string]bool) []string {
+	if s.identities != nil {
+		return s.getIdentitiesToRemove(things)
+	}
+
 	var out []string
 
-	hashMap := s.generateHashMap(things)
-	for thing := range hashMap {
+	for thing := range things {
 		if !s.cache[thing] {
 			out = append(out, thing)
 		}
@@ -110,102 +122,336 @@ func (s *Sync) getThingsToRemove(things []string) []string {
 	return out
 }
 
+// updater is implemented by adapters that can update things in place, which are wrapped typed Updater adapters.
+type updater interface {
+	updates() bool // updates returns true if things can be updated.
+	// changed returns the things that need to be updated, given the source's thing that each of them matches.
+	changed(sources map[string]string) []string
+	// update things in place, with the source's version of each of them.
+	update(ctx context.Context, things []string, sources map[string]string) error
+}
+
+/*
+getThingsToUpdate determines things in both the source and destination service that should be updated in place, and
+records the source's thing that each of them is updated from in sources.
+*/
+func (s *Sync) getThingsToUpdate(adapter updater, sources map[string]string) func(things map[string]bool) []string {
+	return func(things map[string]bool) []string {
+		if s.identities != nil {
+			s.getIdentitiesToUpdate(things, sources)
+		} else {
+			for thing := range things {
+				if s.cache[thing] {
+					sources[thing] = thing
+				}
+			}
+		}
+
+		return adapter.changed(sources)
+	}
+}
+
+// updates returns true if the OperatingMode both adds and removes things, so that things can also be updated in place.
+func (m OperatingMode) updates() bool {
+	return m == RemoveAdd || m == AddRemove
+}
+
 // generateCache populates the cache with a map of things for efficient lookup.
 func (s *Sync) generateCache(ctx context.Context) error {
 	if len(s.cache) == 0 {
-		s.Logger.Println("Getting things from source adapter")
+		logger := s.Logger.With(slog.Group("source", LogAttrs(s.source)...))
+		logger.Info("Getting things from source adapter")
+
+		ctx, span := s.startSpan(ctx, "gosync.source.Get", s.source)
+
+		start := time.Now()
+		things, err := s.fetch(ctx, s.source)
+
+		s.Metrics.observeDuration(s.source, PhaseGet, start)
 
-		things, err := s.source.Get(ctx)
 		if err != nil {
-			return fmt.Errorf("get -> %w", err)
+			endSpan(span, err)
+
+			return fmt.Errorf("get -> %w", wrapError(s.source, PhaseGet, nil, err))
 		}
 
-		s.cache = s.generateHashMap(things)
+		span.SetAttributes(TraceKeyCount.Int(len(things)))
+		span.End()
+		s.Metrics.setSourceSize(s.source, len(things))
+
+		logger.Info("Fetched things from source adapter", slog.Int(LogKeyCount, len(things)))
+
+		s.cache = things
 	}
 
-	return nil
+	return s.loadIdentities(ctx)
 }
 
 // perform processes adding/removing things from a destination service.
 func (s *Sync) perform(
 	ctx context.Context,
-	action string,
-	things []string,
-	diffFn func(things []string) []string,
+	logger *slog.Logger,
+	adapter Adapter,
+	entry int,
+	action Phase,
+	things map[string]bool,
+	diffFn func(things map[string]bool) []string,
 	executeFn func(context.Context, []string) error,
 ) func() error {
 	return func() error {
-		s.Logger.Printf("Processing things to %s\n", action)
+		logger := logger.With(slog.String(LogKeyOperation, string(action)))
+		logger.Info("Processing things")
 
 		thingsToChange := diffFn(things)
 
 		// If the changes exceed the maximum change limit, fail with the ErrTooManyChanges error.
 		if len(thingsToChange) > s.MaximumChanges && s.MaximumChanges != NoChangeLimit {
-			return fmt.Errorf("%s(%v) -> %w(%v)", action, thingsToChange, ErrTooManyChanges, s.MaximumChanges)
+			s.Metrics.recordTooManyChanges(adapter, action)
+			s.Report.record(entry, action, thingsToChange, fmt.Sprintf("maximum changes (%d) exceeded", s.MaximumChanges))
+
+			return NewError(adapter, action, thingsToChange, fmt.Errorf("%w(%v)", ErrTooManyChanges, s.MaximumChanges))
+		}
+
+		if err := checkBatches(adapter, action, thingsToChange); err != nil {
+			s.Report.record(entry, action, thingsToChange, "too many changes for a single batch")
+
+			return err
 		}
 
+		s.annotate(ctx, attribute.Int("gosync."+string(action)+".count", len(thingsToChange)))
+		s.Report.record(entry, action, thingsToChange, "")
+
 		if s.DryRun {
-			s.Logger.Printf("Would %s %s, but running in dry run mode", action, thingsToChange)
+			logger.Info("Running in dry run mode, so no changes have been made",
+				slog.Int(LogKeyCount, len(thingsToChange)),
+				slog.Any(LogKeyThings, thingsToChange),
+			)
 
 			return nil
 		}
 
 		if len(thingsToChange) == 0 {
+			logger.Info("No changes required")
+
 			return nil
 		}
 
-		s.Logger.Printf("%s: %s", action, thingsToChange)
+		logger.Info("Changing things", slog.Int(LogKeyCount, len(thingsToChange)))
 
-		err := executeFn(ctx, thingsToChange)
-		if err != nil {
-			return fmt.Errorf("%s(%v) -> %w", action, things, err)
+		for _, thing := range thingsToChange {
+			logger.Debug("Changing thing", slog.String(LogKeyThing, thing))
+		}
+
+		ctx, span := s.startSpan(ctx, "gosync.destination."+string(action), adapter, TraceKeyCount.Int(len(thingsToChange)))
+
+		start := time.Now()
+
+		// Split the changes into batches that the adapter can accept.
+		for _, things := range batch(thingsToChange, CapabilitiesOf(adapter).MaxBatchSize) {
+			err := executeFn(ctx, things)
+
+			s.Metrics.recordChanges(adapter, action, len(things), err)
+
+			if err != nil {
+				endSpan(span, err)
+				s.Metrics.observeDuration(adapter, action, start)
+
+				return wrapError(adapter, action, things, err)
+			}
 		}
 
+		endSpan(span, nil)
+		s.Metrics.observeDuration(adapter, action, start)
+
+		return nil
+	}
+}
+
+// A CallOption overrides one of Sync's defaults for a single call to SyncWith.
+type CallOption func(*Sync)
+
+// WithOperatingMode overrides Sync's OperatingMode for a single call to SyncWith.
+func WithOperatingMode(mode OperatingMode) CallOption {
+	return func(s *Sync) {
+		s.OperatingMode = mode
+	}
+}
+
+// WithDryRun overrides Sync's DryRun mode for a single call to SyncWith.
+func WithDryRun(dryRun bool) CallOption {
+	return func(s *Sync) {
+		s.DryRun = dryRun
+	}
+}
+
+// WithMaximumChanges overrides Sync's MaximumChanges for a single call to SyncWith.
+func WithMaximumChanges(maximumChanges int) CallOption {
+	return func(s *Sync) {
+		s.MaximumChanges = maximumChanges
+	}
+}
+
+/*
+WithCaseSensitive overrides Sync's CaseSensitive setting for a single call to SyncWith.
+
+Things are lowercased when they're fetched case-insensitively, and can't be made case-sensitive again. A case-sensitive
+call on a case-insensitive Sync fetches the source again, and things fetched by a case-insensitive call aren't shared
+with a case-sensitive Sync.
+*/
+func WithCaseSensitive(caseSensitive bool) CallOption {
+	return func(s *Sync) {
+		s.CaseSensitive = caseSensitive
+	}
+}
+
+// foldCache converts a cache of things between case sensitivities, or returns nil if the original case has been lost.
+func foldCache(cache map[string]bool, from bool, to bool) map[string]bool {
+	switch {
+	case from == to:
+		return cache
+	case !from:
 		return nil
 	}
+
+	out := make(map[string]bool, len(cache))
+
+	for thing := range cache {
+		out[strings.ToLower(thing)] = true
+	}
+
+	return out
+}
+
+/*
+SyncWith synchronises the destination service with the source service, adding & removing things as necessary.
+
+CallOptions override Sync's defaults for this call only, so that one Sync can synchronise destinations that need
+different behaviour without fetching the source more than once:
+
+	err := syncService.SyncWith(ctx, productionTeam, gosync.WithMaximumChanges(5))
+	err = syncService.SyncWith(ctx, publicChannel, gosync.WithOperatingMode(gosync.AddOnly))
+*/
+func (s *Sync) SyncWith(ctx context.Context, adapter Adapter, opts ...CallOption) error {
+	if len(opts) == 0 {
+		return s.syncWithSpan(ctx, adapter)
+	}
+
+	call := *s
+
+	for _, opt := range opts {
+		opt(&call)
+	}
+
+	call.cache = foldCache(s.cache, s.CaseSensitive, call.CaseSensitive)
+	if call.cache == nil {
+		call.cache = make(map[string]bool)
+	}
+
+	err := call.syncWithSpan(ctx, adapter)
+
+	// Share the source's things and identities with later calls, so that they're only fetched once.
+	if s.identities == nil {
+		s.identities = call.identities
+	}
+
+	if len(s.cache) == 0 {
+		if cache := foldCache(call.cache, call.CaseSensitive, s.CaseSensitive); cache != nil {
+			s.cache = cache
+		}
+	}
+
+	return err
+}
+
+// syncWithSpan performs the sync within a SyncWith span.
+func (s *Sync) syncWithSpan(ctx context.Context, adapter Adapter) error {
+	ctx, span := s.startSpan(ctx, "gosync.SyncWith", adapter,
+		TraceKeyMode.String(string(s.OperatingMode)),
+		TraceKeyDryRun.Bool(s.DryRun),
+		TraceKeyCaseSensitive.Bool(s.CaseSensitive),
+	)
+
+	entry := s.Report.begin(adapter, s.OperatingMode, s.DryRun)
+
+	err := s.syncWith(ctx, adapter, entry)
+	endSpan(span, err)
+	s.Report.finish(entry, err)
+
+	return err
 }
 
-// SyncWith synchronises the destination service with the source service, adding & removing things as necessary.
-func (s *Sync) SyncWith(ctx context.Context, adapter Adapter) error {
-	s.Logger.Println("Starting sync")
+// syncWith performs the sync, recording its changes against the destination's entry in the report.
+func (s *Sync) syncWith(ctx context.Context, adapter Adapter, entry int) error {
+	logger := s.Logger.With(LogAttrs(adapter)...)
+	logger.Info("Starting sync")
+
+	// Check the destination can be synchronised before calling any adapters.
+	if err := s.preflight(adapter); err != nil {
+		return fmt.Errorf("sync.syncwith.preflight -> %w", err)
+	}
 
 	// Call to populate the cache from the source adapter.
 	if err := s.generateCache(ctx); err != nil {
 		return fmt.Errorf("sync.syncwith.generateCache -> %w", err)
 	}
 
-	s.Logger.Println("Getting things from destination adapter")
+	logger.Info("Getting things from destination adapter")
+
+	getCtx, span := s.startSpan(ctx, "gosync.destination.Get", adapter)
+
+	start := time.Now()
+	things, err := s.fetch(getCtx, adapter)
+
+	s.Metrics.observeDuration(adapter, PhaseGet, start)
 
-	things, err := adapter.Get(ctx)
 	if err != nil {
-		return fmt.Errorf("sync.syncwith.get -> %w", err)
+		endSpan(span, err)
+
+		return fmt.Errorf("sync.syncwith.get -> %w", wrapError(adapter, PhaseGet, nil, err))
 	}
 
-	s.Logger.Printf("Running in %s operating mode", s.OperatingMode)
+	span.SetAttributes(TraceKeyCount.Int(len(things)))
+	span.End()
+	s.Metrics.setDestinationSize(adapter, len(things))
+
+	logger.Info("Fetched things from destination adapter", slog.Int(LogKeyCount, len(things)))
+	logger.Info("Running sync operations", slog.String(LogKeyMode, string(s.OperatingMode)))
 
 	operations := make([]func() error, 0, 2) //nolint:gomnd,mnd
 
 	switch s.OperatingMode {
 	case AddOnly:
 		operations = []func() error{
-			s.perform(ctx, "add", things, s.getThingsToAdd, adapter.Add),
+			s.perform(ctx, logger, adapter, entry, PhaseAdd, things, s.getThingsToAdd, adapter.Add),
 		}
 	case RemoveOnly:
 		operations = []func() error{
-			s.perform(ctx, "remove", things, s.getThingsToRemove, adapter.Remove),
+			s.perform(ctx, logger, adapter, entry, PhaseRemove, things, s.getThingsToRemove, adapter.Remove),
 		}
 	case RemoveAdd:
 		operations = []func() error{
-			s.perform(ctx, "remove", things, s.getThingsToRemove, adapter.Remove),
-			s.perform(ctx, "add", things, s.getThingsToAdd, adapter.Add),
+			s.perform(ctx, logger, adapter, entry, PhaseRemove, things, s.getThingsToRemove, adapter.Remove),
+			s.perform(ctx, logger, adapter, entry, PhaseAdd, things, s.getThingsToAdd, adapter.Add),
 		}
 	case AddRemove:
 		operations = []func() error{
-			s.perform(ctx, "add", things, s.getThingsToAdd, adapter.Add),
-			s.perform(ctx, "remove", things, s.getThingsToRemove, adapter.Remove),
+			s.perform(ctx, logger, adapter, entry, PhaseAdd, things, s.getThingsToAdd, adapter.Add),
+			s.perform(ctx, logger, adapter, entry, PhaseRemove, things, s.getThingsToRemove, adapter.Remove),
 		}
 	}
 
+	// Things are updated in place after they've been added and removed, if the destination supports it.
+	if updater, ok := adapter.(updater); ok && updater.updates() && s.OperatingMode.updates() {
+		sources := make(map[string]string)
+
+		operations = append(operations,
+			s.perform(ctx, logger, adapter, entry, PhaseUpdate, things, s.getThingsToUpdate(updater, sources),
+				func(ctx context.Context, things []string) error {
+					return updater.update(ctx, things, sources)
+				}),
+		)
+	}
+
 	for _, fn := range operations {
 		err = fn()
 		if err != nil {
@@ -213,7 +459,8 @@ func (s *Sync) SyncWith(ctx context.Context, adapter Adapter) error {
 		}
 	}
 
-	s.Logger.Println("Finished sync")
+	s.Metrics.recordSuccess(adapter)
+	logger.Info("Finished sync")
 
 	return nil
 }
@@ -428,3 +428,75 @@ func TestSync_SyncWith(t *testing.T) { //nolint:maintidx
 		})
 	})
 }
+
+func TestSync_SyncWith_CallOptions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Overrides defaults for one call", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Once().Return([]string{"foo"}, nil)
+
+		channel := NewMockAdapter(t)
+		channel.EXPECT().Get(ctx).Return([]string{"bar"}, nil)
+		channel.EXPECT().Add(ctx, []string{"foo"}).Return(nil)
+
+		trial := NewMockAdapter(t)
+		trial.EXPECT().Get(ctx).Return([]string{"bar"}, nil)
+
+		team := NewMockAdapter(t)
+		team.EXPECT().Get(ctx).Return([]string{"bar", "baz"}, nil)
+
+		syncService := New(source)
+
+		require.NoError(t, syncService.SyncWith(ctx, channel, WithOperatingMode(AddOnly)))
+		require.NoError(t, syncService.SyncWith(ctx, trial, WithDryRun(true)))
+
+		err := syncService.SyncWith(ctx, team, WithMaximumChanges(1))
+
+		require.ErrorIs(t, err, ErrTooManyChanges)
+
+		// The Sync's own defaults are unchanged.
+		assert.Equal(t, RemoveAdd, syncService.OperatingMode)
+		assert.False(t, syncService.DryRun)
+		assert.Equal(t, NoChangeLimit, syncService.MaximumChanges)
+	})
+
+	t.Run("Case insensitive call", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Once().Return([]string{"Foo"}, nil)
+
+		destination := NewMockAdapter(t)
+		destination.EXPECT().Get(ctx).Once().Return([]string{"Foo"}, nil)
+		destination.EXPECT().Get(ctx).Once().Return([]string{"foo"}, nil)
+
+		syncService := New(source)
+
+		require.NoError(t, syncService.SyncWith(ctx, destination))
+		require.NoError(t, syncService.SyncWith(ctx, destination, WithCaseSensitive(false)))
+		assert.Equal(t, map[string]bool{"Foo": true}, syncService.cache)
+	})
+
+	t.Run("Case sensitive call on a case insensitive Sync", func(t *testing.T) {
+		t.Parallel()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(ctx).Twice().Return([]string{"Foo"}, nil)
+
+		destination := NewMockAdapter(t)
+		destination.EXPECT().Get(ctx).Return([]string{"Foo"}, nil)
+
+		syncService := New(source, func(s *Sync) {
+			s.CaseSensitive = false
+		})
+
+		require.NoError(t, syncService.SyncWith(ctx, destination))
+		require.NoError(t, syncService.SyncWith(ctx, destination, WithCaseSensitive(true)))
+		assert.Equal(t, map[string]bool{"foo": true}, syncService.cache)
+	})
+}
@@ -37,3 +37,32 @@ func ExampleNew() {
 		log.Panic(err)
 	}
 }
+
+func ExampleSync_SyncWith() {
+	ctx := context.Background()
+
+	source, err := team.Init(ctx, map[gosync.ConfigKey]string{
+		team.GitHubToken: "some-token",
+		team.GitHubOrg:   "my-org",
+		team.TeamSlug:    "my-team",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	channel, err := conversation.Init(ctx, map[gosync.ConfigKey]string{
+		conversation.SlackAPIKey: "some-key",
+		conversation.Name:        "public-channel",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	syncService := gosync.New(source)
+
+	// Members can't be removed from this channel, so only add them. The source is only fetched once.
+	err = syncService.SyncWith(ctx, channel, gosync.WithOperatingMode(gosync.AddOnly), gosync.WithMaximumChanges(10))
+	if err != nil {
+		log.Panic(err)
+	}
+}
@@ -0,0 +1,368 @@
+package gosync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+/*
+Thing is something that can be synchronised by a TypedAdapter. Things are compared by their Key, so two things with
+the same key are the same thing, regardless of any other data they carry.
+*/
+type Thing interface {
+	Key() string
+}
+
+/*
+Item is a general purpose Thing, identified by its ID and carrying any other data as attributes. For example, a user
+identified by their email, with their display name and role:
+
+	gosync.Item{ID: "foo@example.com", Attributes: map[string]string{"name": "Foo", "role": "maintainer"}}
+*/
+type Item struct {
+	ID         string            // ID is the key used to compare items between adapters.
+	Attributes map[string]string // Attributes are additional data about the item, which aren't compared.
+}
+
+// Key of the item, which is its ID.
+func (i Item) Key() string {
+	return i.ID
+}
+
+/*
+Matches reports whether other has the same value for each of the item's attributes. Attributes that only other has are
+ignored, so that a destination can keep data of its own, such as IDs.
+*/
+func (i Item) Matches(other Item) bool {
+	for key, value := range i.Attributes {
+		if otherValue, ok := other.Attributes[key]; !ok || otherValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Matcher is an optional interface for Things, to report whether a thing in a destination matches the same thing in the
+source. TypedSync only updates things that implement Matcher.
+*/
+type Matcher[T Thing] interface {
+	Matches(other T) bool
+}
+
+/*
+TypedAdapter is a variant of Adapter for things that carry more than a string. Add is passed the source adapter's
+things, and Remove is passed the things previously returned by Get, so adapters don't need to keep their own caches
+of IDs.
+*/
+type TypedAdapter[T Thing] interface {
+	Get(ctx context.Context) (things []T, err error) // Get things in a service.
+	Add(ctx context.Context, things []T) error       // Add things to a service.
+	Remove(ctx context.Context, things []T) error    // Remove things from a service.
+}
+
+/*
+Updater is an optional interface for TypedAdapters that can change things in place. If the destination implements
+Updater, TypedSync passes it the source's version of each thing that exists in both adapters, but doesn't match. For
+example, a member whose role has changed can be updated rather than removed and added again.
+*/
+type Updater[T Thing] interface {
+	Update(ctx context.Context, things []T) error
+}
+
+/*
+TypedSync synchronises TypedAdapters, comparing things by their Key. It embeds Sync, and supports the same options.
+
+	err := gosync.NewTyped[gosync.Item](source).SyncWith(ctx, destination)
+*/
+type TypedSync[T Thing] struct {
+	*Sync
+	source *untypedAdapter[T]
+}
+
+// NewTyped creates a new TypedSync service, accepting the same options as New.
+func NewTyped[T Thing](source TypedAdapter[T], optsFn ...func(*Sync)) *TypedSync[T] {
+	bridge := &untypedAdapter[T]{adapter: source}
+
+	return &TypedSync[T]{
+		Sync:   New(bridge, optsFn...),
+		source: bridge,
+	}
+}
+
+/*
+SyncWith synchronises the destination adapter with the source adapter, adding & removing things as necessary.
+CallOptions override Sync's defaults for this call only.
+*/
+func (t *TypedSync[T]) SyncWith(ctx context.Context, adapter TypedAdapter[T], opts ...CallOption) error {
+	if sameAdapter(t.source.adapter, adapter) {
+		return fmt.Errorf("sync.syncwith.preflight -> %w: source and destination are the same adapter", ErrInvalidConfig)
+	}
+
+	// Things being added or updated are looked up from the source, so the destination receives all of their data.
+	destination := &untypedAdapter[T]{adapter: adapter, source: t.source}
+
+	return t.Sync.SyncWith(ctx, destination, opts...)
+}
+
+/*
+Typed converts an Adapter into a TypedAdapter, so that existing adapters can be synchronised with typed adapters.
+Things returned by Get are created from their keys with newThing, and only the keys of things are passed to Add and
+Remove.
+*/
+func Typed[T Thing](adapter Adapter, newThing func(key string) T) TypedAdapter[T] { //nolint:ireturn
+	return &typedAdapter[T]{adapter: adapter, newThing: newThing}
+}
+
+/*
+Untyped converts a TypedAdapter into an Adapter, so that typed adapters can be used with Sync and Runner. Keys passed
+to Remove are resolved to the things returned by Get, and keys passed to Add, or that haven't been fetched, are
+converted to things with newThing.
+
+Untyped panics if newThing is nil.
+*/
+func Untyped[T Thing](adapter TypedAdapter[T], newThing func(key string) T) Adapter { //nolint:ireturn
+	if newThing == nil {
+		panic("gosync: Untyped newThing function is nil")
+	}
+
+	return &untypedAdapter[T]{adapter: adapter, newThing: newThing}
+}
+
+// typedAdapter wraps an Adapter as a TypedAdapter.
+type typedAdapter[T Thing] struct {
+	adapter  Adapter
+	newThing func(key string) T
+}
+
+func (t *typedAdapter[T]) Get(ctx context.Context) ([]T, error) {
+	keys, err := t.adapter.Get(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	things := make([]T, 0, len(keys))
+
+	for _, key := range keys {
+		things = append(things, t.newThing(key))
+	}
+
+	return things, nil
+}
+
+func (t *typedAdapter[T]) Add(ctx context.Context, things []T) error {
+	return t.adapter.Add(ctx, keys(things)) //nolint:wrapcheck
+}
+
+func (t *typedAdapter[T]) Remove(ctx context.Context, things []T) error {
+	return t.adapter.Remove(ctx, keys(things)) //nolint:wrapcheck
+}
+
+// Kind of the adapter. See [gosync.Describe].
+func (t *typedAdapter[T]) Kind() string {
+	kind, _ := Describe(t.adapter)
+
+	return kind
+}
+
+// Target of the adapter. See [gosync.Describe].
+func (t *typedAdapter[T]) Target() string {
+	_, target := Describe(t.adapter)
+
+	return target
+}
+
+// Capabilities of the adapter. See [gosync.CapabilitiesOf].
+func (t *typedAdapter[T]) Capabilities() Capabilities {
+	return CapabilitiesOf(t.adapter)
+}
+
+// Validate the adapter, if it implements Validator.
+func (t *typedAdapter[T]) Validate(ctx context.Context) error {
+	if validator, ok := t.adapter.(Validator); ok {
+		return validator.Validate(ctx) //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// keys returns the key of each thing.
+func keys[T Thing](things []T) []string {
+	out := make([]string, 0, len(things))
+
+	for _, thing := range things {
+		out = append(out, thing.Key())
+	}
+
+	return out
+}
+
+// untypedAdapter wraps a TypedAdapter as an Adapter, so that Sync can diff its things by key.
+type untypedAdapter[T Thing] struct {
+	adapter  TypedAdapter[T]
+	source   *untypedAdapter[T] // source adapter of a TypedSync, used to look up things being added and updated.
+	newThing func(key string) T // newThing creates things for keys that haven't been fetched.
+	things   map[string]T       // things returned by the last call to Get, by key.
+	folded   map[string]T       // folded are things by lowercase key, as Sync lowercases keys if case-insensitive.
+}
+
+// Get keys of things in the typed adapter, keeping the things so that they can be passed to Add and Remove.
+func (u *untypedAdapter[T]) Get(ctx context.Context) ([]string, error) {
+	things, err := u.adapter.Get(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	u.things = make(map[string]T, len(things))
+	u.folded = make(map[string]T, len(things))
+
+	for _, thing := range things {
+		u.things[thing.Key()] = thing
+
+		if _, ok := u.folded[strings.ToLower(thing.Key())]; !ok {
+			u.folded[strings.ToLower(thing.Key())] = thing
+		}
+	}
+
+	return keys(things), nil
+}
+
+// lookup returns the fetched thing with a key.
+func (u *untypedAdapter[T]) lookup(key string) (T, bool) {
+	if thing, ok := u.things[key]; ok {
+		return thing, true
+	}
+
+	thing, ok := u.folded[strings.ToLower(key)]
+
+	return thing, ok
+}
+
+func (u *untypedAdapter[T]) Add(ctx context.Context, keys []string) error {
+	return u.adapter.Add(ctx, u.resolve(keys)) //nolint:wrapcheck
+}
+
+func (u *untypedAdapter[T]) Remove(ctx context.Context, keys []string) error {
+	return u.adapter.Remove(ctx, u.resolve(keys)) //nolint:wrapcheck
+}
+
+/*
+thing returns the fetched thing with a key, or the source's thing, or creates a new one. Adapters created by Untyped
+always have newThing, and the destination of a TypedSync is only passed keys that it or its source has fetched.
+*/
+func (u *untypedAdapter[T]) thing(key string) T {
+	if thing, ok := u.lookup(key); ok {
+		return thing
+	}
+
+	if u.source != nil {
+		if thing, ok := u.source.lookup(key); ok {
+			return thing
+		}
+	}
+
+	if u.newThing != nil {
+		return u.newThing(key)
+	}
+
+	var zero T
+
+	return zero
+}
+
+// resolve converts keys into things.
+func (u *untypedAdapter[T]) resolve(keys []string) []T {
+	things := make([]T, 0, len(keys))
+
+	for _, key := range keys {
+		things = append(things, u.thing(key))
+	}
+
+	return things
+}
+
+// updates returns true if the typed adapter implements Updater, and the source's things can be compared with it.
+func (u *untypedAdapter[T]) updates() bool {
+	_, ok := u.adapter.(Updater[T])
+
+	return ok && u.source != nil
+}
+
+/*
+changed returns the keys of things that don't match the source's version of them. Sources maps the keys of things to
+the key of the source's thing that they match, which may be another alias of the same identity.
+*/
+func (u *untypedAdapter[T]) changed(sources map[string]string) []string {
+	out := make([]string, 0)
+
+	for _, key := range sortedKeys(sources) {
+		thing, ok := u.lookup(key)
+		if !ok {
+			continue
+		}
+
+		source, ok := u.source.lookup(sources[key])
+		if !ok {
+			continue
+		}
+
+		if matcher, ok := any(source).(Matcher[T]); ok && !matcher.Matches(thing) {
+			out = append(out, key)
+		}
+	}
+
+	return out
+}
+
+/*
+update passes the source's version of things to the typed adapter's Update method. Things matched through an alias are
+passed with the source's key.
+*/
+func (u *untypedAdapter[T]) update(ctx context.Context, keys []string, sources map[string]string) error {
+	things := make([]T, 0, len(keys))
+
+	for _, key := range keys {
+		thing, _ := u.source.lookup(sources[key])
+		things = append(things, thing)
+	}
+
+	return u.adapter.(Updater[T]).Update(ctx, things) //nolint:forcetypeassert,wrapcheck
+}
+
+// Kind of the typed adapter, if it implements Describer.
+func (u *untypedAdapter[T]) Kind() string {
+	if describer, ok := u.adapter.(Describer); ok {
+		return describer.Kind()
+	}
+
+	return fmt.Sprintf("%T", u.adapter)
+}
+
+// Target of the typed adapter, if it implements Describer.
+func (u *untypedAdapter[T]) Target() string {
+	if describer, ok := u.adapter.(Describer); ok {
+		return describer.Target()
+	}
+
+	return ""
+}
+
+// Capabilities of the typed adapter, if it implements Capable.
+func (u *untypedAdapter[T]) Capabilities() Capabilities {
+	if capable, ok := u.adapter.(Capable); ok {
+		return capable.Capabilities()
+	}
+
+	return Capabilities{SupportsAdd: true, SupportsRemove: true}
+}
+
+// Validate the typed adapter, if it implements Validator.
+func (u *untypedAdapter[T]) Validate(ctx context.Context) error {
+	if validator, ok := u.adapter.(Validator); ok {
+		return validator.Validate(ctx) //nolint:wrapcheck
+	}
+
+	return nil
+}
@@ -0,0 +1,342 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAdapter is a TypedAdapter that keeps its things in memory, and records the things passed to Add and Remove.
+type memoryAdapter[T Thing] struct {
+	things  []T
+	added   []T
+	removed []T
+	err     error
+}
+
+func (m *memoryAdapter[T]) Get(_ context.Context) ([]T, error) {
+	return m.things, m.err
+}
+
+func (m *memoryAdapter[T]) Add(_ context.Context, things []T) error {
+	m.added = append(m.added, things...)
+
+	return m.err
+}
+
+func (m *memoryAdapter[T]) Remove(_ context.Context, things []T) error {
+	m.removed = append(m.removed, things...)
+
+	return m.err
+}
+
+func (m *memoryAdapter[T]) Kind() string {
+	return "test/memory"
+}
+
+func (m *memoryAdapter[T]) Target() string {
+	return "target"
+}
+
+// updatingAdapter is a memoryAdapter that also records the things passed to Update.
+type updatingAdapter[T Thing] struct {
+	*memoryAdapter[T]
+	updated   []T
+	updateErr error
+}
+
+func (u *updatingAdapter[T]) Update(_ context.Context, things []T) error {
+	u.updated = append(u.updated, things...)
+
+	return u.updateErr
+}
+
+func TestItem_Matches(t *testing.T) {
+	t.Parallel()
+
+	item := Item{ID: "foo", Attributes: map[string]string{"role": "maintainer"}}
+
+	assert.True(t, item.Matches(Item{ID: "foo", Attributes: map[string]string{"role": "maintainer"}}))
+	assert.True(t, item.Matches(Item{ID: "foo", Attributes: map[string]string{"role": "maintainer", "id": "U1"}}))
+	assert.False(t, item.Matches(Item{ID: "foo", Attributes: map[string]string{"role": "member"}}))
+	assert.False(t, item.Matches(Item{ID: "foo"}))
+	assert.True(t, Item{ID: "foo"}.Matches(item))
+}
+
+func TestTypedSync_SyncWith(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Passes things with their attributes", func(t *testing.T) {
+		t.Parallel()
+
+		source := &memoryAdapter[Item]{things: []Item{
+			{ID: "foo@email", Attributes: map[string]string{"role": "maintainer"}},
+			{ID: "bar@email", Attributes: map[string]string{"role": "member"}},
+		}}
+		destination := &memoryAdapter[Item]{things: []Item{
+			{ID: "bar@email", Attributes: map[string]string{"id": "U2"}},
+			{ID: "baz@email", Attributes: map[string]string{"id": "U3"}},
+		}}
+
+		err := NewTyped[Item](source).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Item{{ID: "foo@email", Attributes: map[string]string{"role": "maintainer"}}}, destination.added)
+		assert.Equal(t, []Item{{ID: "baz@email", Attributes: map[string]string{"id": "U3"}}}, destination.removed)
+	})
+
+	t.Run("Case insensitive", func(t *testing.T) {
+		t.Parallel()
+
+		source := &memoryAdapter[Item]{things: []Item{{ID: "Foo@Email"}, {ID: "Bar@Email"}}}
+		destination := &memoryAdapter[Item]{things: []Item{{ID: "foo@email"}, {ID: "Baz@Email"}}}
+
+		err := NewTyped[Item](source, func(s *Sync) {
+			s.CaseSensitive = false
+		}).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Item{{ID: "Bar@Email"}}, destination.added)
+		assert.Equal(t, []Item{{ID: "Baz@Email"}}, destination.removed)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		source := &memoryAdapter[Item]{things: []Item{{ID: "foo@email"}}}
+		destination := &memoryAdapter[Item]{err: testErr}
+
+		err := NewTyped[Item](source).SyncWith(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, testErr)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, "test/memory", syncErr.Kind)
+		assert.Equal(t, PhaseGet, syncErr.Phase)
+	})
+
+	t.Run("Updates changed things", func(t *testing.T) {
+		t.Parallel()
+
+		source := &memoryAdapter[Item]{things: []Item{
+			{ID: "foo@email", Attributes: map[string]string{"role": "maintainer"}},
+			{ID: "bar@email", Attributes: map[string]string{"role": "member"}},
+		}}
+		destination := &updatingAdapter[Item]{memoryAdapter: &memoryAdapter[Item]{things: []Item{
+			{ID: "foo@email", Attributes: map[string]string{"role": "member", "id": "U1"}},
+			{ID: "bar@email", Attributes: map[string]string{"role": "member", "id": "U2"}},
+		}}}
+
+		metrics := newTestMetrics()
+
+		err := NewTyped[Item](source, WithMetrics(metrics)).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Item{{ID: "foo@email", Attributes: map[string]string{"role": "maintainer"}}}, destination.updated)
+		assert.Empty(t, destination.added)
+		assert.Empty(t, destination.removed)
+		assert.InDelta(t, 1, testutil.ToFloat64(metrics.updated.WithLabelValues("test/memory", "target")), 0)
+	})
+
+	t.Run("Updates case insensitive", func(t *testing.T) {
+		t.Parallel()
+
+		source := &memoryAdapter[Item]{things: []Item{{ID: "Foo@Email", Attributes: map[string]string{"role": "owner"}}}}
+		destination := &updatingAdapter[Item]{memoryAdapter: &memoryAdapter[Item]{things: []Item{
+			{ID: "foo@email", Attributes: map[string]string{"role": "member"}},
+		}}}
+
+		err := NewTyped[Item](source, func(s *Sync) {
+			s.CaseSensitive = false
+		}).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Item{{ID: "Foo@Email", Attributes: map[string]string{"role": "owner"}}}, destination.updated)
+	})
+
+	t.Run("Updates things matched through an alias", func(t *testing.T) {
+		t.Parallel()
+
+		source := &memoryAdapter[Item]{things: []Item{
+			{ID: "jane@example.com", Attributes: map[string]string{"role": "maintainer"}},
+		}}
+		destination := &updatingAdapter[Item]{memoryAdapter: &memoryAdapter[Item]{things: []Item{
+			{ID: "jane@old-example.com", Attributes: map[string]string{"role": "member"}},
+		}}}
+
+		identities := StaticIdentities{{"jane@example.com", "jane@old-example.com"}}
+
+		err := NewTyped[Item](source, WithIdentities(identities)).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+		assert.Equal(t, source.things, destination.updated)
+		assert.Empty(t, destination.added)
+		assert.Empty(t, destination.removed)
+	})
+
+	t.Run("Only updates in modes that add and remove", func(t *testing.T) {
+		t.Parallel()
+
+		for mode, updates := range map[OperatingMode]bool{
+			AddOnly:    false,
+			RemoveOnly: false,
+			AddRemove:  true,
+			RemoveAdd:  true,
+		} {
+			source := &memoryAdapter[Item]{things: []Item{{ID: "foo", Attributes: map[string]string{"role": "owner"}}}}
+			destination := &updatingAdapter[Item]{memoryAdapter: &memoryAdapter[Item]{things: []Item{
+				{ID: "foo", Attributes: map[string]string{"role": "member"}},
+			}}}
+
+			err := NewTyped[Item](source, func(s *Sync) {
+				s.OperatingMode = mode
+			}).SyncWith(ctx, destination)
+
+			require.NoError(t, err, mode)
+			assert.Equal(t, updates, len(destination.updated) == 1, mode)
+		}
+	})
+
+	t.Run("Dry run doesn't update", func(t *testing.T) {
+		t.Parallel()
+
+		source := &memoryAdapter[Item]{things: []Item{{ID: "foo", Attributes: map[string]string{"role": "owner"}}}}
+		destination := &updatingAdapter[Item]{memoryAdapter: &memoryAdapter[Item]{things: []Item{
+			{ID: "foo", Attributes: map[string]string{"role": "member"}},
+		}}}
+
+		err := NewTyped[Item](source, func(s *Sync) {
+			s.DryRun = true
+		}).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+		assert.Empty(t, destination.updated)
+	})
+
+	t.Run("Updates count towards their own change limit", func(t *testing.T) {
+		t.Parallel()
+
+		source := &memoryAdapter[Item]{things: []Item{
+			{ID: "foo", Attributes: map[string]string{"role": "owner"}},
+			{ID: "bar", Attributes: map[string]string{"role": "owner"}},
+			{ID: "baz"},
+		}}
+		destination := &updatingAdapter[Item]{memoryAdapter: &memoryAdapter[Item]{things: []Item{
+			{ID: "foo", Attributes: map[string]string{"role": "member"}},
+			{ID: "bar", Attributes: map[string]string{"role": "member"}},
+		}}}
+
+		err := NewTyped[Item](source, func(s *Sync) {
+			s.MaximumChanges = 1
+		}).SyncWith(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, ErrTooManyChanges)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, PhaseUpdate, syncErr.Phase)
+		assert.Equal(t, []Item{{ID: "baz"}}, destination.added)
+		assert.Empty(t, destination.updated)
+	})
+
+	t.Run("Update error", func(t *testing.T) {
+		t.Parallel()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		source := &memoryAdapter[Item]{things: []Item{{ID: "foo", Attributes: map[string]string{"role": "owner"}}}}
+		destination := &updatingAdapter[Item]{
+			memoryAdapter: &memoryAdapter[Item]{things: []Item{{ID: "foo"}}},
+			updateErr:     testErr,
+		}
+
+		err := NewTyped[Item](source).SyncWith(ctx, destination)
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, testErr)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, PhaseUpdate, syncErr.Phase)
+		assert.Equal(t, []string{"foo"}, syncErr.Things)
+	})
+
+	t.Run("Same source and destination", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := &memoryAdapter[Item]{}
+
+		err := NewTyped[Item](adapter).SyncWith(ctx, adapter)
+
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestTyped(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	adapter := &capableAdapter{NewMockAdapter(t), Capabilities{SupportsAdd: true}}
+	adapter.EXPECT().Get(ctx).Return([]string{"foo", "bar"}, nil)
+	adapter.EXPECT().Add(ctx, []string{"baz"}).Return(nil)
+
+	typed := Typed(adapter, func(key string) Item {
+		return Item{ID: key, Attributes: map[string]string{"source": "typed"}}
+	})
+
+	things, err := typed.Get(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Item{
+		{ID: "foo", Attributes: map[string]string{"source": "typed"}},
+		{ID: "bar", Attributes: map[string]string{"source": "typed"}},
+	}, things)
+	require.NoError(t, typed.Add(ctx, []Item{{ID: "baz"}}))
+
+	// The adapter's capabilities are kept when it's converted back for Sync.
+	assert.Equal(t, Capabilities{SupportsAdd: true}, CapabilitiesOf(Untyped(typed, func(key string) Item {
+		return Item{ID: key}
+	})))
+}
+
+func TestUntyped(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	source := NewMockAdapter(t)
+	source.EXPECT().Get(ctx).Return([]string{"foo", "bar"}, nil)
+
+	destination := &memoryAdapter[Item]{things: []Item{
+		{ID: "bar", Attributes: map[string]string{"id": "2"}},
+		{ID: "baz", Attributes: map[string]string{"id": "3"}},
+	}}
+
+	untyped := Untyped[Item](destination, func(key string) Item {
+		return Item{ID: key}
+	})
+
+	err := New(source).SyncWith(ctx, untyped)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Item{{ID: "foo"}}, destination.added)
+	assert.Equal(t, []Item{{ID: "baz", Attributes: map[string]string{"id": "3"}}}, destination.removed)
+
+	kind, target := Describe(untyped)
+
+	assert.Equal(t, "test/memory", kind)
+	assert.Equal(t, "target", target)
+
+	assert.Panics(t, func() {
+		Untyped[Item](destination, nil)
+	})
+}
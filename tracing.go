@@ -0,0 +1,80 @@
+package gosync
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerName is the instrumentation name of the tracer used by Sync.
+const TracerName = "github.com/ovotech/go-sync"
+
+// Attribute keys used in OpenTelemetry spans created by Sync.
+const (
+	TraceKeyAdapter       = attribute.Key("gosync.adapter")        // TraceKeyAdapter is the kind of adapter.
+	TraceKeyTarget        = attribute.Key("gosync.target")         // TraceKeyTarget is the target of the adapter.
+	TraceKeyCount         = attribute.Key("gosync.count")          // TraceKeyCount is the number of things.
+	TraceKeyMode          = attribute.Key("gosync.mode")           // TraceKeyMode is the OperatingMode of Sync.
+	TraceKeyDryRun        = attribute.Key("gosync.dry_run")        // TraceKeyDryRun is true in dry run mode.
+	TraceKeyCaseSensitive = attribute.Key("gosync.case_sensitive") // TraceKeyCaseSensitive is true if case-sensitive.
+)
+
+// traceAttrs returns the span attributes that identify an adapter.
+func traceAttrs(adapter Adapter) []attribute.KeyValue {
+	kind, target := Describe(adapter)
+
+	return []attribute.KeyValue{TraceKeyAdapter.String(kind), TraceKeyTarget.String(target)}
+}
+
+// startSpan starts a child span for an adapter call. If tracing isn't enabled, the context is returned unchanged.
+func (s *Sync) startSpan(
+	ctx context.Context,
+	name string,
+	adapter Adapter,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	if s.tracer == nil {
+		return ctx, noop.Span{}
+	}
+
+	return s.tracer.Start(ctx, name, //nolint:spancheck
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(append(traceAttrs(adapter), attrs...)...),
+	)
+}
+
+// annotate sets attributes on the current span, if tracing is enabled.
+func (s *Sync) annotate(ctx context.Context, attrs ...attribute.KeyValue) {
+	if s.tracer != nil {
+		trace.SpanFromContext(ctx).SetAttributes(attrs...)
+	}
+}
+
+// endSpan records an error on a span, if there is one, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+/*
+WithTracerProvider enables OpenTelemetry tracing in Sync, using the given [trace.TracerProvider].
+
+Sync creates a span for each call to SyncWith, with child spans for calls to the source and destination adapters.
+Adapters receive the span in their context, so their HTTP clients can propagate it to third party services.
+
+Tracing is disabled by default. To use the global TracerProvider, pass [otel.GetTracerProvider].
+
+[otel.GetTracerProvider]: https://pkg.go.dev/go.opentelemetry.io/otel#GetTracerProvider
+*/
+func WithTracerProvider(provider trace.TracerProvider) func(*Sync) {
+	return func(s *Sync) {
+		s.tracer = provider.Tracer(TracerName)
+	}
+}
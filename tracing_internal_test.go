@@ -0,0 +1,154 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTestTracerProvider returns a TracerProvider that records spans in memory.
+func newTestTracerProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
+
+// spanAttrs converts the attributes of a span into a map for easier assertions.
+func spanAttrs(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	out := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+
+	for _, attr := range span.Attributes {
+		out[attr.Key] = attr.Value
+	}
+
+	return out
+}
+
+func TestSync_Tracing(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		provider, exporter := newTestTracerProvider()
+
+		var getCtx context.Context
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(mock.Anything).Return([]string{"foo", "bar"}, nil)
+
+		destination := &describedAdapter{NewMockAdapter(t)}
+		destination.EXPECT().Get(mock.Anything).RunAndReturn(func(ctx context.Context) ([]string, error) {
+			getCtx = ctx
+
+			return []string{"baz"}, nil
+		})
+		destination.EXPECT().Add(mock.Anything, mock.Anything).Return(nil)
+		destination.EXPECT().Remove(mock.Anything, []string{"baz"}).Return(nil)
+
+		err := New(source, WithTracerProvider(provider)).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 5)
+
+		names := make([]string, 0, len(spans))
+		for _, span := range spans {
+			names = append(names, span.Name)
+		}
+
+		assert.Equal(t, []string{
+			"gosync.source.Get",
+			"gosync.destination.Get",
+			"gosync.destination.remove",
+			"gosync.destination.add",
+			"gosync.SyncWith",
+		}, names)
+
+		root := spans[4]
+		for _, span := range spans[:4] {
+			assert.Equal(t, root.SpanContext.SpanID(), span.Parent.SpanID())
+		}
+
+		// Adapters receive the span in their context.
+		assert.Equal(t, spans[1].SpanContext.SpanID(), trace.SpanFromContext(getCtx).SpanContext().SpanID())
+
+		attrs := spanAttrs(root)
+		assert.Equal(t, "test/adapter", attrs[TraceKeyAdapter].AsString())
+		assert.Equal(t, "foo", attrs[TraceKeyTarget].AsString())
+		assert.Equal(t, string(RemoveAdd), attrs[TraceKeyMode].AsString())
+		assert.False(t, attrs[TraceKeyDryRun].AsBool())
+		assert.Equal(t, int64(2), attrs["gosync.add.count"].AsInt64())
+		assert.Equal(t, int64(1), attrs["gosync.remove.count"].AsInt64())
+
+		assert.Equal(t, "*gosync.MockAdapter", spanAttrs(spans[0])[TraceKeyAdapter].AsString())
+		assert.Equal(t, int64(2), spanAttrs(spans[0])[TraceKeyCount].AsInt64())
+		assert.Equal(t, int64(1), spanAttrs(spans[1])[TraceKeyCount].AsInt64())
+		assert.Equal(t, int64(2), spanAttrs(spans[3])[TraceKeyCount].AsInt64())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		provider, exporter := newTestTracerProvider()
+
+		testErr := errors.New("foo") //nolint:goerr113
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(mock.Anything).Return([]string{"foo"}, nil)
+
+		destination := NewMockAdapter(t)
+		destination.EXPECT().Get(mock.Anything).Return([]string{}, nil)
+		destination.EXPECT().Add(mock.Anything, []string{"foo"}).Return(testErr)
+
+		err := New(source, WithTracerProvider(provider)).SyncWith(ctx, destination)
+
+		require.ErrorIs(t, err, testErr)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 4)
+
+		assert.Equal(t, "gosync.destination.add", spans[2].Name)
+		assert.Equal(t, codes.Error, spans[2].Status.Code)
+		assert.Equal(t, "gosync.SyncWith", spans[3].Name)
+		assert.Equal(t, codes.Error, spans[3].Status.Code)
+		assert.Len(t, spans[3].Events, 1)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		t.Parallel()
+
+		provider, exporter := newTestTracerProvider()
+
+		source := NewMockAdapter(t)
+		source.EXPECT().Get(mock.Anything).Return([]string{"foo"}, nil)
+
+		destination := NewMockAdapter(t)
+		destination.EXPECT().Get(mock.Anything).Return([]string{}, nil)
+
+		err := New(source, WithTracerProvider(provider), func(s *Sync) {
+			s.DryRun = true
+		}).SyncWith(ctx, destination)
+
+		require.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 3)
+
+		attrs := spanAttrs(spans[2])
+		assert.True(t, attrs[TraceKeyDryRun].AsBool())
+		assert.Equal(t, int64(1), attrs["gosync.add.count"].AsInt64())
+	})
+}
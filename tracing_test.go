@@ -0,0 +1,42 @@
+package gosync_test
+
+import (
+	"context"
+	"log"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	gosync "github.com/ovotech/go-sync"
+	"github.com/ovotech/go-sync/adapters/github/team"
+	"github.com/ovotech/go-sync/adapters/slack/conversation"
+)
+
+func ExampleWithTracerProvider() {
+	ctx := context.Background()
+
+	// Configure a TracerProvider with an exporter for your tracing backend.
+	provider := sdktrace.NewTracerProvider()
+	defer func() { _ = provider.Shutdown(ctx) }()
+
+	source, err := team.Init(ctx, map[gosync.ConfigKey]string{
+		team.GitHubToken: "some-token",
+		team.GitHubOrg:   "my-org",
+		team.TeamSlug:    "my-team",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	destination, err := conversation.Init(ctx, map[gosync.ConfigKey]string{
+		conversation.SlackAPIKey: "some-key",
+		conversation.Name:        "example",
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	err = gosync.New(source, gosync.WithTracerProvider(provider)).SyncWith(ctx, destination)
+	if err != nil {
+		log.Panic(err)
+	}
+}
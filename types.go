@@ -11,15 +11,16 @@ type Adapter interface {
 
 // Service can be used for downstream services that implement Sync in your own workflow.
 type Service interface {
-	SyncWith(ctx context.Context, adapter Adapter) error // Sync the things in a source service with this service.
+	// Sync the things in a source service with this service, optionally overriding the service's defaults.
+	SyncWith(ctx context.Context, adapter Adapter, opts ...CallOption) error
 }
 
 // ConfigKey is a configuration key to Init a new adapter.
 type ConfigKey = string
 
-// A ConfigFn is used to pass additional or custom functionality to an adapter.
-type ConfigFn[T Adapter] func(T)
+// A ConfigFn is used to pass additional or custom functionality to an adapter, including a MultiAdapter.
+type ConfigFn[T any] func(T)
 
 // InitFn is an optional adapter function that can initialise a new adapter using a static configuration.
 // This is to make it easier to use an adapter in a CLI or other service that invokes adapters programmatically.
-type InitFn[T Adapter] func(context.Context, map[ConfigKey]string, ...ConfigFn[T]) (T, error)
+type InitFn[T any] func(context.Context, map[ConfigKey]string, ...ConfigFn[T]) (T, error)
@@ -0,0 +1,114 @@
+package gosync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+/*
+Validator is an optional interface for adapters to check their configuration before a sync, so that invalid credentials,
+missing permissions and targets that don't exist are reported up front rather than partway through a sync.
+
+Validate should not make any changes. Return ErrMissingPermission if the credentials don't grant a permission the
+adapter needs, and ErrNotFound if the target doesn't exist.
+*/
+type Validator interface {
+	Validate(ctx context.Context) error
+}
+
+/*
+Validate checks each adapter that implements Validator, and returns every failure joined together. Adapters that don't
+implement Validator are skipped.
+
+	if err := gosync.Validate(ctx, source, destination); err != nil {
+		log.Fatal(err)
+	}
+*/
+func Validate(ctx context.Context, adapters ...Adapter) error {
+	errs := make([]error, 0, len(adapters))
+
+	for _, adapter := range adapters {
+		validator, ok := adapter.(Validator)
+		if !ok {
+			continue
+		}
+
+		if err := validator.Validate(ctx); err != nil {
+			errs = append(errs, wrapError(adapter, PhaseValidate, nil, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+/*
+Validate checks the job's source, its destinations and the destinations in each of its waves. If the job has a
+Discovery, its destinations are discovered and checked too. See [gosync.Validate].
+*/
+func (j Job) Validate(ctx context.Context) error {
+	adapters := append([]Adapter{j.Source}, j.Destinations...)
+
+	for _, wave := range j.Waves {
+		adapters = append(adapters, wave.Destinations...)
+	}
+
+	errs := make([]error, 0)
+
+	if j.Discovery != nil {
+		discovered, err := j.Discovery.discover(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		adapters = append(adapters, discovered...)
+	}
+
+	errs = append(errs, Validate(ctx, adapters...))
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("job(%s).validate -> %w", j.Name, err)
+	}
+
+	return nil
+}
+
+// discover returns the destinations found by a Discovery, sorted by name.
+func (d Discovery) discover(ctx context.Context) ([]Adapter, error) {
+	if d.Discoverer == nil {
+		return nil, fmt.Errorf("discovery -> %w(discoverer)", ErrMissingConfig)
+	}
+
+	destinations, err := d.Discoverer.Discover(ctx, d.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("discovery.discover(%s) -> %w", d.Selector, err)
+	}
+
+	adapters := make([]Adapter, 0, len(destinations))
+	for _, name := range sortedKeys(destinations) {
+		adapters = append(adapters, destinations[name])
+	}
+
+	return adapters, nil
+}
+
+// Validate checks the adapters in every job, so that misconfigured jobs can be reported before the first run.
+func (r *Runner) Validate(ctx context.Context) error {
+	if err := r.validate(); err != nil {
+		return fmt.Errorf("runner.validate -> %w", err)
+	}
+
+	errs := make([]error, 0, len(r.jobs))
+
+	for _, job := range r.jobs {
+		if err := job.Validate(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("runner.validate -> %w", err)
+	}
+
+	return nil
+}
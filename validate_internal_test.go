@@ -0,0 +1,132 @@
+package gosync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validatingAdapter struct {
+	*describedAdapter
+	err error
+}
+
+func (v *validatingAdapter) Validate(_ context.Context) error {
+	return v.err
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		err := Validate(ctx, NewMockAdapter(t), &validatingAdapter{&describedAdapter{NewMockAdapter(t)}, nil})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Failures", func(t *testing.T) {
+		t.Parallel()
+
+		missingPermission := &validatingAdapter{
+			&describedAdapter{NewMockAdapter(t)},
+			fmt.Errorf("scopes -> %w(write)", ErrMissingPermission),
+		}
+		notFound := &validatingAdapter{&describedAdapter{NewMockAdapter(t)}, ErrNotFound}
+
+		err := Validate(ctx, missingPermission, NewMockAdapter(t), notFound)
+
+		var syncErr *Error
+
+		require.ErrorIs(t, err, ErrMissingPermission)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.ErrorAs(t, err, &syncErr)
+		assert.Equal(t, PhaseValidate, syncErr.Phase)
+		assert.Equal(t, "test/adapter", syncErr.Kind)
+	})
+}
+
+func TestRunner_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	runner := NewRunner([]Job{
+		{
+			Name:         "valid",
+			Source:       &validatingAdapter{&describedAdapter{NewMockAdapter(t)}, nil},
+			Destinations: []Adapter{NewMockAdapter(t)},
+		},
+		{
+			Name:         "invalid",
+			Source:       NewMockAdapter(t),
+			Destinations: []Adapter{&validatingAdapter{&describedAdapter{NewMockAdapter(t)}, ErrNotFound}},
+		},
+	})
+
+	err := runner.Validate(ctx)
+
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "job(invalid).validate")
+	assert.NotContains(t, err.Error(), "job(valid)")
+
+	err = NewRunner([]Job{{Name: "missing source"}}).Validate(ctx)
+
+	require.ErrorIs(t, err, ErrMissingConfig)
+}
+
+func TestJob_Validate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+
+	t.Run("Waves", func(t *testing.T) {
+		t.Parallel()
+
+		job := Job{
+			Name:   "waves",
+			Source: NewMockAdapter(t),
+			Waves: []Wave{{
+				Name:         "canary",
+				Destinations: []Adapter{&validatingAdapter{&describedAdapter{NewMockAdapter(t)}, ErrNotFound}},
+			}},
+		}
+
+		require.ErrorIs(t, job.Validate(ctx), ErrNotFound)
+	})
+
+	t.Run("Discovery", func(t *testing.T) {
+		t.Parallel()
+
+		discovered := &validatingAdapter{&describedAdapter{NewMockAdapter(t)}, ErrMissingPermission}
+
+		job := Job{
+			Name:   "discovery",
+			Source: NewMockAdapter(t),
+			Discovery: &Discovery{
+				Discoverer: &staticDiscoverer{destinations: map[string]Adapter{"team-a": discovered}},
+				Selector:   "team-*",
+			},
+		}
+
+		require.ErrorIs(t, job.Validate(ctx), ErrMissingPermission)
+	})
+
+	t.Run("Discovery fails", func(t *testing.T) {
+		t.Parallel()
+
+		job := Job{
+			Name:      "discovery",
+			Source:    NewMockAdapter(t),
+			Discovery: &Discovery{Discoverer: &staticDiscoverer{err: ErrNotFound}},
+		}
+
+		require.ErrorIs(t, job.Validate(ctx), ErrNotFound)
+	})
+}